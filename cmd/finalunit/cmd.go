@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/wimspaargaren/final-unit/internal/evo"
 )
 
 // Default constants
@@ -12,9 +13,13 @@ const (
 	DefaultTestCasesPerFunc = 18
 	// Currently the best way to detect cycles is to count
 	// the amount of times some struct is created
-	DefaultAmountRecursion = 3
-	DefaultNoImprovedGens  = 10
-	DefaultTargetFitness   = 0.95
+	DefaultAmountRecursion  = 3
+	DefaultNoImprovedGens   = 10
+	DefaultTargetFitness    = 0.95
+	DefaultCapturePasses    = 2
+	DefaultAdaptiveMaxCases = 50
+	// Generic functions are tested with a single set of type arguments by default
+	DefaultGenericInstantiations = 1
 )
 
 func initCmd(globalOpts *Opts) *cobra.Command {
@@ -36,6 +41,94 @@ func initCmd(globalOpts *Opts) *cobra.Command {
 			if target <= 0 || target > 1 {
 				return fmt.Errorf("--target-fitness flag must between 0 and 1")
 			}
+
+			capturePasses, err := cmd.Flags().GetInt("capture-passes")
+			if err != nil {
+				return err
+			}
+			if capturePasses < DefaultCapturePasses {
+				return fmt.Errorf("--capture-passes flag must be at least %d", DefaultCapturePasses)
+			}
+
+			adaptiveMaxCases, err := cmd.Flags().GetInt("adaptive-max-cases")
+			if err != nil {
+				return err
+			}
+			if adaptiveMaxCases < 1 {
+				return fmt.Errorf("--adaptive-max-cases flag must be at least 1")
+			}
+
+			generations, err := cmd.Flags().GetInt("generations")
+			if err != nil {
+				return err
+			}
+			if generations < 0 {
+				return fmt.Errorf("--generations flag must be at least 0")
+			}
+
+			captureConcurrency, err := cmd.Flags().GetInt("capture-concurrency")
+			if err != nil {
+				return err
+			}
+			if captureConcurrency < 0 {
+				return fmt.Errorf("--capture-concurrency flag must be at least 0")
+			}
+
+			selection, err := cmd.Flags().GetString("selection")
+			if err != nil {
+				return err
+			}
+			if _, err := evo.NewSelector(evo.Selection(selection)); err != nil {
+				return fmt.Errorf("--selection flag must be one of pool, elitism, roulette or tournament")
+			}
+
+			valueBudget, err := cmd.Flags().GetInt("value-budget")
+			if err != nil {
+				return err
+			}
+			if valueBudget < 0 {
+				return fmt.Errorf("--value-budget flag must be at least 0")
+			}
+
+			aliasBias, err := cmd.Flags().GetFloat64("alias-bias")
+			if err != nil {
+				return err
+			}
+			if aliasBias < 0 || aliasBias > 1 {
+				return fmt.Errorf("--alias-bias flag must between 0 and 1")
+			}
+
+			returnedFuncCalls, err := cmd.Flags().GetInt("returned-func-calls")
+			if err != nil {
+				return err
+			}
+			if returnedFuncCalls < 0 {
+				return fmt.Errorf("--returned-func-calls flag must be at least 0")
+			}
+
+			branchHintBias, err := cmd.Flags().GetFloat64("branch-hint-bias")
+			if err != nil {
+				return err
+			}
+			if branchHintBias < 0 || branchHintBias > 1 {
+				return fmt.Errorf("--branch-hint-bias flag must between 0 and 1")
+			}
+
+			typedNilBias, err := cmd.Flags().GetFloat64("typed-nil-bias")
+			if err != nil {
+				return err
+			}
+			if typedNilBias < 0 || typedNilBias > 1 {
+				return fmt.Errorf("--typed-nil-bias flag must between 0 and 1")
+			}
+
+			lenBoundaryBias, err := cmd.Flags().GetFloat64("len-boundary-bias")
+			if err != nil {
+				return err
+			}
+			if lenBoundaryBias < 0 || lenBoundaryBias > 1 {
+				return fmt.Errorf("--len-boundary-bias flag must between 0 and 1")
+			}
 			return nil
 		},
 	}
@@ -43,12 +136,66 @@ func initCmd(globalOpts *Opts) *cobra.Command {
 	rootCmd.Flags().StringVarP(&globalOpts.Dir, "dir", "d", ".", "Dir for which to execute the generator")
 	rootCmd.PersistentFlags().BoolVarP(&globalOpts.Debug, "debug", "D", false, "Run generator in debug mode")
 	rootCmd.PersistentFlags().BoolVarP(&globalOpts.Verbose, "verbose", "v", false, "Run generator in verbose mode")
+	rootCmd.Flags().BoolVar(&globalOpts.Summary, "summary", false, "Print the amount of generated tests and the package coverage before and after generation")
 	// gen opts
 	rootCmd.Flags().IntVar(&globalOpts.OrganismAmount, "org-amount", DefaultPopulationSize, "Set amount of organisms in the population")
 	rootCmd.Flags().IntVar(&globalOpts.TestCasesPerFunc, "test-cases-func", DefaultTestCasesPerFunc, "Set amount of test cases created for every function")
 	rootCmd.Flags().IntVar(&globalOpts.MaxRecursion, "max-recursion", DefaultAmountRecursion, "Set the amount of times one struct is created")
+	rootCmd.Flags().Float64Var(&globalOpts.AliasBias, "alias-bias", 0, "Set probability between 0 and 1 of passing the same pointer, slice or map for multiple parameters of the same type")
+	rootCmd.Flags().Float64Var(&globalOpts.BranchHintBias, "branch-hint-bias", 0, "Set probability between 0 and 1 of using a constant a parameter is compared against in the function body, or a value next to it")
+	rootCmd.Flags().StringToStringVar(&globalOpts.Comparers, "comparer", nil, "Register a comparison expression template per type, e.g. Cache='{{.Actual}}.Same({{.Expected}})'")
+	rootCmd.Flags().BoolVar(&globalOpts.Allocs, "allocs", false, "Assert the allocations of calling a function, measured using testing.AllocsPerRun, don't exceed the amount measured when generating the tests")
+	rootCmd.Flags().StringVar(&globalOpts.AssertPackage, "assert-pkg", "", "Set import path of a custom assertion package, of which the functions are mapped using --assert-funcs")
+	rootCmd.Flags().StringToStringVar(&globalOpts.AssertFuncs, "assert-funcs", nil, "Map assertions to functions of the custom assertion package, called with the testing.T followed by the expected and actual value, e.g. EqualValues=myassert.Eq")
+	rootCmd.Flags().BoolVar(&globalOpts.ChanFactories, "chan-factories", false, "Create channels by calling a constructor of the package returning a channel of the type, instead of making a channel")
+	rootCmd.Flags().BoolVar(&globalOpts.CmpAssertions, "cmp-assertions", false, "Assert structs, slices and maps as a whole using go-cmp, ignoring the order of slices and the difference between nil and empty")
+	rootCmd.Flags().IntVar(&globalOpts.ConcurrentInvocations, "concurrent-invocations", 0, "Set amount of goroutines calling functions spawning goroutines or accessing package level variables concurrently, so data races are detected by go test -race, if 0 functions aren't called concurrently")
+	rootCmd.Flags().StringVar(&globalOpts.Corpus, "corpus", "", "Path to a file with values used for basic types next to random values and texts of types implementing encoding.TextUnmarshaler, one per line prefixed by their type, e.g. 'string alice@example.com'")
+	rootCmd.Flags().StringVar(&globalOpts.HeaderTemplate, "header-template", "", "Template rendered at the top of every generated test file instead of the default header, e.g. '{{.Header}}. DO NOT EDIT.'")
+	rootCmd.Flags().StringVar(&globalOpts.TestNameTemplate, "test-name-template", "", "Template rendering the names of the generated test methods, with access to .Suite, .Func and .Index, e.g. 'Test_{{.Func}}_{{.Index}}'")
+	rootCmd.Flags().BoolVar(&globalOpts.DefaultTags, "default-tags", false, "Initialize struct fields tagged with default to their declared default value as one of the generated variants")
+	rootCmd.Flags().BoolVar(&globalOpts.ExportShims, "export-shims", false, "Call unexported functions through exported wrappers generated in finalunit_export_test.go, which are reachable from tests in the external test package as well")
+	rootCmd.Flags().BoolVar(&globalOpts.FilePerFunc, "file-per-func", false, "Generate a test file per function named after the function, sharing synthetic declarations via a common file")
+	rootCmd.Flags().IntVar(&globalOpts.GenericInstantiations, "generic-instantiations", DefaultGenericInstantiations, "Set amount of distinct sets of type arguments generic functions are tested with, picked from the constraints of the type parameters")
+	rootCmd.Flags().BoolVar(&globalOpts.Gomock, "gomock", false, "Use the mocks generated by mockgen for interfaces of the package, instead of synthetic implementations")
+	rootCmd.Flags().BoolVar(&globalOpts.GoroutineLeaks, "goroutine-leaks", false, "Verify that functions spawning goroutines don't leak them")
+	rootCmd.Flags().BoolVar(&globalOpts.InterfaceImplementers, "interface-implementers", false, "Pass a mix of the types of the package implementing the interface and a synthetic implementation for interface parameters, types implementing it through pointer receivers are passed by address")
+	rootCmd.Flags().Float64Var(&globalOpts.LenBoundaryBias, "len-boundary-bias", 0, "Set probability between 0 and 1 of using the boundary lengths 0, 1 or max for slices")
+	rootCmd.Flags().BoolVar(&globalOpts.Helpers, "helpers", false, "Hoist the construction of values shared by multiple test cases into helper functions taking testing.TB")
+	rootCmd.Flags().BoolVar(&globalOpts.LogAssertions, "log-assertions", false, "Log expected and actual values instead of asserting them, generated tests never fail")
+	rootCmd.Flags().BoolVar(&globalOpts.NonNilPointers, "non-nil-pointers", false, "Generate all pointers as the address of a value instead of sometimes nil, terminating recursive structs with a pointer to an empty struct")
+	rootCmd.Flags().BoolVar(&globalOpts.MixedInterfaceSlices, "mixed-interface-slices", false, "Fill slices of interfaces with a mix of the types of the package implementing the interface and a synthetic implementation")
+	rootCmd.Flags().Float64Var(&globalOpts.OverflowBias, "overflow-bias", 0, "Set probability between 0 and 1 of using the min or max value of an integer parameter, which is added or multiplied in the function body")
+	rootCmd.Flags().BoolVar(&globalOpts.PanicReports, "panic-reports", false, "Emit test cases which panic as skipped failing tests documenting the panic value and stack, instead of asserting the panic")
+	rootCmd.Flags().BoolVar(&globalOpts.PromotedMethods, "promoted-methods", false, "Generate test cases for methods promoted by embedding types of imported packages")
+	rootCmd.Flags().BoolVar(&globalOpts.ReachableMethodsOnly, "reachable-methods-only", false, "Return zero values from all interface implementation methods, which aren't statically reachable from the function under test")
+	rootCmd.Flags().IntVar(&globalOpts.ReturnedFuncCalls, "returned-func-calls", 0, "Set amount of times a returned func is invoked, asserting the result of every call, if 0 returned funcs aren't invoked")
+	rootCmd.Flags().BoolVar(&globalOpts.Scaffold, "scaffold", false, "Only generate the inputs and calls of the test cases, leaving a TODO placeholder to assert the results manually")
+	rootCmd.Flags().BoolVar(&globalOpts.SeedOffsets, "seed-offsets", false, "Seed every test case with its own seed offset, which is logged in debug mode")
+	rootCmd.Flags().BoolVar(&globalOpts.SideEffectAssertions, "side-effect-assertions", false, "Assert the receiver and the pointer, slice and map arguments after calling a function, capturing their mutations")
+	rootCmd.Flags().StringSliceVar(&globalOpts.SkipAssertFields, "skip-assert-fields", nil, "Skip asserting struct fields of which the name matches one of the regular expressions, e.g. '.*ID$,.*At$'")
+	rootCmd.Flags().BoolVar(&globalOpts.SkipResultAssertions, "skip-result-assertions", false, "Omit the assertions on the values returned by functions")
+	rootCmd.Flags().BoolVar(&globalOpts.SourcePositions, "source-positions", false, "Add a comment with the source position of the function under test to every test case")
+	rootCmd.Flags().BoolVar(&globalOpts.SignalChannels, "signal-channels", false, "Create channels of type chan struct{} which are closed or contain a signal, so receiving from them doesn't block")
+	rootCmd.Flags().BoolVar(&globalOpts.StringerAssertions, "stringer-assertions", false, "Assert returned values of types implementing fmt.Stringer by comparing the result of String, instead of their fields")
+	rootCmd.Flags().BoolVar(&globalOpts.SyncMapEntries, "sync-map-entries", false, "Populate pointers to a sync.Map using Store calls, instead of passing an empty map")
+	rootCmd.Flags().BoolVar(&globalOpts.TempFiles, "temp-files", false, "Create *os.File parameters using temp files filled with generated content, which are removed after the test")
+	rootCmd.Flags().BoolVar(&globalOpts.TestMain, "test-main", false, "Emit a TestMain calling the functions specified by the setup and teardown directives around running the tests, skipped if a test file of the package already declares a TestMain")
+	rootCmd.Flags().BoolVar(&globalOpts.TextUnmarshaler, "text-unmarshaler", false, "Create values for types implementing encoding.TextUnmarshaler by unmarshalling a text of the corpus, a string constant of the type or a generated string")
+	rootCmd.Flags().Float64Var(&globalOpts.TypedNilBias, "typed-nil-bias", 0, "Set probability between 0 and 1 of using a typed nil pointer as interface value, which isn't equal to nil")
+	rootCmd.Flags().IntVar(&globalOpts.ValueBudget, "value-budget", 0, "Set max amount of elements of a generated collection including its nested collections, if 0 the size is unbounded")
+	rootCmd.Flags().BoolVar(&globalOpts.WholeSliceAssertions, "whole-slice-assertions", false, "Assert slices of basic elements as a whole using a slice literal, instead of asserting every element")
+	rootCmd.Flags().BoolVar(&globalOpts.WrappedErrors, "wrapped-errors", false, "Create error values wrapping a generated inner error using fmt.Errorf with %w, sometimes using an error type of the package")
+	rootCmd.Flags().BoolVar(&globalOpts.ZeroValueBodies, "zero-value-bodies", false, "Return zero values from interface implementation methods with expensive return types, which aren't called by the function under test")
 	// population opts
+	rootCmd.Flags().BoolVar(&globalOpts.Adaptive, "adaptive", false, "Keep only the test cases covering new statements, generating cases per function until coverage plateaus")
+	rootCmd.Flags().IntVar(&globalOpts.AdaptiveMaxCases, "adaptive-max-cases", DefaultAdaptiveMaxCases, "Set max amount of test cases evaluated per function in adaptive mode")
+	rootCmd.Flags().IntVar(&globalOpts.CaptureConcurrency, "capture-concurrency", 0, "Set amount of functions of which runtime values are captured concurrently, each in a separate go test process, if 0 all functions are captured in a single run")
+	rootCmd.Flags().IntVar(&globalOpts.CapturePasses, "capture-passes", DefaultCapturePasses, "Set amount of times runtime values are captured, with more than two passes test cases are accepted if a majority agrees")
+	rootCmd.Flags().IntVar(&globalOpts.Generations, "generations", 0, "Set amount of generations the population evolves, if 0 it evolves until the target fitness is hit or no improvements are found")
 	rootCmd.Flags().IntVar(&globalOpts.MaxNoImprovGens, "no-improve-gens", DefaultNoImprovedGens, "Set max amount of generations without improvements before the generator halts ")
+	rootCmd.Flags().StringVar(&globalOpts.Snapshot, "snapshot", "", "Path to a file storing the captured results per test case, asserting the previous result of equivalent test cases of which the result changed")
+	rootCmd.Flags().StringVar((*string)(&globalOpts.Selection), "selection", string(evo.SelectionPool), "Set strategy selecting the parents of the next generation: pool, elitism, roulette or tournament, elitism keeps the best organism")
 	rootCmd.Flags().Float64Var(&globalOpts.Target, "target-fitness", DefaultTargetFitness, "Set number between 0 and 1 indicating the target coverage we try to hit")
 
 	return rootCmd
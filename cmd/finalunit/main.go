@@ -1,11 +1,14 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/wimspaargaren/final-unit/internal/evo"
 	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/tmplexec"
 	"github.com/wimspaargaren/final-unit/pkg/seed"
 )
 
@@ -53,6 +56,15 @@ func Generate(dir string, opts *Opts) {
 		log.Fatal("something unexpected went wrong trying to generate the test cases")
 	}
 
+	coverageBefore := 0.0
+	if opts.Summary {
+		coverageBefore, err = measureCoverage(dir)
+		if err != nil {
+			log.WithError(err).Debug("unable to measure coverage before generation")
+			log.Fatal("something unexpected went wrong trying to generate the test cases")
+		}
+	}
+
 	log.Infof("creating first generation")
 	population, err := evo.NewPopulation(dir, generator, opts.PopulationOpts)
 	if err != nil {
@@ -65,4 +77,23 @@ func Generate(dir string, opts *Opts) {
 		log.WithError(err).Debug("unable to evolve the population")
 		log.Fatal("something unexpected went wrong trying to generate the test cases")
 	}
+	if opts.Summary {
+		coverageAfter, err := measureCoverage(dir)
+		if err != nil {
+			log.WithError(err).Debug("unable to measure coverage after generation")
+			log.Fatal("something unexpected went wrong trying to generate the test cases")
+		}
+		fmt.Println(evo.NewSummary(population.BestFit, coverageBefore, coverageAfter))
+	}
+}
+
+// measureCoverage measures the coverage of the tests of the package, failing tests are reported instead
+// of aborting the run, as the coverage is measured using the passing tests
+func measureCoverage(dir string) (float64, error) {
+	coverage, err := tmplexec.MeasureCoverage(dir)
+	if errors.Is(err, tmplexec.ErrTestsFailed) {
+		log.WithError(err).Warning("tests of the package fail, the coverage is measured using the passing tests")
+		return coverage, nil
+	}
+	return coverage, err
 }
@@ -12,6 +12,8 @@ type Opts struct {
 	Version bool
 	Dir     string
 	Debug   bool
+	// Summary prints the amount of generated tests and the package coverage before and after generation
+	Summary bool
 
 	gen.Options
 	evo.PopulationOpts
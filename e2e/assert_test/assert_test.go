@@ -152,6 +152,165 @@ func (s *E2EResultSuite) TestOutputs() {
 	}
 }
 
+func (s *E2EResultSuite) TestFallibleConstructor() {
+	opts := &gen.Options{
+		OrganismAmount:   1,
+		MaxRecursion:     3,
+		TestCasesPerFunc: 1,
+	}
+	seed.SetRandomSeed(1)
+	g, err := gen.New("examples/fallible_constructor", opts)
+	s.Require().NoError(err)
+	organisms := g.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	path, err := filepath.Abs("examples/fallible_constructor")
+	s.Require().NoError(err)
+	organism := organisms[0]
+
+	// The constructor rejects the generated endpoint, which skips the test case instead of aborting the capture
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path})
+	res, err := valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, true)
+	res, err = valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, false)
+
+	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: true})
+	out, err := assertExecutor.Execute(organism)
+	s.Require().NoError(err)
+	s.Contains(out, "NewClient rejected the generated arguments: missing host")
+	s.Contains(out, "--- SKIP: TestClientSuite/TestFetch0")
+}
+
+func (s *E2EResultSuite) TestTextUnmarshalerFallback() {
+	opts := &gen.Options{
+		OrganismAmount:   1,
+		MaxRecursion:     3,
+		TestCasesPerFunc: 1,
+		TextUnmarshaler:  true,
+	}
+	seed.SetRandomSeed(1)
+	g, err := gen.New("examples/text_unmarshaler", opts)
+	s.Require().NoError(err)
+	organisms := g.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	path, err := filepath.Abs("examples/text_unmarshaler")
+	s.Require().NoError(err)
+	organism := organisms[0]
+
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path})
+	res, err := valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, true)
+	res, err = valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, false)
+
+	// The generated text is rejected, so the email is constructed field by field instead of using the zero value
+	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: true})
+	out, err := assertExecutor.Execute(organism)
+	s.Require().NoError(err)
+	s.NotContains(out, "email without domain")
+	s.Contains(out, "--- PASS: TestEmailSuite/TestDomain0")
+}
+
+func (s *E2EResultSuite) TestTimes() {
+	opts := &gen.Options{
+		OrganismAmount:   1,
+		MaxRecursion:     3,
+		TestCasesPerFunc: 1,
+	}
+	seed.SetRandomSeed(1)
+	g, err := gen.New("examples/times", opts)
+	s.Require().NoError(err)
+	organisms := g.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	path, err := filepath.Abs("examples/times")
+	s.Require().NoError(err)
+	organism := organisms[0]
+
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path})
+	res, err := valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, true)
+	res, err = valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, false)
+
+	// The zero time and times outside the range of nanoseconds since the epoch are asserted as well
+	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: true})
+	out, err := assertExecutor.Execute(organism)
+	s.Require().NoError(err)
+	s.Contains(out, "--- PASS: TestTimesSuite/TestUnset0")
+	s.Contains(out, "--- PASS: TestTimesSuite/TestFounded0")
+	s.Contains(out, "--- PASS: TestTimesSuite/TestMillennium0")
+}
+
+func (s *E2EResultSuite) TestOracleModifiedArguments() {
+	opts := &gen.Options{
+		OrganismAmount:   1,
+		MaxRecursion:     3,
+		TestCasesPerFunc: 1,
+	}
+	seed.SetRandomSeed(1)
+	g, err := gen.New("examples/oracle", opts)
+	s.Require().NoError(err)
+	organisms := g.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	path, err := filepath.Abs("examples/oracle")
+	s.Require().NoError(err)
+	organism := organisms[0]
+
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path})
+	res, err := valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, true)
+	res, err = valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, false)
+
+	// The function reverses its argument in place, the oracle receives the argument before it's reversed
+	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: true})
+	out, err := assertExecutor.Execute(organism)
+	s.Require().NoError(err)
+	s.Contains(out, "--- PASS: TestOracleSuite/TestReverse0")
+}
+
+func (s *E2EResultSuite) TestGoroutineLeaks() {
+	opts := &gen.Options{
+		OrganismAmount:   1,
+		MaxRecursion:     3,
+		TestCasesPerFunc: 1,
+		GoroutineLeaks:   true,
+	}
+	seed.SetRandomSeed(1)
+	g, err := gen.New("examples/goroutine_leaks", opts)
+	s.Require().NoError(err)
+	organisms := g.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	path, err := filepath.Abs("examples/goroutine_leaks")
+	s.Require().NoError(err)
+	organism := organisms[0]
+
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path})
+	res, err := valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, true)
+	res, err = valueExecutor.Execute(organism)
+	s.Require().NoError(err)
+	organism.UpdateAssertStmts(res, false)
+
+	// The test of the leaking function fails, which is reported instead of failing the generation
+	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: true})
+	out, err := assertExecutor.Execute(organism)
+	s.Require().NoError(err)
+	s.Contains(out, "goroutine leak detected")
+	s.Contains(out, "--- FAIL: TestGoroutinesSuite/TestLeak0")
+	s.Contains(out, "--- PASS: TestGoroutinesSuite/TestSum0")
+	s.Contains(out, "--- PASS: TestGoroutinesSuite/TestDouble0")
+}
+
 func TestE2EResultSuite(t *testing.T) {
 	suite.Run(t, new(E2EResultSuite))
 }
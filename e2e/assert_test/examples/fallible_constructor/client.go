@@ -0,0 +1,28 @@
+package fallibleconstructor
+
+import (
+	"errors"
+	"net/url"
+)
+
+// Client a client which must be created using its fallible constructor
+type Client struct {
+	endpoint *url.URL
+}
+
+// NewClient creates a new client for given endpoint
+func NewClient(endpoint string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host == "" {
+		return nil, errors.New("missing host")
+	}
+	return &Client{endpoint: u}, nil
+}
+
+// Fetch fetches the resource at given path
+func Fetch(c *Client, path string) string {
+	return c.endpoint.JoinPath(path).String()
+}
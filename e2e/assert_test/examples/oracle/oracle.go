@@ -0,0 +1,18 @@
+package oracle
+
+// Reverse reverses the values in place
+// final-unit:oracle reversed
+func Reverse(values []int) []int {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	return values
+}
+
+func reversed(values []int) []int {
+	res := make([]int, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		res = append(res, values[i])
+	}
+	return res
+}
@@ -0,0 +1,31 @@
+package textunmarshaler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Email email address which is validated on construction
+type Email struct {
+	local  string
+	domain string
+}
+
+// UnmarshalText parses and validates an email address
+func (e *Email) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid email address: %s", text)
+	}
+	e.local = parts[0]
+	e.domain = parts[1]
+	return nil
+}
+
+// Domain returns the domain of an email address, which panics for the zero value
+func Domain(e Email) string {
+	if e.domain == "" {
+		panic("email without domain")
+	}
+	return e.domain
+}
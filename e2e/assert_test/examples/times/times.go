@@ -0,0 +1,18 @@
+package times
+
+import "time"
+
+// Unset returns the zero time
+func Unset() time.Time {
+	return time.Time{}
+}
+
+// Founded returns a time before 1678, of which the nanoseconds since the epoch overflow
+func Founded() time.Time {
+	return time.Date(1600, time.March, 1, 12, 0, 0, 250, time.UTC)
+}
+
+// Millennium returns the start of the millennium
+func Millennium() time.Time {
+	return time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
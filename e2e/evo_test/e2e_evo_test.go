@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -61,6 +64,9 @@ func (s *EvoTestSuite) TestInputExamples() {
 	for _, f := range files {
 		s.Run(f.Name(), func() {
 			dir := "../../test/data/inputs/" + f.Name()
+			if !isModuleExample(dir) {
+				s.T().Skip("example can't be tested in the module")
+			}
 
 			genOpts := &gen.Options{
 				OrganismAmount:   1,
@@ -83,6 +89,25 @@ func (s *EvoTestSuite) TestInputExamples() {
 	}
 }
 
+// isModuleExample checks if the tests generated for an example compile in the module. Examples which are separate
+// modules, or constrained to a newer go version than the module e.g. for generics, only test the generation
+func isModuleExample(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+		return false
+	}
+	fileNames, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return false
+	}
+	for _, fileName := range fileNames {
+		content, err := ioutil.ReadFile(filepath.Clean(fileName))
+		if err != nil || strings.HasPrefix(string(content), "//go:build go1.") {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *EvoTestSuite) TestOutputExamples() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -0,0 +1,165 @@
+// Package corpus provides functionality for reading a corpus of user supplied values,
+// which are used as realistic input values for generated test cases
+package corpus
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidCorpus invalid line in corpus file
+var ErrInvalidCorpus = fmt.Errorf("invalid corpus")
+
+// Corpus user supplied value expressions per basic type name, and texts per name of a type
+// implementing encoding.TextUnmarshaler, types of other packages are qualified e.g. mail.Address
+type Corpus struct {
+	Values map[string][]ast.Expr
+}
+
+// Load reads the corpus file at given path, an empty corpus is returned if no path is given
+func Load(path string) (*Corpus, error) {
+	if path == "" {
+		return &Corpus{Values: make(map[string][]ast.Expr)}, nil
+	}
+	// nolint: gosec
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return Parse(f)
+}
+
+// Parse parses a corpus consisting of one typed value per line e.g. "string alice@example.com".
+// String values are taken literally, other values are go literals of the given basic type.
+// Values of other types are texts which are unmarshalled by the type, e.g. "Email alice@example.com".
+// Empty lines and lines starting with # are skipped
+func Parse(r io.Reader) (*Corpus, error) {
+	res := &Corpus{Values: make(map[string][]ast.Expr)}
+	scanner := bufio.NewScanner(r)
+	lineNr := 0
+	for scanner.Scan() {
+		lineNr++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		typeName, value := splitLine(line)
+		expr, err := valueExpr(typeName, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidCorpus, lineNr, err.Error())
+		}
+		res.Values[typeName] = append(res.Values[typeName], expr)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Get retrieves the value expressions for given type name
+func (c *Corpus) Get(typeName string) []ast.Expr {
+	if c == nil {
+		return nil
+	}
+	return c.Values[typeName]
+}
+
+func splitLine(line string) (string, string) {
+	index := strings.IndexAny(line, " \t")
+	if index == -1 {
+		return line, ""
+	}
+	return line[:index], strings.TrimSpace(line[index+1:])
+}
+
+// valueExpr creates the expression of a value for given basic type name,
+// numeric values are converted to their type, unless the literal already has the type
+func valueExpr(typeName, value string) (ast.Expr, error) {
+	switch typeName {
+	case "string":
+		return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(value)}, nil
+	case "bool":
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("expected true or false, got: %s", value)
+		}
+		return &ast.Ident{Name: value}, nil
+	case "int":
+		return literal(value, token.INT, token.CHAR)
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune":
+		return conversion(typeName, value, token.INT, token.CHAR)
+	case "float32", "float64":
+		return conversion(typeName, value, token.INT, token.FLOAT)
+	case "complex64", "complex128":
+		return conversion(typeName, value, token.INT, token.FLOAT, token.IMAG)
+	default:
+		if !isTextTypeName(typeName) {
+			return nil, fmt.Errorf("unsupported type: %s", typeName)
+		}
+		return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(value)}, nil
+	}
+}
+
+// isTextTypeName checks if given name refers to a declared type, optionally qualified by its package,
+// of which the values are texts. Predeclared types other than the basic types are unsupported
+func isTextTypeName(typeName string) bool {
+	parts := strings.Split(typeName, ".")
+	if len(parts) > 2 {
+		return false
+	}
+	for _, part := range parts {
+		if !token.IsIdentifier(part) {
+			return false
+		}
+	}
+	return len(parts) == 2 || types.Universe.Lookup(typeName) == nil
+}
+
+func conversion(typeName, value string, kinds ...token.Token) (ast.Expr, error) {
+	lit, err := literal(value, kinds...)
+	if err != nil {
+		return nil, err
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.Ident{Name: typeName},
+		Args: []ast.Expr{lit},
+	}, nil
+}
+
+// literal parses a basic literal of one of the given kinds, which may be negated
+func literal(value string, kinds ...token.Token) (ast.Expr, error) {
+	expr, err := parser.ParseExpr(value)
+	if err != nil {
+		return nil, err
+	}
+	unaryExpr, negative := expr.(*ast.UnaryExpr)
+	if negative && unaryExpr.Op == token.SUB {
+		expr = unaryExpr.X
+	}
+	basicLit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return nil, fmt.Errorf("expected literal, got: %s", value)
+	}
+	for _, kind := range kinds {
+		if basicLit.Kind != kind {
+			continue
+		}
+		// Positions of the parsed literal are omitted, since they don't refer to the generated file
+		res := ast.Expr(&ast.BasicLit{Kind: basicLit.Kind, Value: basicLit.Value})
+		if negative {
+			res = &ast.UnaryExpr{Op: token.SUB, X: res}
+		}
+		return res, nil
+	}
+	return nil, fmt.Errorf("unexpected literal: %s", value)
+}
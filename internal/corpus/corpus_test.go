@@ -0,0 +1,82 @@
+package corpus
+
+import (
+	"errors"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CorpusTestSuite struct {
+	suite.Suite
+}
+
+func (s *CorpusTestSuite) TestParse() {
+	corpus, err := Parse(strings.NewReader(`# names
+string Alice Smith
+string alice@example.com
+
+int -42
+uint8 7
+float64 3
+rune 'a'
+bool true
+Email bob@example.com
+mail.Address Carol <carol@example.com>
+`))
+	s.Require().NoError(err)
+	tests := []struct {
+		Type     string
+		Expected []string
+	}{
+		{Type: "string", Expected: []string{`"Alice Smith"`, `"alice@example.com"`}},
+		{Type: "int", Expected: []string{"-42"}},
+		{Type: "uint8", Expected: []string{"uint8(7)"}},
+		{Type: "float64", Expected: []string{"float64(3)"}},
+		{Type: "rune", Expected: []string{"rune('a')"}},
+		{Type: "bool", Expected: []string{"true"}},
+		{Type: "int64", Expected: []string{}},
+		{Type: "Email", Expected: []string{`"bob@example.com"`}},
+		{Type: "mail.Address", Expected: []string{`"Carol <carol@example.com>"`}},
+	}
+	for _, test := range tests {
+		s.Run(test.Type, func() {
+			res := []string{}
+			for _, expr := range corpus.Get(test.Type) {
+				res = append(res, types.ExprString(expr))
+			}
+			s.Equal(test.Expected, res)
+		})
+	}
+}
+
+func (s *CorpusTestSuite) TestParseInvalid() {
+	tests := []struct {
+		Name  string
+		Input string
+	}{
+		{Name: "unsupported type", Input: "error oops"},
+		{Name: "invalid bool", Input: "bool yes"},
+		{Name: "float for int", Input: "int 3.5"},
+		{Name: "no literal", Input: "int x"},
+		{Name: "invalid type name", Input: "mail.Address.Name carol"},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			_, err := Parse(strings.NewReader(test.Input))
+			s.True(errors.Is(err, ErrInvalidCorpus))
+		})
+	}
+}
+
+func (s *CorpusTestSuite) TestLoadNoPath() {
+	corpus, err := Load("")
+	s.Require().NoError(err)
+	s.Empty(corpus.Get("string"))
+}
+
+func TestCorpusSuite(t *testing.T) {
+	suite.Run(t, new(CorpusTestSuite))
+}
@@ -26,11 +26,29 @@ var (
 	ErrExpectedOneRetrunVal      = fmt.Errorf("expected only one return value")
 	ErrDecoratorFuncNameNotFound = fmt.Errorf("decorator func name not found")
 	ErrMissingFileName           = fmt.Errorf("missing file name")
+	ErrIncorrectGenerations      = fmt.Errorf("incorrect generations")
+	ErrIncorrectCleanup          = fmt.Errorf("incorrect cleanup")
 )
 
 // Deco result of a decorator file
 type Deco struct {
 	Files map[string]*File
+	// Generations amount of generations the population evolves, zero if not specified
+	Generations int
+	// MaxDepths amount of times a value of a type is created per value, overriding the global max recursion,
+	// specified by max-depth directives per type name
+	MaxDepths map[string]int
+	// Setup function setting up the fixture shared by the tests of the package, called by TestMain
+	Setup *FixtureFunc
+	// Teardown function tearing down the fixture shared by the tests of the package, called by TestMain
+	Teardown *FixtureFunc
+}
+
+// FixtureFunc function specified by a setup or teardown directive
+type FixtureFunc struct {
+	Name string
+	// ReturnsError indicates if the function returns an error, which fails the tests of the package
+	ReturnsError bool
 }
 
 // HasReceiverVal checks if a receiver val is specified
@@ -102,6 +120,159 @@ func (d *Deco) HasVal(fileName, funcName, paramName string) bool {
 	return len(param.Values) != 0
 }
 
+// GetExpectErrors retrieves the expect error directives for given file and func
+func (d *Deco) GetExpectErrors(fileName, funcName string) []*ExpectError {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return []*ExpectError{}
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return []*ExpectError{}
+	}
+	return function.ExpectErrors
+}
+
+// GetInvariants retrieves the invariant directives for given file and func
+func (d *Deco) GetInvariants(fileName, funcName string) []string {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return []string{}
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return []string{}
+	}
+	return function.Invariants
+}
+
+// GetOracle retrieves the function computing the expected results for given file and func,
+// an empty string is returned if no oracle directive is specified
+func (d *Deco) GetOracle(fileName, funcName string) string {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return ""
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return ""
+	}
+	return function.Oracle
+}
+
+// IsIdempotent checks if given file and func are marked idempotent by an idempotent directive
+func (d *Deco) IsIdempotent(fileName, funcName string) bool {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return false
+	}
+	return function.Idempotent
+}
+
+// IsPure checks if given file and func are marked pure by a pure directive
+func (d *Deco) IsPure(fileName, funcName string) bool {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return false
+	}
+	return function.Pure
+}
+
+// GetCtxValues retrieves the key value pairs injected in the context passed to given func
+// specified by ctx-value directives, in the order of the directives
+func (d *Deco) GetCtxValues(fileName, funcName string) []*CtxValue {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return []*CtxValue{}
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return []*CtxValue{}
+	}
+	return function.CtxValues
+}
+
+// IsUnordered checks if the results of given file and func are marked order insensitive by an unordered directive
+func (d *Deco) IsUnordered(fileName, funcName string) bool {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return false
+	}
+	return function.Unordered
+}
+
+// IsAllocs checks if the allocations of given file and func are asserted as specified by an allocs directive
+func (d *Deco) IsAllocs(fileName, funcName string) bool {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return false
+	}
+	return function.Allocs
+}
+
+// GetMaxAllocs retrieves the maximum amount of allocations of given file and func specified by an allocs directive,
+// false is returned if no maximum is specified
+func (d *Deco) GetMaxAllocs(fileName, funcName string) (int, bool) {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return 0, false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok || function.MaxAllocs == nil {
+		return 0, false
+	}
+	return *function.MaxAllocs, true
+}
+
+// GetResets retrieves the functions resetting package state specified by reset directives for given file and func
+func (d *Deco) GetResets(fileName, funcName string) []string {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return []string{}
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return []string{}
+	}
+	return function.Resets
+}
+
+// GetMaxDepth retrieves the max depth of given type specified by a max-depth directive
+func (d *Deco) GetMaxDepth(typeName string) (int, bool) {
+	depth, ok := d.MaxDepths[typeName]
+	return depth, ok
+}
+
+// GetRange retrieves the bounds of the values generated for given numeric parameter specified by a range directive
+func (d *Deco) GetRange(fileName, funcName, paramName string) (*Range, bool) {
+	f, ok := d.Files[fileName]
+	if !ok {
+		return nil, false
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		return nil, false
+	}
+	r, ok := function.Ranges[paramName]
+	return r, ok
+}
+
 // File file decorator
 type File struct {
 	Ignore bool
@@ -113,6 +284,27 @@ type Func struct {
 	Ignore         bool
 	ReceiverValues []*CustomVal
 	Params         map[string]*Param
+	ExpectErrors   []*ExpectError
+	// Invariants boolean go expressions which should hold for every test case
+	Invariants []string
+	// Oracle function computing the expected results from the same inputs as the function
+	Oracle string
+	// Idempotent indicates calling the function on its own results returns the same results
+	Idempotent bool
+	// Pure indicates the function doesn't mutate its arguments
+	Pure bool
+	// Unordered indicates the order of the elements of the slices returned by the function is irrelevant
+	Unordered bool
+	// Allocs indicates the allocations of a call of the function are asserted not to exceed MaxAllocs
+	Allocs bool
+	// MaxAllocs maximum amount of allocations of a call of the function, captured at runtime if nil
+	MaxAllocs *int
+	// CtxValues key value pairs injected in the context passed to the function
+	CtxValues []*CtxValue
+	// Resets functions resetting the package state touched by the function, called before every test case
+	Resets []string
+	// Ranges bounds of the values generated per numeric parameter
+	Ranges map[string]*Range
 }
 
 // Param param decorator
@@ -125,12 +317,16 @@ type Param struct {
 type CustomVal struct {
 	Type ast.Expr
 	Call *ast.CallExpr
+	// Cleanup indicates the call returns a func() as second result, releasing the resources
+	// of the value after the test e.g. removing a temp file
+	Cleanup bool
 }
 
 // Spec spec of decorator file
 type Spec struct {
-	CustomVals string     `yaml:"custom_vals"`
-	Files      []FileSpec `yaml:"files"`
+	CustomVals  string     `yaml:"custom_vals"`
+	Generations int        `yaml:"generations"`
+	Files       []FileSpec `yaml:"files"`
 }
 
 // FileSpec file spec of decorator file
@@ -155,8 +351,17 @@ type ParamSpec struct {
 }
 
 // GetDecorators retrieves decorators if specified in given file
+// and adds the comment directives found in the source files of given dir
 func GetDecorators(dir string) (*Deco, error) {
-	return YamlToSpec(dir)
+	res, err := YamlToSpec(dir)
+	if err != nil {
+		return nil, err
+	}
+	err = ParseDirectives(dir, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // YamlToSpec converts yaml file to result
@@ -279,8 +484,12 @@ func ParseYaml(dir string) (*Spec, error) {
 
 // ConvertSpec convert spec to decorator result
 func ConvertSpec(n *ast.File, spec *Spec) (*Deco, error) { // nolint: gocognit
+	if spec.Generations < 0 {
+		return nil, fmt.Errorf("%w: generations must be positive, got %d", ErrIncorrectGenerations, spec.Generations)
+	}
 	res := &Deco{
-		Files: make(map[string]*File),
+		Files:       make(map[string]*File),
+		Generations: spec.Generations,
 	}
 	for i := 0; i < len(spec.Files); i++ {
 		fileSpec := spec.Files[i]
@@ -348,14 +557,19 @@ func FindCustomVal(f *ast.File, funcName string) (*CustomVal, error) {
 				if len(t.Type.Params.List) != 0 {
 					return nil, fmt.Errorf("%w for decorator with function name %s", ErrNoInputParamsExpected, funcName)
 				}
-				if len(t.Type.Results.List) != 1 {
+				results := resultTypes(t.Type.Results)
+				if len(results) != 1 && len(results) != 2 {
 					return nil, fmt.Errorf("%w for decorator with function name %s", ErrExpectedOneRetrunVal, funcName)
 				}
+				if len(results) == 2 && !isCleanupFunc(results[1]) {
+					return nil, fmt.Errorf("%w: second return value of decorator with function name %s must be of type func()", ErrIncorrectCleanup, funcName)
+				}
 				return &CustomVal{
-					Type: t.Type.Results.List[0].Type,
+					Type: results[0],
 					Call: &ast.CallExpr{
 						Fun: t.Name,
 					},
+					Cleanup: len(results) == 2,
 				}, nil
 			}
 		}
@@ -363,6 +577,33 @@ func FindCustomVal(f *ast.File, funcName string) (*CustomVal, error) {
 	return nil, fmt.Errorf("%w with name %s", ErrDecoratorFuncNameNotFound, funcName)
 }
 
+// resultTypes retrieves the type of every result of a function, also if results share their type
+func resultTypes(results *ast.FieldList) []ast.Expr {
+	res := []ast.Expr{}
+	if results == nil {
+		return res
+	}
+	for _, field := range results.List {
+		if len(field.Names) == 0 {
+			res = append(res, field.Type)
+			continue
+		}
+		for range field.Names {
+			res = append(res, field.Type)
+		}
+	}
+	return res
+}
+
+// isCleanupFunc checks if given type is a func() without params and results
+func isCleanupFunc(e ast.Expr) bool {
+	funcType, ok := e.(*ast.FuncType)
+	if !ok {
+		return false
+	}
+	return funcType.Params.NumFields() == 0 && funcType.Results.NumFields() == 0
+}
+
 // GetDecoratorFile retrieve file with decorator spec
 func GetDecoratorFile(dir, file string) (*ast.File, error) {
 	if file == "" {
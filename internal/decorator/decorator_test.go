@@ -3,6 +3,7 @@ package decorator
 import (
 	"errors"
 	"go/ast"
+	"go/types"
 	"os"
 	"path/filepath"
 	"testing"
@@ -75,6 +76,33 @@ func (s *DecoratorTestSuite) TestNoDeco() {
 	s.Equal(0, len(values))
 }
 
+func (s *DecoratorTestSuite) TestGenerations() {
+	res, err := GetDecorators("testdata/generations")
+	s.Require().NoError(err)
+	s.Equal(25, res.Generations)
+
+	res, err = GetDecorators("testdata/nodeco")
+	s.Require().NoError(err)
+	s.Equal(0, res.Generations)
+}
+
+func (s *DecoratorTestSuite) TestCleanup() {
+	res, err := GetDecorators("testdata/cleanup")
+	s.Require().NoError(err)
+	values := res.GetVal("size.go", "Size", "f")
+	s.Require().Equal(1, len(values))
+	s.True(values[0].Cleanup)
+
+	res, err = GetDecorators("testdata/simple")
+	s.Require().NoError(err)
+	values = res.GetVal("password.go", "ComparePassword", "hash")
+	s.Require().Equal(1, len(values))
+	s.False(values[0].Cleanup)
+
+	_, err = GetDecorators("testdata/incorrectcleanup")
+	s.True(errors.Is(err, ErrIncorrectCleanup))
+}
+
 func (s *DecoratorTestSuite) TestIncorrect() {
 	_, err := GetDecorators("testdata/incorrect")
 	s.Require().Error(err)
@@ -95,6 +123,174 @@ func (s *DecoratorTestSuite) TestIncorrectSpec() {
 	s.Require().Error(err)
 }
 
+func (s *DecoratorTestSuite) TestExpectErrorDirective() {
+	res, err := GetDecorators("testdata/expecterror")
+	s.Require().NoError(err)
+	expectErrors := res.GetExpectErrors("divide.go", "Divide")
+	s.Require().Equal(2, len(expectErrors))
+	s.Equal("b", expectErrors[0].Param)
+	lit, ok := expectErrors[0].Value.(*ast.BasicLit)
+	s.Require().True(ok)
+	s.Equal("0", lit.Value)
+	s.Equal("a", expectErrors[1].Param)
+	_, ok = expectErrors[1].Value.(*ast.UnaryExpr)
+	s.True(ok)
+	s.Equal(0, len(res.GetExpectErrors("divide.go", "x")))
+	s.Equal(0, len(res.GetExpectErrors("x.go", "Divide")))
+}
+
+func (s *DecoratorTestSuite) TestInvariantDirective() {
+	res, err := GetDecorators("testdata/invariant")
+	s.Require().NoError(err)
+	s.Equal([]string{"len(result) == len(input)"}, res.GetInvariants("reverse.go", "Reverse"))
+	s.Equal(0, len(res.GetInvariants("reverse.go", "x")))
+
+	_, err = GetDecorators("testdata/incorrectinvariant")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestOracleDirective() {
+	res, err := GetDecorators("testdata/oracle")
+	s.Require().NoError(err)
+	s.Equal("naiveSum", res.GetOracle("sum.go", "Sum"))
+	s.Equal("", res.GetOracle("sum.go", "naiveSum"))
+
+	_, err = GetDecorators("testdata/incorrectoracle")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestIdempotentDirective() {
+	res, err := GetDecorators("testdata/idempotent")
+	s.Require().NoError(err)
+	s.True(res.IsIdempotent("normalize.go", "Normalize"))
+	s.True(res.IsIdempotent("normalize.go", "Clamp"))
+	s.False(res.IsIdempotent("normalize.go", "trim"))
+
+	_, err = GetDecorators("testdata/incorrectidempotent")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestCtxValueDirective() {
+	res, err := GetDecorators("testdata/ctxvalue")
+	s.Require().NoError(err)
+	ctxValues := res.GetCtxValues("tenant.go", "Tenant")
+	s.Require().Equal(2, len(ctxValues))
+	s.Equal("TenantKey", types.ExprString(ctxValues[0].Key))
+	s.Equal(`"acme corp"`, types.ExprString(ctxValues[0].Value))
+	s.Equal(`ctxKey("user")`, types.ExprString(ctxValues[1].Key))
+	s.Equal("42", types.ExprString(ctxValues[1].Value))
+	s.Empty(res.GetCtxValues("tenant.go", "User"))
+
+	_, err = GetDecorators("testdata/incorrectctxvalue")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestAllocsDirective() {
+	res, err := GetDecorators("testdata/allocs")
+	s.Require().NoError(err)
+	s.True(res.IsAllocs("join.go", "Join"))
+	maxAllocs, ok := res.GetMaxAllocs("join.go", "Join")
+	s.True(ok)
+	s.Equal(2, maxAllocs)
+	// Without a maximum the allocations are captured at runtime
+	s.True(res.IsAllocs("join.go", "Upper"))
+	_, ok = res.GetMaxAllocs("join.go", "Upper")
+	s.False(ok)
+	s.False(res.IsAllocs("join.go", "Lower"))
+
+	_, err = GetDecorators("testdata/incorrectallocs")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestUnorderedDirective() {
+	res, err := GetDecorators("testdata/unordered")
+	s.Require().NoError(err)
+	s.True(res.IsUnordered("keys.go", "Keys"))
+	s.False(res.IsUnordered("keys.go", "Sorted"))
+
+	_, err = GetDecorators("testdata/incorrectunordered")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestPureDirective() {
+	res, err := GetDecorators("testdata/pure")
+	s.Require().NoError(err)
+	s.True(res.IsPure("sum.go", "Sum"))
+	s.False(res.IsPure("sum.go", "Reverse"))
+
+	_, err = GetDecorators("testdata/incorrectpure")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestRangeDirective() {
+	res, err := GetDecorators("testdata/ranges")
+	s.Require().NoError(err)
+	r, ok := res.GetRange("percentage.go", "Percentage", "percent")
+	s.Require().True(ok)
+	s.Equal(&Range{Min: 0, Max: 100}, r)
+	_, ok = res.GetRange("percentage.go", "Percentage", "total")
+	s.False(ok)
+
+	_, err = GetDecorators("testdata/incorrectrange")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestResetDirective() {
+	res, err := GetDecorators("testdata/reset")
+	s.Require().NoError(err)
+	s.Equal([]string{"resetCounter"}, res.GetResets("counter.go", "Next"))
+	s.Equal(0, len(res.GetResets("counter.go", "resetCounter")))
+
+	_, err = GetDecorators("testdata/incorrectreset")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestMaxDepthDirective() {
+	res, err := GetDecorators("testdata/maxdepth")
+	s.Require().NoError(err)
+	for typeName, expected := range map[string]int{"Tree": 5, "List": 2, "Node": 3} {
+		depth, ok := res.GetMaxDepth(typeName)
+		s.True(ok, typeName)
+		s.Equal(expected, depth, typeName)
+	}
+	_, ok := res.GetMaxDepth("Leaf")
+	s.False(ok)
+
+	_, err = GetDecorators("testdata/incorrectmaxdepth")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestSetupTeardownDirectives() {
+	res, err := GetDecorators("testdata/testmain")
+	s.Require().NoError(err)
+	s.Equal(&FixtureFunc{Name: "connect", ReturnsError: true}, res.Setup)
+	s.Equal(&FixtureFunc{Name: "disconnect"}, res.Teardown)
+	// Fixture functions aren't tested themselves
+	s.True(res.ShouldIgnoreFunc("fixture.go", "connect"))
+	s.True(res.ShouldIgnoreFunc("fixture.go", "disconnect"))
+	s.False(res.ShouldIgnoreFunc("fixture.go", "Connected"))
+
+	_, err = GetDecorators("testdata/incorrectsetup")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
+func (s *DecoratorTestSuite) TestIncorrectDirective() {
+	_, err := GetDecorators("testdata/incorrectdirective")
+	s.Require().Error(err)
+	s.True(errors.Is(err, ErrInvalidDirective))
+}
+
 func TestDecoratorTestSuite(t *testing.T) {
 	suite.Run(t, new(DecoratorTestSuite))
 }
@@ -0,0 +1,469 @@
+package decorator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DirectivePrefix prefix of comment directives placed above function declarations,
+// the prefix may be preceded by a space as gofmt does not recognise it as a directive
+const DirectivePrefix = "final-unit:"
+
+// Directive names
+const (
+	DirectiveAllocs      = "allocs"
+	DirectiveCtxValue    = "ctx-value"
+	DirectiveExpectError = "expect-error"
+	DirectiveIdempotent  = "idempotent"
+	DirectiveInvariant   = "invariant"
+	DirectiveMaxDepth    = "max-depth"
+	DirectiveOracle      = "oracle"
+	DirectivePure        = "pure"
+	DirectiveRange       = "range"
+	DirectiveReset       = "reset"
+	DirectiveSetup       = "setup"
+	DirectiveTeardown    = "teardown"
+	DirectiveUnordered   = "unordered"
+)
+
+// error definitions
+var (
+	ErrInvalidDirective = fmt.Errorf("invalid directive")
+)
+
+// ExpectError input value for a parameter for which the function is expected to return an error
+type ExpectError struct {
+	Param string
+	Value ast.Expr
+}
+
+// CtxValue key value pair injected in the context passed to a function
+type CtxValue struct {
+	Key   ast.Expr
+	Value ast.Expr
+}
+
+// Range inclusive bounds of the values generated for a numeric parameter
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// ParseDirectives parses the comment directives of all functions declared in given dir
+// and adds them to the decorator
+func ParseDirectives(dir string, deco *Deco) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, directiveFileFilter, parser.ParseComments)
+	if err != nil {
+		var pathError *os.PathError
+		if errors.As(err, &pathError) {
+			return nil
+		}
+		return err
+	}
+	for _, pkg := range pkgs {
+		for filePath, f := range pkg.Files {
+			_, fileName := filepath.Split(filePath)
+			for _, decl := range f.Decls {
+				if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+					err := deco.addTypeDirectives(genDecl)
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				funcDecl, ok := decl.(*ast.FuncDecl)
+				if !ok || funcDecl.Doc == nil {
+					continue
+				}
+				err := deco.addDirectives(fileName, funcDecl)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func directiveFileFilter(fileInfo os.FileInfo) bool {
+	return !strings.HasSuffix(fileInfo.Name(), "_test.go")
+}
+
+// addDirectives adds the directives found in the doc of a function declaration
+func (d *Deco) addDirectives(fileName string, funcDecl *ast.FuncDecl) error {
+	for _, c := range funcDecl.Doc.List {
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if !strings.HasPrefix(text, DirectivePrefix) {
+			continue
+		}
+		name, args := splitDirective(strings.TrimPrefix(text, DirectivePrefix))
+		function := d.funcForDirective(fileName, funcDecl.Name.Name)
+		switch name {
+		case DirectiveAllocs:
+			maxAllocs, err := parseAllocs(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Allocs = true
+			function.MaxAllocs = maxAllocs
+		case DirectiveCtxValue:
+			ctxValue, err := parseCtxValue(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.CtxValues = append(function.CtxValues, ctxValue)
+		case DirectiveExpectError:
+			expectError, err := parseExpectError(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.ExpectErrors = append(function.ExpectErrors, expectError)
+		case DirectiveIdempotent:
+			err := parseIdempotent(funcDecl, args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Idempotent = true
+		case DirectiveInvariant:
+			err := parseInvariant(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Invariants = append(function.Invariants, args)
+		case DirectiveOracle:
+			err := parseFuncName(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Oracle = args
+		case DirectivePure:
+			err := parsePure(funcDecl, args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Pure = true
+		case DirectiveRange:
+			param, r, err := parseRange(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			if function.Ranges == nil {
+				function.Ranges = make(map[string]*Range)
+			}
+			function.Ranges[param] = r
+		case DirectiveReset:
+			err := parseFuncName(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Resets = append(function.Resets, args)
+		case DirectiveMaxDepth:
+			err := d.addMaxDepth(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+		case DirectiveSetup, DirectiveTeardown:
+			fixtureFunc, err := parseFixtureFunc(funcDecl)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			err = d.addFixtureFunc(name, fixtureFunc)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			// Setting up and tearing down the fixture is done by TestMain, it isn't tested itself
+			function.Ignore = true
+		case DirectiveUnordered:
+			err := parseUnordered(args)
+			if err != nil {
+				return fmt.Errorf("%w in func %s: %s", err, funcDecl.Name.Name, c.Text)
+			}
+			function.Unordered = true
+		default:
+			return fmt.Errorf("%w in func %s, unknown directive: %s", ErrInvalidDirective, funcDecl.Name.Name, name)
+		}
+	}
+	return nil
+}
+
+// addTypeDirectives adds the directives found in the doc of a type declaration, only the max-depth directive
+// applies to types
+func (d *Deco) addTypeDirectives(genDecl *ast.GenDecl) error {
+	docs := []*ast.CommentGroup{genDecl.Doc}
+	for _, spec := range genDecl.Specs {
+		docs = append(docs, spec.(*ast.TypeSpec).Doc)
+	}
+	for _, doc := range docs {
+		if doc == nil {
+			continue
+		}
+		for _, c := range doc.List {
+			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if !strings.HasPrefix(text, DirectivePrefix) {
+				continue
+			}
+			name, args := splitDirective(strings.TrimPrefix(text, DirectivePrefix))
+			if name != DirectiveMaxDepth {
+				return fmt.Errorf("%w, directive can't be used on a type: %s", ErrInvalidDirective, name)
+			}
+			err := d.addMaxDepth(args)
+			if err != nil {
+				return fmt.Errorf("%w: %s", err, c.Text)
+			}
+		}
+	}
+	return nil
+}
+
+// addMaxDepth adds the max depth of a type specified by a max-depth directive
+func (d *Deco) addMaxDepth(args string) error {
+	typeName, depth, err := parseMaxDepth(args)
+	if err != nil {
+		return err
+	}
+	if d.MaxDepths == nil {
+		d.MaxDepths = make(map[string]int)
+	}
+	d.MaxDepths[typeName] = depth
+	return nil
+}
+
+// addFixtureFunc adds the function setting up or tearing down the fixture of the package,
+// only one of each can be specified per package
+func (d *Deco) addFixtureFunc(directive string, fixtureFunc *FixtureFunc) error {
+	current := &d.Setup
+	if directive == DirectiveTeardown {
+		current = &d.Teardown
+	}
+	if *current != nil {
+		return fmt.Errorf("%w: %s already specified by %s", ErrInvalidDirective, directive, (*current).Name)
+	}
+	*current = fixtureFunc
+	return nil
+}
+
+// funcForDirective retrieves the function decorator for given file and func, creating it if absent
+func (d *Deco) funcForDirective(fileName, funcName string) *Func {
+	f, ok := d.Files[fileName]
+	if !ok {
+		f = &File{
+			Funcs: make(map[string]*Func),
+		}
+		d.Files[fileName] = f
+	}
+	function, ok := f.Funcs[funcName]
+	if !ok {
+		function = &Func{
+			ReceiverValues: []*CustomVal{},
+			Params:         make(map[string]*Param),
+		}
+		f.Funcs[funcName] = function
+	}
+	return function
+}
+
+// splitDirective splits a directive in its name and arguments
+func splitDirective(directive string) (string, string) {
+	directive = strings.TrimSpace(directive)
+	i := strings.IndexAny(directive, " \t")
+	if i == -1 {
+		return directive, ""
+	}
+	return directive[:i], strings.TrimSpace(directive[i+1:])
+}
+
+// parseExpectError parses the arguments of an expect-error directive: <param> <value>
+func parseExpectError(args string) (*ExpectError, error) {
+	param, value := splitDirective(args)
+	if param == "" || value == "" {
+		return nil, fmt.Errorf("%w: expected <param> <value>", ErrInvalidDirective)
+	}
+	expr, err := parser.ParseExpr(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse value %s", ErrInvalidDirective, value)
+	}
+	return &ExpectError{
+		Param: param,
+		Value: expr,
+	}, nil
+}
+
+// parseCtxValue parses the arguments of a ctx-value directive: <key-expr> <value>
+func parseCtxValue(args string) (*CtxValue, error) {
+	key, value := splitDirective(args)
+	if key == "" || value == "" {
+		return nil, fmt.Errorf("%w: expected <key-expr> <value>", ErrInvalidDirective)
+	}
+	keyExpr, err := parser.ParseExpr(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse key %s", ErrInvalidDirective, key)
+	}
+	valueExpr, err := parser.ParseExpr(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse value %s", ErrInvalidDirective, value)
+	}
+	return &CtxValue{
+		Key:   keyExpr,
+		Value: valueExpr,
+	}, nil
+}
+
+// parseRange parses the arguments of a range directive: <param> <min> <max>
+func parseRange(args string) (string, *Range, error) {
+	fields := strings.Fields(args)
+	if len(fields) != 3 {
+		return "", nil, fmt.Errorf("%w: expected <param> <min> <max>", ErrInvalidDirective)
+	}
+	min, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: unable to parse min %s", ErrInvalidDirective, fields[1])
+	}
+	max, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: unable to parse max %s", ErrInvalidDirective, fields[2])
+	}
+	if min > max {
+		return "", nil, fmt.Errorf("%w: min %s exceeds max %s", ErrInvalidDirective, fields[1], fields[2])
+	}
+	return fields[0], &Range{
+		Min: min,
+		Max: max,
+	}, nil
+}
+
+// parseFixtureFunc verifies the function of a setup or teardown directive can be called by TestMain,
+// i.e. it's a function without parameters returning nothing or an error
+func parseFixtureFunc(funcDecl *ast.FuncDecl) (*FixtureFunc, error) {
+	if funcDecl.Recv != nil || funcDecl.Type.TypeParams != nil || funcDecl.Type.Params.NumFields() != 0 {
+		return nil, fmt.Errorf("%w: expected func without receiver and parameters", ErrInvalidDirective)
+	}
+	switch funcDecl.Type.Results.NumFields() {
+	case 0:
+		return &FixtureFunc{Name: funcDecl.Name.Name}, nil
+	case 1:
+		if ident, ok := funcDecl.Type.Results.List[0].Type.(*ast.Ident); ok && ident.Name == "error" {
+			return &FixtureFunc{Name: funcDecl.Name.Name, ReturnsError: true}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: expected func returning nothing or an error", ErrInvalidDirective)
+}
+
+// parseMaxDepth parses the arguments of a max-depth directive: <type> <depth>
+func parseMaxDepth(args string) (string, int, error) {
+	fields := strings.Fields(args)
+	if len(fields) != 2 {
+		return "", 0, fmt.Errorf("%w: expected <type> <depth>", ErrInvalidDirective)
+	}
+	depth, err := strconv.Atoi(fields[1])
+	if err != nil || depth < 1 {
+		return "", 0, fmt.Errorf("%w: depth must be a positive integer, got %s", ErrInvalidDirective, fields[1])
+	}
+	return fields[0], depth, nil
+}
+
+// parseAllocs parses the optional argument of an allocs directive: [<max>], nil is returned if no maximum is specified,
+// in which case the maximum is captured from the allocations measured at runtime
+func parseAllocs(args string) (*int, error) {
+	if args == "" {
+		return nil, nil
+	}
+	maxAllocs, err := strconv.Atoi(args)
+	if err != nil || maxAllocs < 0 {
+		return nil, fmt.Errorf("%w: max must be a non-negative integer, got %s", ErrInvalidDirective, args)
+	}
+	return &maxAllocs, nil
+}
+
+// parseInvariant verifies the argument of an invariant directive is a valid go expression
+func parseInvariant(args string) error {
+	if args == "" {
+		return fmt.Errorf("%w: expected <expression>", ErrInvalidDirective)
+	}
+	_, err := parser.ParseExpr(args)
+	if err != nil {
+		return fmt.Errorf("%w: unable to parse expression %s", ErrInvalidDirective, args)
+	}
+	return nil
+}
+
+// parseIdempotent verifies an idempotent directive has no arguments and the results of the function
+// can be passed as its parameters in the same order
+func parseIdempotent(funcDecl *ast.FuncDecl, args string) error {
+	if args != "" {
+		return fmt.Errorf("%w: expected no arguments, got %s", ErrInvalidDirective, args)
+	}
+	params := fieldListTypes(funcDecl.Type.Params)
+	results := fieldListTypes(funcDecl.Type.Results)
+	if len(params) == 0 || len(params) != len(results) {
+		return fmt.Errorf("%w: expected func with results matching its parameters", ErrInvalidDirective)
+	}
+	for i := range params {
+		if params[i] != results[i] {
+			return fmt.Errorf("%w: result %s can't be passed as parameter %s", ErrInvalidDirective, results[i], params[i])
+		}
+	}
+	return nil
+}
+
+// parsePure verifies a pure directive has no arguments and the function has parameters which could be mutated
+func parsePure(funcDecl *ast.FuncDecl, args string) error {
+	if args != "" {
+		return fmt.Errorf("%w: expected no arguments, got %s", ErrInvalidDirective, args)
+	}
+	if len(fieldListTypes(funcDecl.Type.Params)) == 0 {
+		return fmt.Errorf("%w: expected func with parameters", ErrInvalidDirective)
+	}
+	return nil
+}
+
+// parseUnordered verifies an unordered directive has no arguments
+func parseUnordered(args string) error {
+	if args != "" {
+		return fmt.Errorf("%w: expected no arguments, got %s", ErrInvalidDirective, args)
+	}
+	return nil
+}
+
+// fieldListTypes retrieves the type of every entry of a field list, repeating the type of grouped names
+func fieldListTypes(fieldList *ast.FieldList) []string {
+	res := []string{}
+	if fieldList == nil {
+		return res
+	}
+	for _, field := range fieldList.List {
+		amount := len(field.Names)
+		if amount == 0 {
+			amount = 1
+		}
+		for i := 0; i < amount; i++ {
+			res = append(res, types.ExprString(field.Type))
+		}
+	}
+	return res
+}
+
+// parseFuncName verifies the argument of a directive, e.g. oracle or reset, is the name of a function
+func parseFuncName(args string) error {
+	if args == "" {
+		return fmt.Errorf("%w: expected <func>", ErrInvalidDirective)
+	}
+	expr, err := parser.ParseExpr(args)
+	if err != nil {
+		return fmt.Errorf("%w: unable to parse func %s", ErrInvalidDirective, args)
+	}
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return nil
+	default:
+		return fmt.Errorf("%w: expected func name, got %s", ErrInvalidDirective, args)
+	}
+}
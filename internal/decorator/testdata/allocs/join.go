@@ -0,0 +1,20 @@
+package allocs
+
+import "strings"
+
+// Join joins the words
+// final-unit:allocs 2
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}
+
+// Upper converts the word to upper case
+// final-unit:allocs
+func Upper(word string) string {
+	return strings.ToUpper(word)
+}
+
+// Lower converts the word to lower case
+func Lower(word string) string {
+	return strings.ToLower(word)
+}
@@ -0,0 +1,17 @@
+package cleanup
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func tempFile() (*os.File, func()) {
+	f, err := ioutil.TempFile("", "cleanup")
+	if err != nil {
+		panic(err)
+	}
+	return f, func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+}
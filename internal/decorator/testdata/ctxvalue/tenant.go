@@ -0,0 +1,22 @@
+package ctxvalue
+
+import "context"
+
+type ctxKey string
+
+// TenantKey context key of the tenant
+const TenantKey ctxKey = "tenant"
+
+// Tenant retrieves the tenant from the context
+// final-unit:ctx-value TenantKey "acme corp"
+// final-unit:ctx-value ctxKey("user") 42
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value(TenantKey).(string)
+	return tenant
+}
+
+// User retrieves the user from the context
+func User(ctx context.Context) int {
+	user, _ := ctx.Value(ctxKey("user")).(int)
+	return user
+}
@@ -0,0 +1,16 @@
+package expecterror
+
+import "fmt"
+
+// Divide divides a by b
+//final-unit:expect-error b 0
+//final-unit:expect-error a -1
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	if a < 0 {
+		return 0, fmt.Errorf("negative numerator")
+	}
+	return a / b, nil
+}
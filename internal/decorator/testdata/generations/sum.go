@@ -0,0 +1,5 @@
+package generations
+
+func Sum(a, b int) int {
+	return a + b
+}
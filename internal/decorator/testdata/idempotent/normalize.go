@@ -0,0 +1,25 @@
+package idempotent
+
+import "strings"
+
+// Normalize lower cases and trims a string
+// final-unit:idempotent
+func Normalize(s string) string {
+	return strings.ToLower(trim(s))
+}
+
+// Clamp clamps the bounds of a range to be positive
+// final-unit:idempotent
+func Clamp(low, high int) (int, int) {
+	if low < 0 {
+		low = 0
+	}
+	if high < low {
+		high = low
+	}
+	return low, high
+}
+
+func trim(s string) string {
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,9 @@
+package incorrectallocs
+
+import "strings"
+
+// Join joins the words
+// final-unit:allocs -1
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,10 @@
+package cleanup
+
+import (
+	"io/ioutil"
+	"os"
+)
+
+func tempFile() (*os.File, error) {
+	return ioutil.TempFile("", "cleanup")
+}
@@ -0,0 +1,12 @@
+package cleanup
+
+import "os"
+
+// Size retrieves the size of given file
+func Size(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,10 @@
+package incorrectctxvalue
+
+import "context"
+
+// Tenant retrieves the tenant from the context
+// final-unit:ctx-value tenant
+func Tenant(ctx context.Context) string {
+	tenant, _ := ctx.Value("tenant").(string)
+	return tenant
+}
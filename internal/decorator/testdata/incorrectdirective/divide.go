@@ -0,0 +1,7 @@
+package incorrectdirective
+
+// Divide divides a by b
+// final-unit:expect-error b
+func Divide(a, b int) int {
+	return a / b
+}
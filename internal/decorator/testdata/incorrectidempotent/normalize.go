@@ -0,0 +1,12 @@
+package incorrectidempotent
+
+import "strings"
+
+// Normalize lower cases a string, returning an error for empty strings
+// final-unit:idempotent
+func Normalize(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	return strings.ToLower(s), nil
+}
@@ -0,0 +1,7 @@
+package incorrectinvariant
+
+// Reverse reverses the order of the input
+// final-unit:invariant len(result) ==
+func Reverse(input []int) []int {
+	return input
+}
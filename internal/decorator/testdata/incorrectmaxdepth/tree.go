@@ -0,0 +1,6 @@
+package incorrectmaxdepth
+
+// final-unit:max-depth Tree zero
+type Tree struct {
+	Children []*Tree
+}
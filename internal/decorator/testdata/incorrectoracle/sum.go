@@ -0,0 +1,11 @@
+package incorrectoracle
+
+// Sum sums the input
+// final-unit:oracle naiveSum(input)
+func Sum(input []int) int {
+	res := 0
+	for _, x := range input {
+		res += x
+	}
+	return res
+}
@@ -0,0 +1,9 @@
+package incorrectpure
+
+import "time"
+
+// Now retrieves the current time
+// final-unit:pure
+func Now() time.Time {
+	return time.Now()
+}
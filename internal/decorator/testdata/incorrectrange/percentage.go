@@ -0,0 +1,7 @@
+package incorrectrange
+
+// Percentage calculates the given percentage of total
+// final-unit:range percent 100 0
+func Percentage(total int, percent int) int {
+	return total * percent / 100
+}
@@ -0,0 +1,9 @@
+package reset
+
+var counter int
+
+// final-unit:reset counter = 0
+func Next() int {
+	counter++
+	return counter
+}
@@ -0,0 +1,6 @@
+package incorrectsetup
+
+// final-unit:setup
+func connect(dsn string) error {
+	return nil
+}
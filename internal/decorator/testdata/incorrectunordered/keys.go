@@ -0,0 +1,11 @@
+package incorrectunordered
+
+// Keys retrieves the keys of a map
+// final-unit:unordered keys
+func Keys(m map[string]int) []string {
+	res := []string{}
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
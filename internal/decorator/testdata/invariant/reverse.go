@@ -0,0 +1,11 @@
+package invariant
+
+// Reverse reverses the order of the input
+// final-unit:invariant len(result) == len(input)
+func Reverse(input []int) []int {
+	res := make([]int, len(input))
+	for i, x := range input {
+		res[len(input)-1-i] = x
+	}
+	return res
+}
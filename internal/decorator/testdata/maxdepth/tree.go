@@ -0,0 +1,24 @@
+package maxdepth
+
+// Tree a tree which is generated deeper than others
+// final-unit:max-depth Tree 5
+type Tree struct {
+	Value    int
+	Children []*Tree
+}
+
+type (
+	// final-unit:max-depth List 2
+	List struct {
+		Next *List
+	}
+)
+
+// final-unit:max-depth Node 3
+func Depth(t *Tree, n *Node) int {
+	return 0
+}
+
+type Node struct {
+	Next *Node
+}
@@ -0,0 +1,23 @@
+package oracle
+
+// Sum sums the input using loop unrolling
+// final-unit:oracle naiveSum
+func Sum(input []int) int {
+	res := 0
+	i := 0
+	for ; i+1 < len(input); i += 2 {
+		res += input[i] + input[i+1]
+	}
+	if i < len(input) {
+		res += input[i]
+	}
+	return res
+}
+
+func naiveSum(input []int) int {
+	res := 0
+	for _, x := range input {
+		res += x
+	}
+	return res
+}
@@ -0,0 +1,18 @@
+package pure
+
+// Sum sums the values
+// final-unit:pure
+func Sum(values []int) int {
+	res := 0
+	for _, v := range values {
+		res += v
+	}
+	return res
+}
+
+// Reverse reverses the values in place
+func Reverse(values []int) {
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+}
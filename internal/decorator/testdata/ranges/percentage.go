@@ -0,0 +1,7 @@
+package ranges
+
+// Percentage calculates the given percentage of total
+// final-unit:range percent 0 100
+func Percentage(total int, percent int) int {
+	return total * percent / 100
+}
@@ -0,0 +1,14 @@
+package reset
+
+var counter int
+
+// Next increments the package counter and returns the incremented value plus step
+// final-unit:reset resetCounter
+func Next(step int) int {
+	counter++
+	return counter + step
+}
+
+func resetCounter() {
+	counter = 0
+}
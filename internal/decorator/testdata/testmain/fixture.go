@@ -0,0 +1,18 @@
+package testmain
+
+var connected bool
+
+// final-unit:setup
+func connect() error {
+	connected = true
+	return nil
+}
+
+// final-unit:teardown
+func disconnect() {
+	connected = false
+}
+
+func Connected() bool {
+	return connected
+}
@@ -0,0 +1,20 @@
+package unordered
+
+import "sort"
+
+// Keys retrieves the keys of a map
+// final-unit:unordered
+func Keys(m map[string]int) []string {
+	res := []string{}
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
+
+// Sorted retrieves the sorted keys of a map
+func Sorted(m map[string]int) []string {
+	res := Keys(m)
+	sort.Strings(res)
+	return res
+}
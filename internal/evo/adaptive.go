@@ -0,0 +1,89 @@
+package evo
+
+import (
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+)
+
+// CaseCoverage retrieves the blocks of statements covered by the test case
+// with given index of given function in the organism
+type CaseCoverage func(organism *gen.Organism, funcName string, index int) (map[string]bool, error)
+
+// AdaptCases replaces the test cases of every function in the organism by the cases covering new statements.
+// The existing cases are evaluated first, after which new cases are generated until maxCases cases
+// have been evaluated, or plateau consecutive cases didn't cover any new statements
+func AdaptCases(organism *gen.Organism, coverage CaseCoverage, maxCases, plateau int) error {
+	for _, f := range organism.Files {
+		funcNames := []string{}
+		for funcName := range f.TestCases {
+			funcNames = append(funcNames, funcName)
+		}
+		sort.Strings(funcNames)
+		for _, funcName := range funcNames {
+			kept, err := adaptFuncCases(organism, f, funcName, coverage, maxCases, plateau)
+			if err != nil {
+				return err
+			}
+			log.Debugf("adaptive generation kept %d test cases for %s", len(kept), funcName)
+			f.TestCases[funcName] = kept
+		}
+	}
+	return nil
+}
+
+// adaptFuncCases retrieves the test cases of a single function which cover new statements,
+// the test cases forced by expect error directives are always kept
+func adaptFuncCases(organism *gen.Organism, f *gen.File, funcName string, coverage CaseCoverage, maxCases, plateau int) ([]*testcase.TestCase, error) {
+	candidates := []*testcase.TestCase{}
+	forced := []*testcase.TestCase{}
+	for _, testCase := range f.TestCases[funcName] {
+		if testCase.ExpectError != nil {
+			forced = append(forced, testCase)
+			continue
+		}
+		candidates = append(candidates, testCase)
+	}
+	if len(candidates) == 0 {
+		return forced, nil
+	}
+	template := candidates[0]
+	kept := []*testcase.TestCase{}
+	covered := make(map[string]bool)
+	noImprovement := 0
+	for i := 0; i < maxCases && noImprovement < plateau; i++ {
+		var candidate *testcase.TestCase
+		if i < len(candidates) {
+			candidate = candidates[i]
+		} else {
+			candidate = template.NewCase()
+		}
+		if candidate.Invalid {
+			noImprovement++
+			continue
+		}
+		// Only the candidate is present while measuring, so it's executed at index 0
+		f.TestCases[funcName] = []*testcase.TestCase{candidate}
+		blocks, err := coverage(organism, funcName, 0)
+		if err != nil {
+			return nil, err
+		}
+		newBlocks := false
+		for block := range blocks {
+			if !covered[block] {
+				newBlocks = true
+				covered[block] = true
+			}
+		}
+		// At least one case is kept, also if the function has no statements
+		if newBlocks || len(kept) == 0 {
+			kept = append(kept, candidate)
+			noImprovement = 0
+			continue
+		}
+		noImprovement++
+	}
+	return append(kept, forced...), nil
+}
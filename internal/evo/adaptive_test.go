@@ -0,0 +1,89 @@
+package evo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
+)
+
+type AdaptiveTestSuite struct {
+	suite.Suite
+}
+
+func (s *AdaptiveTestSuite) TestRedundantCasesDropped() {
+	seed.SetRandomSeed(1)
+	generator, err := gen.New("../../test/data/inputs/example_bool", &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 4,
+	})
+	s.Require().NoError(err)
+	organism := generator.GetNewOrganism()
+	s.Require().Equal(1, len(organism.Files))
+	file := organism.Files[0]
+	original := file.TestCases["BoolFunc"]
+	s.Require().Equal(4, len(original))
+
+	// Blocks covered per evaluated candidate, the fifth and later candidates are newly generated
+	coveredBlocks := [][]string{
+		{"bool.go:3.1,3.2"},
+		{"bool.go:3.1,3.2"},
+		{"bool.go:3.1,3.2", "bool.go:4.1,4.2"},
+		{},
+		{"bool.go:5.1,5.2"},
+	}
+	evaluated := []*testcase.TestCase{}
+	coverage := func(organism *gen.Organism, funcName string, index int) (map[string]bool, error) {
+		s.Require().Equal(0, index)
+		s.Require().Equal(1, len(organism.Files[0].TestCases[funcName]))
+		res := make(map[string]bool)
+		if funcName != "BoolFunc" {
+			return res, nil
+		}
+		evaluated = append(evaluated, organism.Files[0].TestCases[funcName][0])
+		if len(evaluated) <= len(coveredBlocks) {
+			for _, block := range coveredBlocks[len(evaluated)-1] {
+				res[block] = true
+			}
+		}
+		return res, nil
+	}
+	err = AdaptCases(organism, coverage, 10, 3)
+	s.Require().NoError(err)
+
+	// Coverage plateaus after three cases not covering new blocks
+	s.Require().Equal(8, len(evaluated))
+	s.Equal(original, evaluated[:4])
+	s.Equal([]*testcase.TestCase{evaluated[0], evaluated[2], evaluated[4]}, file.TestCases["BoolFunc"])
+	// Functions without covered statements keep a single test case
+	s.Equal(1, len(file.TestCases["BoolPointerFunc"]))
+}
+
+func (s *AdaptiveTestSuite) TestMaxCases() {
+	seed.SetRandomSeed(1)
+	generator, err := gen.New("../../test/data/inputs/example_bool", &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	})
+	s.Require().NoError(err)
+	organism := generator.GetNewOrganism()
+	evaluations := 0
+	coverage := func(organism *gen.Organism, funcName string, index int) (map[string]bool, error) {
+		evaluations++
+		// Every case covers a new block
+		return map[string]bool{string(rune('a' + evaluations)): true}, nil
+	}
+	err = AdaptCases(organism, coverage, 5, 3)
+	s.Require().NoError(err)
+	s.Equal(10, evaluations)
+	s.Equal(5, len(organism.Files[0].TestCases["BoolFunc"]))
+	s.Equal(5, len(organism.Files[0].TestCases["BoolPointerFunc"]))
+}
+
+func TestAdaptiveTestSuite(t *testing.T) {
+	suite.Run(t, new(AdaptiveTestSuite))
+}
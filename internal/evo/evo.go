@@ -4,7 +4,6 @@ package evo
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -24,6 +23,13 @@ const (
 	DefaultMutationRate float64 = 5
 	DefaultTarget       float64 = 95.0
 	DefaultNoImprovGens int     = 10
+	// DefaultCapturePasses by default a test case is valid if two capture passes are equal
+	DefaultCapturePasses int = 2
+	// DefaultAdaptiveMaxCases maximum amount of test cases evaluated per function in adaptive mode
+	DefaultAdaptiveMaxCases int = 50
+	// DefaultAdaptivePlateau amount of consecutive test cases not covering new statements
+	// after which adaptive generation of a function stops
+	DefaultAdaptivePlateau int = 5
 )
 
 // PopulationStats struct representing the stats of the population
@@ -44,6 +50,24 @@ type PopulationOpts struct {
 	Target            float64
 	MaxNoImprovGens   int
 	OverrideTestCases bool
+	// CapturePasses amount of times the runtime values of the best fit are captured, with more than
+	// two passes test cases are accepted if a majority of the passes agree
+	CapturePasses int
+	// Adaptive replaces the test cases of the best fit by the cases covering new statements,
+	// generating additional cases until coverage plateaus or AdaptiveMaxCases is reached
+	Adaptive         bool
+	AdaptiveMaxCases int
+	// Generations amount of generations the population evolves before the best fit is chosen,
+	// if zero the population evolves until the target is hit or no improvements are found
+	Generations int
+	// Selection strategy selecting the parents of the next generation
+	Selection Selection
+	// CaptureConcurrency amount of functions of which the runtime values are captured at the same time,
+	// each in a separate go test process. If at most one all functions are captured in a single run
+	CaptureConcurrency int
+	// Snapshot path of the file storing the captured results per test case, test cases of which the result changed
+	// compared to an equivalent test case of a previous run assert the previous result. Disabled if empty
+	Snapshot string
 }
 
 // DefaultPopOpts create some default options for the population
@@ -53,6 +77,9 @@ func DefaultPopOpts() PopulationOpts {
 		Target:            DefaultTarget,
 		MaxNoImprovGens:   DefaultNoImprovGens,
 		OverrideTestCases: false,
+		CapturePasses:     DefaultCapturePasses,
+		AdaptiveMaxCases:  DefaultAdaptiveMaxCases,
+		Selection:         SelectionPool,
 	}
 }
 
@@ -65,21 +92,33 @@ type Population struct {
 	Fitness      float64
 	OrgGenerator *gen.Generator
 	Executor     tmplexec.IExecutor
+	Selector     Selector
 	Dir          string
+	// SnapshotChanges test cases of the best fit of which the captured result changed compared to the snapshot
+	SnapshotChanges []*SnapshotChange
 	// StatChan  chan PopulationStats
 }
 
 // NewPopulation creates a new population for specified options
 func NewPopulation(dir string, generator *gen.Generator, opts PopulationOpts) (*Population, error) {
+	selector, err := NewSelector(opts.Selection)
+	if err != nil {
+		return nil, err
+	}
 	p := &Population{
 		Dir:          dir,
 		OrgGenerator: generator,
 		Opts:         opts,
 		Executor:     tmplexec.NewCoverageExecutor(tmplexec.Opts{Dir: dir, Override: opts.OverrideTestCases}),
+		Selector:     selector,
+	}
+	// Decorator setting is used if the amount of generations isn't set explicitly
+	if p.Opts.Generations == 0 && generator.Deco != nil {
+		p.Opts.Generations = generator.Deco.Generations
 	}
 	// Create first generation
 	p.Organisms = p.OrgGenerator.GetTestCases()
-	err := p.GetFitnessForOrganisms()
+	err = p.GetFitnessForOrganisms()
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +146,7 @@ func (p *Population) GetFitnessForOrganisms() error {
 			p.BestFit = org
 			p.Stats.BestFit = fitness
 		}
-		if fitness > p.BestFit.Fitness {
+		if org.FitterThan(p.BestFit) {
 			newBest = true
 			p.BestFit = org
 			p.Stats.BestFit = fitness
@@ -125,11 +164,21 @@ func (p *Population) GetFitnessForOrganisms() error {
 
 // Evolve evolve a population using natural selection
 func (p *Population) Evolve() error {
+	err := p.evolveGenerations()
+	if err != nil {
+		return err
+	}
+	log.Infof("organism which meets target criteria found, generating result")
+	return p.CreateBestFitResult()
+}
+
+// evolveGenerations performs natural selection until the configured amount of generations is reached,
+// or if not configured until the target is hit or no improvements are found
+func (p *Population) evolveGenerations() error {
 	for {
 		p.Stats.logStats()
-		if p.BestFit.Fitness >= p.Opts.Target || p.Stats.NoImprovedGens >= p.Opts.MaxNoImprovGens {
-			log.Infof("organism which meets target criteria found, generating result")
-			return p.CreateBestFitResult()
+		if p.done() {
+			return nil
 		}
 		t := time.Now()
 		// Perform natural selection for creating a newe generation
@@ -138,14 +187,28 @@ func (p *Population) Evolve() error {
 			return err
 		}
 		log.Infof("natural selection took: %.2fs", time.Since(t).Seconds())
-		// Target reached we can stop evolving
 	}
 }
 
+// done checks if the population is done evolving
+func (p *Population) done() bool {
+	if p.Opts.Generations > 0 {
+		return p.Stats.Generation >= p.Opts.Generations
+	}
+	return p.BestFit.Fitness >= p.Opts.Target || p.Stats.NoImprovedGens >= p.Opts.MaxNoImprovGens
+}
+
 // CreateBestFitResult creates result for best fit
 func (p *Population) CreateBestFitResult() error {
 	path := p.Dir
-	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path, Override: p.Opts.OverrideTestCases})
+	if p.Opts.Adaptive {
+		caseCoverageExecutor := tmplexec.NewCaseCoverageExecutor(tmplexec.Opts{Dir: path, Override: p.Opts.OverrideTestCases})
+		err := AdaptCases(p.BestFit, caseCoverageExecutor.CaseCoverage, p.Opts.AdaptiveMaxCases, DefaultAdaptivePlateau)
+		if err != nil {
+			return err
+		}
+	}
+	valueExecutor := tmplexec.NewValueExecutor(tmplexec.Opts{Dir: path, Override: p.Opts.OverrideTestCases, Concurrency: p.Opts.CaptureConcurrency})
 
 	// First run
 	res, err := valueExecutor.Execute(p.BestFit)
@@ -161,6 +224,22 @@ func (p *Population) CreateBestFitResult() error {
 	}
 	p.BestFit.UpdateAssertStmts(res, false)
 
+	// Additional runs settling disagreements by majority
+	for i := DefaultCapturePasses; i < p.Opts.CapturePasses; i++ {
+		res, err = valueExecutor.Execute(p.BestFit)
+		if err != nil {
+			return err
+		}
+		p.BestFit.AddCapturePass(res)
+	}
+
+	if p.Opts.Snapshot != "" {
+		err = p.compareSnapshot()
+		if err != nil {
+			return err
+		}
+	}
+
 	// Assert executor
 	assertExecutor := tmplexec.NewAssertExecutor(tmplexec.Opts{Dir: path, Override: p.Opts.OverrideTestCases})
 	_, err = assertExecutor.Execute(p.BestFit)
@@ -176,13 +255,15 @@ func (p *Population) NaturalSelection() error {
 	// Increment generation
 	p.Stats.Generation++
 
-	pool := p.createSelectionPool()
 	nextGen := []*gen.Organism{}
+	// The best organism is kept unchanged, so the best fit never regresses
+	if p.Selector.Elitism() {
+		nextGen = append(nextGen, p.BestFit)
+	}
 
-	for i := 0; i < len(p.Organisms); i++ {
-		r1, r2 := rand.Intn(len(pool)), rand.Intn(len(pool))
-		a := p.Organisms[pool[r1]]
-		b := p.Organisms[pool[r2]]
+	for len(nextGen) < len(p.Organisms) {
+		a := p.Selector.Select(p.Organisms)
+		b := p.Selector.Select(p.Organisms)
 		child, err := p.crossover(a, b)
 		if err != nil {
 			return err
@@ -202,17 +283,25 @@ func (p *Population) crossover(a, b *gen.Organism) (*gen.Organism, error) {
 	const crossOverRate = 50
 	for i, af := range a.Files {
 		bf := b.Files[i]
+		// The settings of the parent are kept, since they're used when writing the test files
 		x := &gen.File{
 			FileName:    af.FileName,
 			PackageName: af.PackageName,
 			TestCases:   make(map[string][]*testcase.TestCase),
+			PackageInfo: af.PackageInfo,
+			IdentGen:    af.IdentGen,
+			Opts:        af.Opts,
+			Deco:        af.Deco,
 		}
 		for funcName, testCaseList := range af.TestCases {
 			for j, testCase := range testCaseList {
+				// Mutations create a new test case, since test cases are shared with the parents
 				if chance.IsChance(p.Opts.MutationRate) {
-					testCase.Create()
-					x.TestCases[funcName] = append(x.TestCases[funcName], testCase)
-					continue
+					// A mutation of which values couldn't be generated keeps the original case
+					if mutation := testCase.NewCase(); !mutation.Invalid {
+						x.TestCases[funcName] = append(x.TestCases[funcName], mutation)
+						continue
+					}
 				}
 				if chance.IsChance(crossOverRate) {
 					x.TestCases[funcName] = append(x.TestCases[funcName], testCase)
@@ -226,18 +315,3 @@ func (p *Population) crossover(a, b *gen.Organism) (*gen.Organism, error) {
 	}
 	return res, nil
 }
-
-func (p *Population) createSelectionPool() []int {
-	pool := []int{}
-	const fullPercent = 100
-	for i := range p.Organisms {
-		pool = append(pool, i)
-	}
-	for i, o := range p.Organisms {
-		num := int(o.Fitness * fullPercent)
-		for j := 0; j < num; j++ {
-			pool = append(pool, i)
-		}
-	}
-	return pool
-}
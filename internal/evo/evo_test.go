@@ -0,0 +1,92 @@
+package evo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
+)
+
+type EvoTestSuite struct {
+	suite.Suite
+}
+
+// countingExecutor executor assigning a fixed fitness, counting the amount of executions
+type countingExecutor struct {
+	fitness    float64
+	executions int
+}
+
+func (e *countingExecutor) Execute(organism *gen.Organism) (string, error) {
+	e.executions++
+	organism.Fitness = e.fitness
+	return "", nil
+}
+
+func (s *EvoTestSuite) newPopulation(opts PopulationOpts, fitness float64) (*Population, *countingExecutor) {
+	seed.SetRandomSeed(1)
+	generator, err := gen.New("../../test/data/inputs/example_bool", &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   3,
+		TestCasesPerFunc: 2,
+	})
+	s.Require().NoError(err)
+	executor := &countingExecutor{fitness: fitness}
+	selector, err := NewSelector(opts.Selection)
+	s.Require().NoError(err)
+	p := &Population{
+		Selector:     selector,
+		OrgGenerator: generator,
+		Opts:         opts,
+		Executor:     executor,
+		Organisms:    generator.GetTestCases(),
+	}
+	s.Require().NoError(p.GetFitnessForOrganisms())
+	return p, executor
+}
+
+func (s *EvoTestSuite) TestGenerations() {
+	opts := DefaultPopOpts()
+	opts.Generations = 7
+	// The target is already hit, but the configured amount of generations is run regardless
+	p, executor := s.newPopulation(opts, 100)
+	s.Require().NoError(p.evolveGenerations())
+	s.Equal(7, p.Stats.Generation)
+	s.Equal(3*8, executor.executions)
+}
+
+func (s *EvoTestSuite) TestNoGenerations() {
+	opts := DefaultPopOpts()
+	opts.MaxNoImprovGens = 4
+	p, _ := s.newPopulation(opts, 10)
+	s.Require().NoError(p.evolveGenerations())
+	// Without configured generations the population stops evolving if no improvements are found
+	s.Equal(4, p.Stats.Generation)
+}
+
+func (s *EvoTestSuite) TestElitism() {
+	opts := DefaultPopOpts()
+	opts.Selection = SelectionElitism
+	opts.MutationRate = 100
+	p, _ := s.newPopulation(opts, 10)
+	p.Organisms[1].Fitness = 50
+	p.BestFit = p.Organisms[1]
+	best := p.BestFit
+	original := best.Files[0].TestCases["BoolFunc"][0]
+	stmts := original.Stmts
+	s.Require().NoError(p.NaturalSelection())
+	// Every test case of the other organisms is mutated, while the best organism is kept unchanged
+	s.Equal(3, len(p.Organisms))
+	s.Same(best, p.Organisms[0])
+	s.Same(original, best.Files[0].TestCases["BoolFunc"][0])
+	s.Equal(stmts, original.Stmts)
+	s.NotSame(original, p.Organisms[1].Files[0].TestCases["BoolFunc"][0])
+	// Children keep the settings of their parents, which are used when writing their test files
+	s.Same(best.Files[0].Opts, p.Organisms[1].Files[0].Opts)
+	s.Equal(gen.DefaultHeader, p.Organisms[1].Files[0].Header())
+}
+
+func TestEvoTestSuite(t *testing.T) {
+	suite.Run(t, new(EvoTestSuite))
+}
@@ -0,0 +1,152 @@
+package evo
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/wimspaargaren/final-unit/internal/gen"
+)
+
+// ErrUnknownSelection unknown selection strategy
+var ErrUnknownSelection = fmt.Errorf("unknown selection strategy")
+
+// Selection name of a strategy selecting the parents of the next generation
+type Selection string
+
+// Available selection strategies
+const (
+	// SelectionPool selects parents out of a pool favouring fit organisms, while every organism can be selected
+	SelectionPool Selection = "pool"
+	// SelectionElitism keeps the best organism and selects parents at random
+	SelectionElitism Selection = "elitism"
+	// SelectionRoulette selects parents proportional to their fitness
+	SelectionRoulette Selection = "roulette"
+	// SelectionTournament selects the fittest of a few organisms picked at random
+	SelectionTournament Selection = "tournament"
+)
+
+// DefaultTournamentSize amount of organisms competing in a tournament
+const DefaultTournamentSize = 3
+
+// Selector selects a parent out of the organisms of a generation
+type Selector interface {
+	Select(organisms []*gen.Organism) *gen.Organism
+	// Elitism indicates if the best organism is kept in the next generation
+	Elitism() bool
+}
+
+// NewSelector creates the selector for given selection strategy, defaults to the pool selection
+func NewSelector(selection Selection) (Selector, error) {
+	switch selection {
+	case SelectionPool, "":
+		return &poolSelector{}, nil
+	case SelectionElitism:
+		return &elitismSelector{}, nil
+	case SelectionRoulette:
+		return &rouletteSelector{}, nil
+	case SelectionTournament:
+		return &tournamentSelector{size: DefaultTournamentSize}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelection, selection)
+	}
+}
+
+// poolSelector selects parents out of a pool containing every organism once,
+// and once more for every hundredth of its fitness
+type poolSelector struct{}
+
+func (s *poolSelector) Select(organisms []*gen.Organism) *gen.Organism {
+	organisms = viable(organisms)
+	const fullPercent = 100
+	size := 0
+	for _, o := range organisms {
+		size += 1 + int(o.Fitness*fullPercent)
+	}
+	r := rand.Intn(size)
+	for _, o := range organisms {
+		r -= 1 + int(o.Fitness*fullPercent)
+		if r < 0 {
+			return o
+		}
+	}
+	return organisms[len(organisms)-1]
+}
+
+func (s *poolSelector) Elitism() bool {
+	return false
+}
+
+// elitismSelector selects parents at random, while the best organism is kept
+type elitismSelector struct{}
+
+func (s *elitismSelector) Select(organisms []*gen.Organism) *gen.Organism {
+	organisms = viable(organisms)
+	return organisms[rand.Intn(len(organisms))]
+}
+
+func (s *elitismSelector) Elitism() bool {
+	return true
+}
+
+// rouletteSelector selects parents with a probability proportional to their fitness
+type rouletteSelector struct{}
+
+func (s *rouletteSelector) Select(organisms []*gen.Organism) *gen.Organism {
+	organisms = viable(organisms)
+	total := 0.0
+	for _, o := range organisms {
+		total += o.Fitness
+	}
+	// Without any fitness every organism is equally likely
+	if total <= 0 {
+		return organisms[rand.Intn(len(organisms))]
+	}
+	r := rand.Float64() * total
+	for _, o := range organisms {
+		r -= o.Fitness
+		if r < 0 {
+			return o
+		}
+	}
+	return organisms[len(organisms)-1]
+}
+
+func (s *rouletteSelector) Elitism() bool {
+	return false
+}
+
+// tournamentSelector selects the fittest out of a given amount of organisms picked at random
+type tournamentSelector struct {
+	size int
+}
+
+func (s *tournamentSelector) Select(organisms []*gen.Organism) *gen.Organism {
+	organisms = viable(organisms)
+	var best *gen.Organism
+	for i := 0; i < s.size; i++ {
+		o := organisms[rand.Intn(len(organisms))]
+		if best == nil || o.FitterThan(best) {
+			best = o
+		}
+	}
+	return best
+}
+
+func (s *tournamentSelector) Elitism() bool {
+	return false
+}
+
+// viable retrieves the organisms of which the test cases compile, so non compiling organisms are never
+// selected as parent. If none of the organisms compile all organisms are returned
+func viable(organisms []*gen.Organism) []*gen.Organism {
+	res := []*gen.Organism{}
+	for _, o := range organisms {
+		if !o.BuildFailed {
+			res = append(res, o)
+		}
+	}
+	if len(res) == 0 {
+		return organisms
+	}
+	return res
+}
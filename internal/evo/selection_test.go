@@ -0,0 +1,135 @@
+package evo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
+)
+
+type SelectionTestSuite struct {
+	suite.Suite
+}
+
+// selectionDistribution retrieves the fraction of times every organism is selected
+func (s *SelectionTestSuite) selectionDistribution(selection Selection, fitness []float64) []float64 {
+	seed.SetRandomSeed(1)
+	selector, err := NewSelector(selection)
+	s.Require().NoError(err)
+	organisms := []*gen.Organism{}
+	index := make(map[*gen.Organism]int)
+	for i, f := range fitness {
+		o := &gen.Organism{Fitness: f}
+		organisms = append(organisms, o)
+		index[o] = i
+	}
+	const samples = 100000
+	res := make([]float64, len(fitness))
+	for i := 0; i < samples; i++ {
+		res[index[selector.Select(organisms)]]++
+	}
+	for i := range res {
+		res[i] /= samples
+	}
+	return res
+}
+
+func (s *SelectionTestSuite) TestDistribution() {
+	const delta = 0.01
+	fitness := []float64{10, 30, 60}
+	tests := []struct {
+		Name      string
+		Selection Selection
+		Fitness   []float64
+		Expected  []float64
+		Elitism   bool
+	}{
+		{
+			// Every organism is in the pool once, and once more for every hundredth of its fitness
+			Name:      "pool favours fit organisms",
+			Selection: SelectionPool,
+			Fitness:   []float64{0.1, 0.3, 0.6},
+			Expected:  []float64{11.0 / 103, 31.0 / 103, 61.0 / 103},
+		},
+		{
+			Name:      "pool without fitness selects at random",
+			Selection: SelectionPool,
+			Fitness:   []float64{0, 0},
+			Expected:  []float64{0.5, 0.5},
+		},
+		{
+			Name:      "elitism selects at random",
+			Selection: SelectionElitism,
+			Fitness:   fitness,
+			Expected:  []float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+			Elitism:   true,
+		},
+		{
+			Name:      "roulette selects proportional to fitness",
+			Selection: SelectionRoulette,
+			Fitness:   fitness,
+			Expected:  []float64{0.1, 0.3, 0.6},
+		},
+		{
+			Name:      "roulette without fitness selects at random",
+			Selection: SelectionRoulette,
+			Fitness:   []float64{0, 0},
+			Expected:  []float64{0.5, 0.5},
+		},
+		{
+			// The fittest of three organisms drawn with replacement
+			Name:      "tournament",
+			Selection: SelectionTournament,
+			Fitness:   fitness,
+			Expected:  []float64{1.0 / 27, 7.0 / 27, 19.0 / 27},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			res := s.selectionDistribution(test.Selection, test.Fitness)
+			s.Require().Equal(len(test.Expected), len(res))
+			for i := range res {
+				s.InDelta(test.Expected[i], res[i], delta)
+			}
+			selector, err := NewSelector(test.Selection)
+			s.Require().NoError(err)
+			s.Equal(test.Elitism, selector.Elitism())
+		})
+	}
+}
+
+func (s *SelectionTestSuite) TestStagedFitness() {
+	seed.SetRandomSeed(1)
+	buildFailed := &gen.Organism{Fitness: 0, BuildFailed: true}
+	panics := &gen.Organism{Fitness: 90, Panics: true}
+	passes := &gen.Organism{Fitness: 40}
+	s.True(passes.FitterThan(panics))
+	s.True(panics.FitterThan(buildFailed))
+	s.False(buildFailed.FitterThan(passes))
+
+	for _, selection := range []Selection{SelectionPool, SelectionElitism, SelectionRoulette, SelectionTournament} {
+		s.Run(string(selection), func() {
+			selector, err := NewSelector(selection)
+			s.Require().NoError(err)
+			for i := 0; i < 1000; i++ {
+				s.Require().NotSame(buildFailed, selector.Select([]*gen.Organism{buildFailed, panics, passes}))
+			}
+			// Without any compiling organism, non compiling organisms are still selected
+			s.Same(buildFailed, selector.Select([]*gen.Organism{buildFailed}))
+		})
+	}
+}
+
+func (s *SelectionTestSuite) TestDefaultSelection() {
+	selector, err := NewSelector("")
+	s.Require().NoError(err)
+	s.IsType(&poolSelector{}, selector)
+	s.False(selector.Elitism())
+	_, err = NewSelector("unknown")
+	s.ErrorIs(err, ErrUnknownSelection)
+}
+
+func TestSelectionTestSuite(t *testing.T) {
+	suite.Run(t, new(SelectionTestSuite))
+}
@@ -0,0 +1,341 @@
+package evo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+)
+
+// ErrInvalidSnapshot snapshot file can't be parsed
+var ErrInvalidSnapshot = fmt.Errorf("invalid snapshot file")
+
+// SnapshotStore captured results of the test cases of the latest run, keyed by the identity of the test case
+type SnapshotStore struct {
+	Path  string
+	Cases map[string]*Snapshot
+}
+
+// Snapshot captured result of a test case, in which the identifiers declared by the test case are normalized
+type Snapshot struct {
+	// Panic value the test case panicked with, empty if it didn't panic
+	Panic string `json:"panic,omitempty"`
+	// Stmts statements asserting the results of the test case
+	Stmts []*SnapshotStmt `json:"stmts"`
+}
+
+// SnapshotStmt statement asserting a result of a test case, either an assert or an assign statement
+type SnapshotStmt struct {
+	Assert *runtime.AssertStmt `json:"assert,omitempty"`
+	Assign *runtime.AssignStmt `json:"assign,omitempty"`
+}
+
+// SnapshotChange a test case of which the captured result differs from the snapshot of an equivalent test case
+type SnapshotChange struct {
+	Func     string
+	Identity string
+	Before   []string
+	After    []string
+}
+
+// String prints the change in a concise human readable format
+func (c *SnapshotChange) String() string {
+	return fmt.Sprintf("result of %s changed for equivalent input %s: %s → %s", c.Func, c.Identity, strings.Join(c.Before, "; "), strings.Join(c.After, "; "))
+}
+
+// LoadSnapshotStore loads the snapshot store from given path, an empty store is returned if the file doesn't exist
+func LoadSnapshotStore(path string) (*SnapshotStore, error) {
+	store := &SnapshotStore{
+		Path:  path,
+		Cases: make(map[string]*Snapshot),
+	}
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.Cases); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSnapshot, err.Error())
+	}
+	return store, nil
+}
+
+// Save persists the snapshot store
+func (s *SnapshotStore) Save() error {
+	data, err := json.MarshalIndent(s.Cases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(s.Path, data, 0o600)
+}
+
+// Compare compares the captured results of the test cases of given organism against the snapshots of equivalent
+// test cases. Test cases of which the result changed assert the result of the snapshot instead, so the generated
+// test fails until the snapshot is removed. Results of new test cases are recorded, snapshots of test cases which
+// aren't generated any more are pruned. Test cases with non deterministic results are skipped
+func (s *SnapshotStore) Compare(organism *gen.Organism) []*SnapshotChange {
+	changes := []*SnapshotChange{}
+	generated := make(map[string]bool)
+	for _, f := range organism.Files {
+		// Map iteration is random, sort functions so changes are reported in a fixed order
+		funcNames := []string{}
+		for funcName := range f.TestCases {
+			funcNames = append(funcNames, funcName)
+		}
+		sort.Strings(funcNames)
+		for _, funcName := range funcNames {
+			for _, testCase := range f.TestCases[funcName] {
+				if !testCase.RunTimeInfo.IsValid() {
+					continue
+				}
+				names := normalizedNames(testCase)
+				identity := caseIdentity(funcName, testCase, names)
+				generated[identity] = true
+				snapshot := newSnapshot(testCase, names)
+				before, ok := s.Cases[identity]
+				if !ok {
+					s.Cases[identity] = snapshot
+					continue
+				}
+				if reflect.DeepEqual(before, snapshot) {
+					continue
+				}
+				after := snapshotResult(testCase)
+				before.apply(testCase, inverseNames(names))
+				changes = append(changes, &SnapshotChange{
+					Func:     funcName,
+					Identity: identity,
+					Before:   snapshotResult(testCase),
+					After:    after,
+				})
+			}
+		}
+	}
+	for identity := range s.Cases {
+		if !generated[identity] {
+			delete(s.Cases, identity)
+		}
+	}
+	return changes
+}
+
+// CaseIdentity creates a stable identity of a test case of given function, equal for test cases with equal inputs
+// regardless of the names of the identifiers declared by the test case
+func CaseIdentity(funcName string, testCase *testcase.TestCase) string {
+	return caseIdentity(funcName, testCase, normalizedNames(testCase))
+}
+
+func caseIdentity(funcName string, testCase *testcase.TestCase, names map[string]string) string {
+	hash := sha256.New()
+	parts := []string{funcName}
+	parts = append(parts, testCase.Decls...)
+	parts = append(parts, testCase.Stmts...)
+	parts = append(parts, testCase.FuncStmt)
+	for _, part := range parts {
+		// Separate parts, so moving a line between parts results in another identity
+		hash.Write([]byte(renameIdents(part, names)))
+		hash.Write([]byte{0})
+	}
+	return funcName + "/" + hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// newSnapshot creates the snapshot of the captured result of a test case
+func newSnapshot(testCase *testcase.TestCase, names map[string]string) *Snapshot {
+	if testCase.RunTimeInfo.Panics {
+		return &Snapshot{Panic: testCase.RunTimeInfo.PanicMessage, Stmts: []*SnapshotStmt{}}
+	}
+	return &Snapshot{Stmts: renameStmts(testCase.RunTimeInfo.Stmts(), names)}
+}
+
+// apply replaces the captured result of a test case by the result of the snapshot
+func (s *Snapshot) apply(testCase *testcase.TestCase, names map[string]string) {
+	info := testCase.RunTimeInfo
+	info.Panics = s.Panic != ""
+	info.PanicMessage = s.Panic
+	info.Expectations = []runtime.Stmt{}
+	info.ExpectationsOnly = true
+	for _, stmt := range renameStmts(s.runtimeStmts(), names) {
+		info.Expectations = append(info.Expectations, stmt.runtimeStmt())
+	}
+}
+
+func (s *Snapshot) runtimeStmts() []runtime.Stmt {
+	res := []runtime.Stmt{}
+	for _, stmt := range s.Stmts {
+		if runtimeStmt := stmt.runtimeStmt(); runtimeStmt != nil {
+			res = append(res, runtimeStmt)
+		}
+	}
+	return res
+}
+
+func (s *SnapshotStmt) runtimeStmt() runtime.Stmt {
+	if s.Assert != nil {
+		return s.Assert
+	}
+	if s.Assign != nil {
+		return s.Assign
+	}
+	return nil
+}
+
+// renameStmts copies the statements, replacing the identifiers according to names
+func renameStmts(stmts []runtime.Stmt, names map[string]string) []*SnapshotStmt {
+	res := []*SnapshotStmt{}
+	for _, stmt := range stmts {
+		switch t := stmt.(type) {
+		case *runtime.AssertStmt:
+			res = append(res, &SnapshotStmt{Assert: &runtime.AssertStmt{
+				AssertStmtType: t.AssertStmtType,
+				Expected:       renameIdents(t.Expected, names),
+				Value:          renameIdents(t.Value, names),
+				Delta:          renameIdents(t.Delta, names),
+			}})
+		case *runtime.AssignStmt:
+			res = append(res, &SnapshotStmt{Assign: &runtime.AssignStmt{
+				AssignStmtType: t.AssignStmtType,
+				LeftHand:       renameIdents(t.LeftHand, names),
+				RightHand:      renameIdents(t.RightHand, names),
+			}})
+		}
+	}
+	return res
+}
+
+// snapshotResult retrieves the captured result of a test case, being either its assert statements or its panic
+func snapshotResult(testCase *testcase.TestCase) []string {
+	if testCase.RunTimeInfo.Panics {
+		return []string{"panic: " + testCase.RunTimeInfo.PanicMessage}
+	}
+	return testCase.RunTimeInfo.GetAssertStmts()
+}
+
+// normalizedNames maps the identifiers declared by a test case to names numbered in order of declaration,
+// the names of generated identifiers depend on the other test cases of the organism
+func normalizedNames(testCase *testcase.TestCase) map[string]string {
+	res := make(map[string]string)
+	sources := []string{}
+	sources = append(sources, testCase.Decls...)
+	sources = append(sources, testCase.Stmts...)
+	sources = append(sources, testCase.OracleCallStmts...)
+	sources = append(sources, testCase.FuncPrintStmt)
+	for _, src := range sources {
+		for _, name := range declaredIdents(src) {
+			if _, ok := res[name]; !ok && name != "_" {
+				res[name] = "_" + strconv.Itoa(len(res)+1)
+			}
+		}
+	}
+	return res
+}
+
+func inverseNames(names map[string]string) map[string]string {
+	res := make(map[string]string)
+	for name, normalized := range names {
+		res[normalized] = name
+	}
+	return res
+}
+
+type scannedToken struct {
+	offset int
+	tok    token.Token
+	lit    string
+}
+
+// scanTokens scans the tokens of a snippet of Go source
+func scanTokens(src string) []scannedToken {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), nil, 0)
+	res := []scannedToken{}
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return res
+		}
+		res = append(res, scannedToken{offset: file.Offset(pos), tok: tok, lit: lit})
+	}
+}
+
+// declaredIdents retrieves the identifiers declared by a statement or declaration, e.g. a and b of a, b := f()
+func declaredIdents(src string) []string {
+	tokens := scanTokens(src)
+	if len(tokens) > 1 && (tokens[0].tok == token.TYPE || tokens[0].tok == token.VAR) && tokens[1].tok == token.IDENT {
+		return []string{tokens[1].lit}
+	}
+	res := []string{}
+	for _, t := range tokens {
+		switch t.tok {
+		case token.IDENT:
+			res = append(res, t.lit)
+		case token.COMMA:
+		case token.DEFINE:
+			return res
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// renameIdents replaces the identifiers of a snippet of Go source according to names. Selected fields and keys
+// of composite literals aren't replaced, as these may share the name of an identifier
+func renameIdents(src string, names map[string]string) string {
+	tokens := scanTokens(src)
+	var b strings.Builder
+	last := 0
+	for i, t := range tokens {
+		name, ok := names[t.lit]
+		if t.tok != token.IDENT || !ok {
+			continue
+		}
+		if (i > 0 && tokens[i-1].tok == token.PERIOD) || (i+1 < len(tokens) && tokens[i+1].tok == token.COLON) {
+			continue
+		}
+		b.WriteString(src[last:t.offset])
+		b.WriteString(name)
+		last = t.offset + len(t.lit)
+	}
+	b.WriteString(src[last:])
+	return b.String()
+}
+
+// compareSnapshot compares the captured results of the best fit against the snapshot file,
+// logging the changed results and updating the snapshot file
+func (p *Population) compareSnapshot() error {
+	store, err := LoadSnapshotStore(p.Opts.Snapshot)
+	if err != nil {
+		return err
+	}
+	p.SnapshotChanges = store.Compare(p.BestFit)
+	for _, change := range p.SnapshotChanges {
+		p.logger().Warningln(change.String())
+	}
+	return store.Save()
+}
+
+// logger retrieves the logger of the generator, the global logrus logger is used if none is configured
+func (p *Population) logger() log.FieldLogger {
+	if p.OrgGenerator != nil && p.OrgGenerator.Opts != nil && p.OrgGenerator.Opts.Logger != nil {
+		return p.OrgGenerator.Opts.Logger
+	}
+	return log.StandardLogger()
+}
@@ -0,0 +1,109 @@
+package evo
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
+)
+
+type SnapshotTestSuite struct {
+	suite.Suite
+}
+
+// capturedOrganism creates an organism for the race example of which Total captured given result in both runs
+func (s *SnapshotTestSuite) capturedOrganism(total string) *gen.Organism {
+	seed.SetRandomSeed(1)
+	generator, err := gen.New("../../test/data/inputs/example_race", &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	})
+	s.Require().NoError(err)
+	organism := generator.GetNewOrganism()
+	printed := `<START;Total0>
+{ "type": "int", "var_name": "out", "val": "` + total + `"}
+<END;Total0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+	return organism
+}
+
+func (s *SnapshotTestSuite) TestChangedResultIsFlagged() {
+	path := filepath.Join(s.T().TempDir(), "snapshot.json")
+
+	// First run records the results
+	store, err := LoadSnapshotStore(path)
+	s.Require().NoError(err)
+	s.Empty(store.Compare(s.capturedOrganism("3")))
+	s.Require().NoError(store.Save())
+
+	// Equal results of an equivalent test case aren't flagged
+	store, err = LoadSnapshotStore(path)
+	s.Require().NoError(err)
+	s.Empty(store.Compare(s.capturedOrganism("3")))
+	s.Require().NoError(store.Save())
+
+	// Changed results are flagged
+	store, err = LoadSnapshotStore(path)
+	s.Require().NoError(err)
+	organism := s.capturedOrganism("5")
+	changes := store.Compare(organism)
+	s.Require().Equal(1, len(changes))
+	s.Equal("Total", changes[0].Func)
+	s.Equal([]string{"s.EqualValues(int(3),out)"}, changes[0].Before)
+	s.Equal([]string{"s.EqualValues(int(5),out)"}, changes[0].After)
+	// The test case asserts the result of the snapshot, which is kept
+	s.Equal([]string{"s.EqualValues(int(3),out)"}, organism.Files[0].TestCases["Total"][0].RunTimeInfo.GetAssertStmts())
+	s.Equal([]*SnapshotStmt{{Assert: &runtime.AssertStmt{
+		AssertStmtType: runtime.AssertStmtTypeEqualValues,
+		Expected:       "int(3)",
+		Value:          "_1",
+	}}}, store.Cases[changes[0].Identity].Stmts)
+}
+
+func (s *SnapshotTestSuite) TestRemovedCasesArePruned() {
+	store := &SnapshotStore{Cases: map[string]*Snapshot{
+		"Removed/0123456789abcdef": {Stmts: []*SnapshotStmt{}},
+	}}
+	s.Empty(store.Compare(s.capturedOrganism("3")))
+	s.NotContains(store.Cases, "Removed/0123456789abcdef")
+	s.NotEmpty(store.Cases)
+}
+
+func (s *SnapshotTestSuite) TestCaseIdentity() {
+	organism := s.capturedOrganism("3")
+	testCase := organism.Files[0].TestCases["Add"][0]
+	s.Equal(CaseIdentity("Add", testCase), CaseIdentity("Add", testCase))
+	s.NotEqual(CaseIdentity("Add", testCase), CaseIdentity("Total", testCase))
+}
+
+func (s *SnapshotTestSuite) TestCaseIdentityIgnoresIdentNames() {
+	testCase := func(ident, value string) *testcase.TestCase {
+		return &testcase.TestCase{
+			Decls:    []string{"type " + ident + "Mock struct{}"},
+			Stmts:    []string{ident + " := Point{X: " + value + "}", "_ = &" + ident + "Mock{}"},
+			FuncStmt: "Scale(" + ident + ".X)",
+		}
+	}
+	s.Equal(CaseIdentity("Scale", testCase("a", "1")), CaseIdentity("Scale", testCase("b", "1")))
+	s.NotEqual(CaseIdentity("Scale", testCase("a", "1")), CaseIdentity("Scale", testCase("a", "2")))
+	s.Equal("_1 := Point{a: 1}.a", renameIdents("a := Point{a: 1}.a", map[string]string{"a": "_1"}))
+}
+
+func (s *SnapshotTestSuite) TestInvalidSnapshot() {
+	path := filepath.Join(s.T().TempDir(), "snapshot.json")
+	s.Require().NoError(ioutil.WriteFile(path, []byte("{"), 0o600))
+	_, err := LoadSnapshotStore(path)
+	s.ErrorIs(err, ErrInvalidSnapshot)
+}
+
+func TestSnapshotTestSuite(t *testing.T) {
+	suite.Run(t, new(SnapshotTestSuite))
+}
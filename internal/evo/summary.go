@@ -0,0 +1,38 @@
+package evo
+
+import (
+	"fmt"
+
+	"github.com/wimspaargaren/final-unit/internal/gen"
+)
+
+// Summary summary of the generated tests and the package coverage before and after generation
+type Summary struct {
+	Tests          int
+	Funcs          int
+	CoverageBefore float64
+	CoverageAfter  float64
+}
+
+// NewSummary creates a summary of the test cases of given organism with the measured coverage
+func NewSummary(organism *gen.Organism, coverageBefore, coverageAfter float64) *Summary {
+	summary := &Summary{
+		CoverageBefore: coverageBefore,
+		CoverageAfter:  coverageAfter,
+	}
+	for _, f := range organism.Files {
+		for _, testCases := range f.TestCases {
+			if len(testCases) == 0 {
+				continue
+			}
+			summary.Tests += len(testCases)
+			summary.Funcs++
+		}
+	}
+	return summary
+}
+
+// String prints the summary in a concise human readable format
+func (s *Summary) String() string {
+	return fmt.Sprintf("Generated %d tests for %d functions; package coverage %.0f%% → %.0f%%", s.Tests, s.Funcs, s.CoverageBefore, s.CoverageAfter)
+}
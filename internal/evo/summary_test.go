@@ -0,0 +1,35 @@
+package evo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
+)
+
+type SummaryTestSuite struct {
+	suite.Suite
+}
+
+func (s *SummaryTestSuite) TestSummary() {
+	seed.SetRandomSeed(1)
+	generator, err := gen.New("../../test/data/inputs/example_int", &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 4,
+	})
+	s.Require().NoError(err)
+	organism := generator.GetNewOrganism()
+	// Functions without test cases aren't counted
+	organism.Files[0].TestCases["IntFunc"] = nil
+
+	summary := NewSummary(organism, 41.2, 76)
+	s.Equal(32, summary.Tests)
+	s.Equal(8, summary.Funcs)
+	s.Equal("Generated 32 tests for 8 functions; package coverage 41% → 76%", summary.String())
+}
+
+func TestSummaryTestSuite(t *testing.T) {
+	suite.Run(t, new(SummaryTestSuite))
+}
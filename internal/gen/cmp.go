@@ -0,0 +1,21 @@
+package gen
+
+import "strings"
+
+// UsesCmp checks if any test case of the file asserts values using go-cmp,
+// so the go-cmp packages are imported by the test file
+func (f *File) UsesCmp() bool {
+	if f.Opts == nil || !f.Opts.CmpAssertions {
+		return false
+	}
+	for _, testCases := range f.TestCases {
+		for _, testCase := range testCases {
+			for _, stmt := range testCase.RunTimeInfo.GetAssertStmts() {
+				if strings.Contains(stmt, "cmp.Diff(") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
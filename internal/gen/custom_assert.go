@@ -0,0 +1,76 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"strconv"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// ErrInvalidAssertMapping mapping of assertions to the functions of a custom assertion package is invalid
+var ErrInvalidAssertMapping = fmt.Errorf("invalid custom assertion mapping")
+
+// parseAssertFuncs validates the mapping of assertion names to qualified functions of the custom assertion
+// package, all functions must be qualified by the same package name, which is used to import the package
+func parseAssertFuncs(pkg string, funcs map[string]string) (map[runtime.AssertStmtType]string, string, error) {
+	if pkg == "" && len(funcs) == 0 {
+		return nil, "", nil
+	}
+	if pkg == "" {
+		return nil, "", fmt.Errorf("%w: assertion functions are mapped without an assertion package", ErrInvalidAssertMapping)
+	}
+	if len(funcs) == 0 {
+		return nil, "", fmt.Errorf("%w: assertion package %s is set without mapping any assertion", ErrInvalidAssertMapping, pkg)
+	}
+	known := make(map[runtime.AssertStmtType]bool)
+	for _, assertStmtType := range runtime.AssertStmtTypes {
+		known[assertStmtType] = true
+	}
+	res := make(map[runtime.AssertStmtType]string)
+	alias := ""
+	for name, fun := range funcs {
+		if !known[runtime.AssertStmtType(name)] {
+			return nil, "", fmt.Errorf("%w: unknown assertion %s", ErrInvalidAssertMapping, name)
+		}
+		expr, err := parser.ParseExpr(fun)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: unable to parse func %s of assertion %s", ErrInvalidAssertMapping, fun, name)
+		}
+		selectorExpr, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return nil, "", fmt.Errorf("%w: expected qualified func e.g. myassert.Eq for assertion %s, got %s", ErrInvalidAssertMapping, name, fun)
+		}
+		ident, ok := selectorExpr.X.(*ast.Ident)
+		if !ok {
+			return nil, "", fmt.Errorf("%w: expected qualified func e.g. myassert.Eq for assertion %s, got %s", ErrInvalidAssertMapping, name, fun)
+		}
+		if alias != "" && ident.Name != alias {
+			return nil, "", fmt.Errorf("%w: funcs are qualified by different packages %s and %s", ErrInvalidAssertMapping, alias, ident.Name)
+		}
+		alias = ident.Name
+		res[runtime.AssertStmtType(name)] = fun
+	}
+	return res, alias, nil
+}
+
+// AssertImport retrieves the import spec of the custom assertion package, an empty string is returned
+// if no test case of the file calls a custom assertion function
+func (f *File) AssertImport() string {
+	if f.Opts == nil || f.Opts.assertAlias == "" {
+		return ""
+	}
+	prefix := f.Opts.assertAlias + "."
+	for _, testCases := range f.TestCases {
+		for _, testCase := range testCases {
+			for _, stmt := range testCase.RunTimeInfo.GetAssertStmts() {
+				if strings.HasPrefix(stmt, prefix) {
+					return f.Opts.assertAlias + " " + strconv.Quote(f.Opts.AssertPackage)
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package gen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+)
+
+// ExportShimFileName name of the file declaring the exported wrappers of unexported functions, which differs from
+// the conventional export_test.go, so hand-written export files aren't overwritten
+const ExportShimFileName = "finalunit_export_test.go"
+
+// ExportShims retrieves the declarations of the exported wrappers of the unexported functions
+// called by the test cases of the organism
+func (o *Organism) ExportShims() []string {
+	funcNames := make(map[string]bool)
+	for _, f := range o.Files {
+		for _, testCases := range f.TestCases {
+			for _, testCase := range testCases {
+				if testCase.UsesExportShim() {
+					funcNames[testCase.FuncDecl.Name.Name] = true
+				}
+			}
+		}
+	}
+	res := []string{}
+	for funcName := range funcNames {
+		res = append(res, fmt.Sprintf("var %s = %s", testcase.ExportShimName(funcName), funcName))
+	}
+	sort.Strings(res)
+	return res
+}
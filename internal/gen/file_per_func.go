@@ -0,0 +1,66 @@
+package gen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+)
+
+// SharedDeclsFileName name of the file declaring the synthetic declarations and helpers shared by the test files
+// generated per function
+const SharedDeclsFileName = "shared_decls_test.go"
+
+// OutputFiles retrieves the files for which test files are generated. If enabled, the test cases of every function
+// are split into a separate file named after the function, of which the declarations are shared via a common file
+func (o *Organism) OutputFiles() []*File {
+	if len(o.Files) == 0 || !o.Files[0].Opts.FilePerFunc {
+		return o.Files
+	}
+	res := []*File{}
+	used := make(map[string]int)
+	for _, f := range o.Files {
+		dir, _ := filepath.Split(f.FileName)
+		for _, funcName := range f.sortedFuncNames() {
+			res = append(res, &File{
+				PackageName: f.PackageName,
+				FileName:    filepath.Join(dir, funcFileName(funcName, used)),
+				TestCases:   map[string][]*testcase.TestCase{funcName: f.TestCases[funcName]},
+				PackageInfo: f.PackageInfo,
+				IdentGen:    f.IdentGen,
+				Opts:        f.Opts,
+				Deco:        f.Deco,
+				DeclsShared: true,
+			})
+		}
+	}
+	return res
+}
+
+// SharedDecls retrieves the declarations of the test cases and the helpers of all files of the organism,
+// which are declared once in the shared declarations file if test files are generated per function
+func (o *Organism) SharedDecls() []string {
+	res := []string{}
+	for _, f := range o.Files {
+		res = append(res, f.Helpers...)
+		for _, funcName := range f.sortedFuncNames() {
+			for _, testCase := range f.TestCases[funcName] {
+				res = append(res, testCase.Decls...)
+			}
+		}
+	}
+	return res
+}
+
+// funcFileName creates the name of the source file a test file is generated for per function, e.g. divide.go
+// for Divide. The name is lower cased without separators, so it doesn't end in a build constraint like _linux,
+// and numbered if another function results in the same name
+func funcFileName(funcName string, used map[string]int) string {
+	name := strings.ToLower(funcName)
+	used[name]++
+	if used[name] > 1 {
+		name = fmt.Sprintf("%s%d", name, used[name])
+	}
+	return name + ".go"
+}
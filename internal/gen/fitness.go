@@ -0,0 +1,30 @@
+package gen
+
+// Stages of the staged fitness of an organism, a higher stage is always fitter regardless of coverage
+const (
+	StageBuildFailed = iota
+	StagePanics
+	StagePasses
+)
+
+// Stage retrieves the stage of the staged fitness reached by the organism:
+// compiling organisms outrank non compiling ones and organisms running without panics outrank panicking ones
+func (o *Organism) Stage() int {
+	switch {
+	case o.BuildFailed:
+		return StageBuildFailed
+	case o.Panics:
+		return StagePanics
+	default:
+		return StagePasses
+	}
+}
+
+// FitterThan checks if the organism is fitter than the other organism, comparing their stage first
+// and their coverage fitness within the same stage
+func (o *Organism) FitterThan(other *Organism) bool {
+	if o.Stage() != other.Stage() {
+		return o.Stage() > other.Stage()
+	}
+	return o.Fitness > other.Fitness
+}
@@ -8,11 +8,14 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"text/template"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/corpus"
 	"github.com/wimspaargaren/final-unit/internal/decorator"
 	"github.com/wimspaargaren/final-unit/internal/ident"
 	"github.com/wimspaargaren/final-unit/internal/importer"
+	"github.com/wimspaargaren/final-unit/internal/runtime"
 	"github.com/wimspaargaren/final-unit/internal/testcase"
 	"github.com/wimspaargaren/final-unit/pkg/values"
 	"github.com/wimspaargaren/final-unit/pkg/variables"
@@ -23,6 +26,10 @@ import (
 // Organism organism is a set of testcases for functions of files in a given directory
 type Organism struct {
 	Fitness float64
+	// BuildFailed indicates the test cases of the organism don't compile, such organisms have no fitness
+	BuildFailed bool
+	// Panics indicates at least one test case of the organism panicked while measuring its fitness
+	Panics bool
 	// Create test cases for all files in a pkg
 	Files []*File
 }
@@ -46,6 +53,17 @@ func (o *Organism) UpdateAssertStmts(printed string, firstRun bool) {
 	}
 }
 
+// AddCapturePass adds the assert statements of an additional capture pass based on printed runtime result
+func (o *Organism) AddCapturePass(printed string) {
+	for _, f := range o.Files {
+		for funcName, testCases := range f.TestCases {
+			for i, testCase := range testCases {
+				testCase.RunTimeInfo.AddCaptureRun(printed, funcName, i)
+			}
+		}
+	}
+}
+
 // File file contains test cases for functions of a given file
 type File struct {
 	PackageName string
@@ -56,10 +74,18 @@ type File struct {
 	IdentGen    ident.IGen
 	Opts        *Options
 	Deco        *decorator.Deco
+	// Helpers helper functions constructing values shared by multiple test cases
+	Helpers []string
+	// DeclsShared indicates the declarations of the test cases are declared in the shared declarations file,
+	// instead of in the file itself
+	DeclsShared bool
+	// testNames cached names of the test methods, rendered for the test cases identified by testNamesFor
+	testNames    map[string][]string
+	testNamesFor string
 }
 
-// NewFile creates a new file object
-func NewFile(pathName string, pkgInfo *importer.PackageInfo, opts *Options, deco *decorator.Deco) *File {
+// NewFile creates a new file object, global declarations are named uniquely within given global scope
+func NewFile(pathName string, pkgInfo *importer.PackageInfo, opts *Options, deco *decorator.Deco, globalScope map[string]int) *File {
 	astFile, ok := pkgInfo.GetRootPkg()[pathName]
 	if !ok {
 		return nil
@@ -71,7 +97,7 @@ func NewFile(pathName string, pkgInfo *importer.PackageInfo, opts *Options, deco
 		PackageInfo: pkgInfo,
 		Opts:        opts,
 		Deco:        deco,
-		IdentGen:    ident.New(),
+		IdentGen:    ident.NewGenWithGlobal(globalScope),
 	}
 	file.TestCases = file.GetTestCasesForFunctionsInFile(pathName, astFile)
 	return file
@@ -96,6 +122,171 @@ type Options struct {
 	MaxRecursion     int
 	OrganismAmount   int
 	TestCasesPerFunc int
+	// TextUnmarshaler creates values for types implementing encoding.TextUnmarshaler
+	// by unmarshalling a generated string instead of filling their fields
+	TextUnmarshaler bool
+	// SeedOffsets seeds the generators of every test case with its own seed offset,
+	// so a single test case can be reproduced
+	SeedOffsets bool
+	// PromotedMethods generates test cases for the methods promoted to local struct types
+	// by embedding types of imported packages
+	PromotedMethods bool
+	// ZeroValueBodies generates zero value method bodies for synthetic interface implementations
+	// for methods with expensive return types, which are not called by the function under test
+	ZeroValueBodies bool
+	// ReachableMethodsOnly generates zero value method bodies for synthetic interface implementations
+	// for all methods, which are not statically reachable from the function under test
+	ReachableMethodsOnly bool
+	// LogAssertions generates non failing tests, which log expected and actual values
+	// instead of asserting them, useful for exploring the behaviour of a package
+	LogAssertions bool
+	// GoroutineLeaks verifies that functions spawning goroutines don't leak them
+	GoroutineLeaks bool
+	// ConcurrentInvocations amount of goroutines calling functions spawning goroutines or accessing package level
+	// variables concurrently after the assertions, so data races are detected by go test -race, if 0 it's disabled
+	ConcurrentInvocations int
+	// Comparers comparison expression templates used for asserting values per type name,
+	// types with an Equal(other T) bool method are compared using it by default
+	Comparers map[string]string
+	// LenBoundaryBias probability of using one of the boundary lengths 0, 1 or max for slices,
+	// since off-by-one bugs cluster at these boundaries
+	LenBoundaryBias float64
+	// BranchHintBias probability of using a constant a parameter is compared against in the function body,
+	// or a value next to it, so the branches guarded by the comparison are covered
+	BranchHintBias float64
+	// OverflowBias probability of using the min or max value of an integer parameter, which is an operand of an addition
+	// or multiplication in the function body, e.g. math.MaxInt64 for an int, so overflow behaviour is exercised
+	OverflowBias float64
+	// AliasBias probability of passing the same pointer, slice or map for multiple parameters of the same type,
+	// exercising the edge cases of functions like Merge(dst, src *Buf) when both refer to the same object
+	AliasBias float64
+	// SignalChannels creates channels of type chan struct{}, commonly used as done channels,
+	// which are closed or contain a buffered signal, so functions receiving from them proceed
+	SignalChannels bool
+	// Scaffold only generates the scaffolding of the test cases, creating the inputs and calling the function,
+	// capturing every result in a variable and leaving a TODO placeholder instead of the generated assertions
+	Scaffold bool
+	// ChanFactories creates channel values by calling a constructor of the package returning a channel of the type,
+	// e.g. func NewEvents() <-chan Event, for APIs handing out pre-populated channels, instead of making a channel
+	ChanFactories bool
+	// TempFiles creates *os.File parameters using temp files filled with generated content,
+	// which are closed and removed after the test. Opt-in as the generated tests touch the filesystem
+	TempFiles bool
+	// Helpers hoists the construction of values shared by multiple test cases of a file
+	// into helper functions taking testing.TB, reducing the size of generated files
+	Helpers bool
+	// ExportShims calls unexported functions through exported wrappers declared in finalunit_export_test.go,
+	// the generated tests are in the package under test, but the wrappers are reachable from hand-written tests
+	// in the external test package as well
+	ExportShims bool
+	// SourcePositions adds a comment with the source position of the function under test to every test case
+	SourcePositions bool
+	// ValueBudget max amount of elements of a generated collection including its nested collections,
+	// bounding the size of e.g. slices of maps of slices, unbounded if zero
+	ValueBudget int
+	// SyncMapEntries populates pointers to a sync.Map using Store calls, instead of passing an empty map
+	SyncMapEntries bool
+	// ReturnedFuncCalls amount of times a func returned by a function is invoked, asserting the result of every
+	// successive call, e.g. of a counter closure. Returned funcs aren't invoked if zero
+	ReturnedFuncCalls int
+	// TypedNilBias probability of using a typed nil pointer as interface value, e.g. (*impl)(nil), exercising
+	// the nil handling of functions for interfaces which aren't nil while their underlying value is
+	TypedNilBias float64
+	// SkipResultAssertions omits the assertions on the values returned by functions,
+	// e.g. when only their side effects are of interest
+	SkipResultAssertions bool
+	// SkipAssertFields regular expressions matching the names of struct fields which aren't asserted,
+	// e.g. .*ID$ or .*At$ for fields which are non deterministic like identifiers and timestamps
+	SkipAssertFields []string
+	// SideEffectAssertions asserts the receiver and the pointer, slice and map arguments after calling a function,
+	// capturing the mutations of its arguments
+	SideEffectAssertions bool
+	// Corpus path to a file with user supplied values, one per line prefixed by their type e.g. "string alice@example.com",
+	// which are used for basic types next to random values and as texts of types implementing encoding.TextUnmarshaler
+	Corpus string
+	// PanicReports emits test cases which panicked at runtime as skipped failing tests with a FIXME comment
+	// documenting the panic value and stack, so panics surface as bug reports instead of being asserted
+	PanicReports bool
+	// FilePerFunc generates a separate test file per function named after the function, instead of a test file
+	// per source file. The synthetic declarations and helpers are declared once in a shared file
+	FilePerFunc bool
+	// Gomock uses the mocks generated by mockgen for interfaces of the package under test, expecting calls to every
+	// method which return generated values, instead of generating synthetic implementations
+	Gomock bool
+	// DefaultTags initializes struct fields tagged with default e.g. `default:"8080"` to their declared default value
+	// as one of the generated variants next to random values, exercising the default code path of config structs
+	DefaultTags bool
+	// WholeSliceAssertions asserts slices and arrays of basic elements as a whole using Equal with a slice literal,
+	// which also catches length differences, instead of asserting every element
+	WholeSliceAssertions bool
+	// WrappedErrors creates error values using fmt.Errorf with %w wrapping a generated inner error,
+	// which is sometimes an error type declared in the package under test, so errors.Is and errors.As checks are covered
+	WrappedErrors bool
+	// MixedInterfaceSlices fills slices of interfaces with a mix of the types of the package under test implementing
+	// the interface and the synthetic implementation, covering type switches on the elements
+	MixedInterfaceSlices bool
+	// InterfaceImplementers passes a mix of the types of the package under test implementing the interface and the
+	// synthetic implementation for interface parameters. Types implementing the interface through pointer receivers
+	// are passed by address
+	InterfaceImplementers bool
+	// NonNilPointers generates all pointers as the address of a value, so functions dereferencing their parameters
+	// don't panic before their logic is reached. Recursive chains are terminated with a pointer to an empty struct
+	NonNilPointers bool
+	// GenericInstantiations amount of distinct sets of type arguments generic functions are tested with, picked from
+	// the constraints of their type parameters. Test cases are spread over the instantiations
+	GenericInstantiations int
+	// Allocs asserts the average amount of allocations of calling a function, measured using testing.AllocsPerRun,
+	// doesn't exceed the amount measured while capturing, guarding against allocation regressions
+	Allocs bool
+	// TestMain emits a TestMain calling the functions specified by the setup and teardown directives
+	// around running the tests, e.g. for packages requiring a database connection. Skipped if the package already declares a TestMain
+	TestMain bool
+	// CmpAssertions asserts composite values i.e. structs, slices and maps as a whole using go-cmp with cmpopts.SortSlices
+	// and cmpopts.EquateEmpty, so differences in ordering and between nil and empty values don't make tests flaky
+	CmpAssertions bool
+	// StringerAssertions asserts returned values of types implementing fmt.Stringer by comparing the result of
+	// their String method with the captured string, instead of asserting their fields, which is more stable for
+	// display oriented types
+	StringerAssertions bool
+	// AssertPackage import path of a custom assertion package, e.g. a team's internal assertion helpers
+	AssertPackage string
+	// AssertFuncs maps assertion names to qualified functions of the custom assertion package,
+	// e.g. EqualValues to myassert.Eq, which are called with the testing.T of the suite followed by
+	// the expected and actual value, LessOrEqual and ErrorAs are called with the actual value followed by
+	// the bound or target like testify. Assertions which aren't mapped keep using testify,
+	// logged assertions don't use them
+	AssertFuncs map[string]string
+	// HeaderTemplate text/template rendered at the top of every generated test file instead of the default header,
+	// e.g. to add a license notice. The template has access to the default .Header and the .File it's generated for
+	HeaderTemplate string
+	// TestNameTemplate text/template rendering the names of the generated test methods instead of Test<Func><Index>,
+	// e.g. 'Test_{{.Func}}_{{.Index}}'. The template has access to the .Suite, .Func and .Index of the test case,
+	// names are prefixed by Test if omitted and colliding names are made unique by suffixing a counter
+	TestNameTemplate string
+	// Logger logger used for generation diagnostics, defaults to the global logrus logger,
+	// allows capturing the diagnostics of a single run when embedding the generator
+	Logger log.FieldLogger
+
+	// corpus values loaded from the corpus file
+	corpus *corpus.Corpus
+	// headerTemplate parsed header template, nil if no header template is configured
+	headerTemplate *template.Template
+	// testNameTemplate parsed test name template, nil if no test name template is configured
+	testNameTemplate *template.Template
+	// skipAssertFields compiled patterns of the names of struct fields which aren't asserted
+	skipAssertFields []*regexp.Regexp
+	// assertFuncs validated custom assertion functions per assertion type
+	assertFuncs map[runtime.AssertStmtType]string
+	// assertAlias name under which the custom assertion package is imported
+	assertAlias string
+}
+
+// logger retrieves the logger used for generation diagnostics
+func (o *Options) logger() log.FieldLogger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return log.StandardLogger()
 }
 
 // Generator the generator
@@ -104,15 +295,21 @@ type Generator struct {
 	PackageInfo *importer.PackageInfo
 	Opts        *Options
 	Deco        *decorator.Deco
+	// GlobalScope names of global declarations shared by all files of all organisms, since files of the same package
+	// share their scope and crossover combines test cases of different organisms in a single file
+	GlobalScope map[string]int
 }
 
 // New creates a new generator for generating assignment statements for function parameters
 func New(dir string, opts *Options) (*Generator, error) {
+	// The parsed options are stored in a copy, so the options of the caller aren't modified
+	parsed := *opts
+	opts = &parsed
 	dir, err := filepath.Abs(dir)
 	if err != nil {
 		return nil, err
 	}
-	packageInfo, err := importer.ParseRoot(dir)
+	packageInfo, err := importer.ParseRoot(dir, opts.logger())
 	if err != nil {
 		return nil, err
 	}
@@ -121,11 +318,32 @@ func New(dir string, opts *Options) (*Generator, error) {
 	if err != nil {
 		return nil, err
 	}
+	opts.corpus, err = corpus.Load(opts.Corpus)
+	if err != nil {
+		return nil, err
+	}
+	opts.headerTemplate, err = parseHeaderTemplate(opts.HeaderTemplate)
+	if err != nil {
+		return nil, err
+	}
+	opts.testNameTemplate, err = parseTestNameTemplate(opts.TestNameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	opts.skipAssertFields, err = parseSkipAssertFields(opts.SkipAssertFields)
+	if err != nil {
+		return nil, err
+	}
+	opts.assertFuncs, opts.assertAlias, err = parseAssertFuncs(opts.AssertPackage, opts.AssertFuncs)
+	if err != nil {
+		return nil, err
+	}
 	return &Generator{
 		Dir:         dir,
 		PackageInfo: packageInfo,
 		Opts:        opts,
 		Deco:        deco,
+		GlobalScope: make(map[string]int),
 	}, nil
 }
 
@@ -141,12 +359,18 @@ func (g *Generator) GetTestCases() []*Organism {
 // GetNewOrganism get a single organism
 func (g *Generator) GetNewOrganism() *Organism {
 	var files []*File
+	// Files are processed in a fixed order, so the numbering of global declarations is the same for every run
+	fileNames := []string{}
 	for fileName := range g.PackageInfo.GetRootPkg() {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
 		if g.Deco.ShouldIgnoreFile(fileName) {
 			continue
 		}
-		file := NewFile(fileName, g.PackageInfo, g.Opts, g.Deco)
-		log.Debugf("GetNewOrganism for file: %s", fileName)
+		file := NewFile(fileName, g.PackageInfo, g.Opts, g.Deco, g.GlobalScope)
+		g.Opts.logger().Debugf("GetNewOrganism for file: %s", fileName)
 		files = append(files, file)
 	}
 	return NewOrganism(files)
@@ -158,34 +382,48 @@ func (g *Generator) GetNewOrganism() *Organism {
 func (f *File) GetTestCasesForFunctionsInFile(path string, astFile *ast.File) map[string][]*testcase.TestCase {
 	// List of test cases per func name
 	res := make(map[string][]*testcase.TestCase)
-	for _, decl := range astFile.Decls {
+	decls := astFile.Decls
+	if f.Opts.PromotedMethods {
+		decls = append(append([]ast.Decl{}, decls...), f.PromotedMethods(path, astFile)...)
+	}
+	// Decorators are specified per file name
+	_, fileName := filepath.Split(path)
+	for _, decl := range decls {
 		switch t := decl.(type) {
 		case *ast.FuncDecl:
-			log.Debugf("GetTestCasesForFunctionsInFile: %s", t.Name.Name)
+			f.Opts.logger().Debugf("GetTestCasesForFunctionsInFile: %s", t.Name.Name)
 
+			// Decorator can specify no test generation for given functions
+			if t.Name.Name != "main" && f.Deco.ShouldIgnoreFunc(fileName, t.Name.Name) {
+				continue
+			}
+			instances := f.FuncInstances(path, t)
+			if len(instances) == 0 {
+				f.Opts.logger().Warningf("unable to determine type arguments of generic func %s, skipping", t.Name.Name)
+				continue
+			}
 			testCases := []*testcase.TestCase{}
 			for i := 0; i < f.Opts.TestCasesPerFunc; i++ {
 				if t.Name.Name == "main" {
 					continue
 				}
-				// Decorator can specify no test generation for given functions
-				if f.Deco.ShouldIgnoreFunc(path, t.Name.Name) {
-					continue
-				}
 				pointer := &importer.PkgResolverPointer{
 					Dir:  f.PackageInfo.RootDir,
 					Pkg:  f.PackageInfo.RootPkg,
 					File: path,
 				}
-				testCase := testcase.New(t, pointer, f.PackageInfo, testcase.Options{
-					ValTestCase:  values.NewGenerator(),
-					VarTestCase:  variables.NewGenerator(),
-					MaxRecursion: f.Opts.MaxRecursion,
-					IdentGen:     f.IdentGen,
-				}, f.Deco)
+				// Test cases of generic functions are spread over the instantiations
+				instance := instances[i%len(instances)]
+				testCase := testcase.New(instance.FuncDecl, pointer, f.PackageInfo, f.TestCaseOptions(), f.Deco)
+				testCase.TypeArgs = instance.TypeArgs
 				testCase.Create()
+				// Cases of which values couldn't be generated wouldn't compile
+				if testCase.Invalid {
+					continue
+				}
 				testCases = append(testCases, testCase)
 			}
+			testCases = append(testCases, f.GetExpectErrorTestCases(path, t)...)
 
 			res[f.TestCasePrefix(t)+t.Name.Name] = testCases
 		default:
@@ -196,6 +434,95 @@ func (f *File) GetTestCasesForFunctionsInFile(path string, astFile *ast.File) ma
 	return res
 }
 
+// TestCaseOptions creates the options used for generating a test case
+func (f *File) TestCaseOptions() testcase.Options {
+	return testcase.Options{
+		ValTestCase:           values.NewGenerator(),
+		VarTestCase:           variables.NewGenerator(),
+		MaxRecursion:          f.Opts.MaxRecursion,
+		IdentGen:              f.IdentGen,
+		TextUnmarshaler:       f.Opts.TextUnmarshaler,
+		SeedOffsets:           f.Opts.SeedOffsets,
+		ZeroValueBodies:       f.Opts.ZeroValueBodies,
+		ReachableMethodsOnly:  f.Opts.ReachableMethodsOnly,
+		LogAssertions:         f.Opts.LogAssertions,
+		GoroutineLeaks:        f.Opts.GoroutineLeaks,
+		ConcurrentInvocations: f.Opts.ConcurrentInvocations,
+		Comparers:             f.Opts.Comparers,
+		LenBoundaryBias:       f.Opts.LenBoundaryBias,
+		BranchHintBias:        f.Opts.BranchHintBias,
+		OverflowBias:          f.Opts.OverflowBias,
+		AliasBias:             f.Opts.AliasBias,
+		Logger:                f.Opts.Logger,
+		SignalChannels:        f.Opts.SignalChannels,
+		TempFiles:             f.Opts.TempFiles,
+		ChanFactories:         f.Opts.ChanFactories,
+		Scaffold:              f.Opts.Scaffold,
+		ExportShims:           f.Opts.ExportShims,
+		SourcePositions:       f.Opts.SourcePositions,
+		ValueBudget:           f.Opts.ValueBudget,
+		Corpus:                f.Opts.corpus,
+		SyncMapEntries:        f.Opts.SyncMapEntries,
+		SkipResultAssertions:  f.Opts.SkipResultAssertions,
+		SkipAssertFields:      f.Opts.skipAssertFields,
+		SideEffectAssertions:  f.Opts.SideEffectAssertions,
+		TypedNilBias:          f.Opts.TypedNilBias,
+		NonNilPointers:        f.Opts.NonNilPointers,
+		ReturnedFuncCalls:     f.Opts.ReturnedFuncCalls,
+		Gomock:                f.Opts.Gomock,
+		PanicReports:          f.Opts.PanicReports,
+		DefaultTags:           f.Opts.DefaultTags,
+		WholeSliceAssertions:  f.Opts.WholeSliceAssertions,
+		WrappedErrors:         f.Opts.WrappedErrors,
+		MixedInterfaceSlices:  f.Opts.MixedInterfaceSlices,
+		InterfaceImplementers: f.Opts.InterfaceImplementers,
+		Allocs:                f.Opts.Allocs,
+		CmpAssertions:         f.Opts.CmpAssertions,
+		StringerAssertions:    f.Opts.StringerAssertions,
+		AssertFuncs:           f.Opts.assertFuncs,
+	}
+}
+
+// FuncInstances retrieves the instantiations of a function under test, generic functions are instantiated
+// with the configured amount of distinct sets of type arguments
+func (f *File) FuncInstances(path string, funcDecl *ast.FuncDecl) []*testcase.FuncInstance {
+	pointer := &importer.PkgResolverPointer{
+		Dir:  f.PackageInfo.RootDir,
+		Pkg:  f.PackageInfo.RootPkg,
+		File: path,
+	}
+	return testcase.FuncInstances(funcDecl, f.PackageInfo, pointer, f.Opts.GenericInstantiations)
+}
+
+// GetExpectErrorTestCases creates a test case for every expect error directive of given function
+func (f *File) GetExpectErrorTestCases(path string, funcDecl *ast.FuncDecl) []*testcase.TestCase {
+	testCases := []*testcase.TestCase{}
+	_, fileName := filepath.Split(path)
+	if funcDecl.Name.Name == "main" || f.Deco.ShouldIgnoreFunc(fileName, funcDecl.Name.Name) {
+		return testCases
+	}
+	for _, expectError := range f.Deco.GetExpectErrors(fileName, funcDecl.Name.Name) {
+		pointer := &importer.PkgResolverPointer{
+			Dir:  f.PackageInfo.RootDir,
+			Pkg:  f.PackageInfo.RootPkg,
+			File: path,
+		}
+		instances := f.FuncInstances(path, funcDecl)
+		if len(instances) == 0 {
+			return testCases
+		}
+		testCase := testcase.New(instances[0].FuncDecl, pointer, f.PackageInfo, f.TestCaseOptions(), f.Deco)
+		testCase.TypeArgs = instances[0].TypeArgs
+		testCase.ExpectError = expectError
+		testCase.Create()
+		if testCase.Invalid {
+			continue
+		}
+		testCases = append(testCases, testCase)
+	}
+	return testCases
+}
+
 // TestCasePrefix in case of receiver create prefix
 // this is need to ensure test results dont override eachother in case of:
 // func X() func (r T) X()
@@ -207,7 +534,7 @@ func (f *File) TestCasePrefix(funcDecl *ast.FuncDecl) string {
 	if len(funcDecl.Recv.List) == 1 {
 		return f.TypeToPrefix(funcDecl.Recv.List[0].Type)
 	}
-	log.Warningf("expected func receiver to have only one field")
+	f.Opts.logger().Warningf("expected func receiver to have only one field")
 	return f.IdentGen.Create(&ast.Ident{Name: "prefix"}).Name
 }
 
@@ -221,7 +548,7 @@ func (f *File) TypeToPrefix(e ast.Expr) string {
 	case *ast.StarExpr:
 		return f.TypeToPrefix(t.X)
 	default:
-		log.Warningf("unexpected field receiver type found: %T", e)
+		f.Opts.logger().Warningf("unexpected field receiver type found: %T", e)
 		return f.IdentGen.Create(&ast.Ident{Name: "prefix"}).Name
 	}
 }
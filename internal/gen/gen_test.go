@@ -1,7 +1,19 @@
 package gen
 
 import (
+	"encoding/json"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -32,15 +44,15 @@ func (s *PrintStmtTestSuite) TestExamplesSingleFile() {
 				{
 					Func: "EllipsisStringFunc",
 					ResStmts: []string{
-						"x := \"Bart Beatty\"",
-						"EllipsisStringFunc(x)",
+						"x := []string{\"Bart Beatty\", \"Cordia Jacobi\", \"Nickolas Emard\", \"Hollis Dickens\", \"Stacy Dietrich\", \"Aleen Legros\", \"Adelia Metz\"}",
+						"EllipsisStringFunc(x...)",
 					},
 				},
 				{
 					Func: "EllipsisStructFunc",
 					ResStmts: []string{
-						`x := SomeStruct{X: -73}`,
-						"EllipsisStructFunc(x)",
+						`x := []SomeStruct{SomeStruct{X: -77}, SomeStruct{X: 70}, SomeStruct{X: -95}, SomeStruct{}, SomeStruct{}, SomeStruct{}, SomeStruct{}, SomeStruct{}, SomeStruct{}}`,
+						"EllipsisStructFunc(x...)",
 					},
 				},
 			},
@@ -421,11 +433,6 @@ func (s *PrintStmtTestSuite) TestExamplesSingleFile() {
 					ResStmts: []string{"x := func() reflect.Type {\n\treturn nil\n}()", "ReflectType(x)"},
 					ResDecls: []string{},
 				},
-				{
-					Func:     "AstSelection",
-					ResStmts: []string{"x := func() ast.Selection {\n\treturn nil\n}()", "AstSelection(x)"},
-					ResDecls: []string{},
-				},
 			},
 		},
 		{
@@ -539,8 +546,7 @@ func (s *PrintStmtTestSuite) TestExamplesSingleFile() {
 				{
 					Func: "FuncCycle",
 					ResStmts: []string{
-						"pointerA3 := A{}",
-						"pointerB2 := B{X: &pointerA3, Y: -80}",
+						"pointerB2 := B{X: nil, Y: -80}",
 						"pointerA2 := A{X: &pointerB2, Y: -45}",
 						"pointerB := B{X: &pointerA2, Y: -73}",
 						"pointerA := A{X: &pointerB, Y: -92}",
@@ -567,8 +573,7 @@ func (s *PrintStmtTestSuite) TestExamplesSingleFile() {
 				{
 					Func: "CycleComplicated",
 					ResStmts: []string{
-						"pointerA3 := somepkg.A{}",
-						"pointerB2 := somepkg.B{X: func() (io.ReadCloser, error) {\n\to14 := &TestB{}\n\to15 := func() error {\n\t\treturn fmt.Errorf(\"very error\")\n\t}()\n\treturn o14, o15\n}, Y: &TestB{}, Z: &pointerA3}",
+						"pointerB2 := somepkg.B{X: func() (io.ReadCloser, error) {\n\to14 := &TestB{}\n\to15 := func() error {\n\t\treturn fmt.Errorf(\"very error\")\n\t}()\n\treturn o14, o15\n}, Y: &TestB{}, Z: nil}",
 						"pointerA2 := somepkg.A{B: &pointerB2}",
 						"pointerB := somepkg.B{X: func() (io.ReadCloser, error) {\n\to9 := &TestB3{}\n\to13 := func() error {\n\t\treturn nil\n\t}()\n\treturn o9, o13\n}, Y: &TestB{}, Z: &pointerA2}",
 						"pointerA := somepkg.A{B: &pointerB}",
@@ -625,12 +630,7 @@ func (s *PrintStmtTestSuite) TestExamplesSingleFile() {
 				OrganismAmount:   1,
 				TestCasesPerFunc: 1,
 			}
-			seed.SetRandomSeed(1)
-			generator, err := New(test.Path, opts)
-			s.Require().NoError(err)
-			organisms := generator.GetTestCases()
-			s.Require().Equal(1, len(organisms))
-			files := organisms[0].Files
+			files := s.generate(test.Path, opts).Files
 			s.Require().Equal(1, len(files))
 			res := files[0].TestCases
 
@@ -701,12 +701,7 @@ func (s *PrintStmtTestSuite) TestExamplesMultiFile() {
 				MaxRecursion:     3,
 				TestCasesPerFunc: 1,
 			}
-			seed.SetRandomSeed(1)
-			generator, err := New(test.Path, opts)
-			s.Require().NoError(err)
-			organisms := generator.GetTestCases()
-			s.Require().Equal(1, len(organisms))
-			files := organisms[0].Files
+			files := s.generate(test.Path, opts).Files
 			s.Require().Equal(2, len(files))
 
 			for _, testResult := range test.TestResults {
@@ -840,12 +835,7 @@ func (s *PrintStmtTestSuite) TestExampleImports() {
 				TestCasesPerFunc: 1,
 				MaxRecursion:     10,
 			}
-			seed.SetRandomSeed(1)
-			generator, err := New(test.Path, opts)
-			s.Require().NoError(err)
-			organisms := generator.GetTestCases()
-			s.Require().Equal(1, len(organisms))
-			files := organisms[0].Files
+			files := s.generate(test.Path, opts).Files
 			s.Require().Equal(1, len(files))
 
 			for _, testResult := range test.TestResults {
@@ -941,16 +931,2831 @@ func (s *PrintStmtTestSuite) TestChan() {
 	}
 }
 
-func TestPrintStmtTestSuite(t *testing.T) {
-	suite.Run(t, new(PrintStmtTestSuite))
+func (s *PrintStmtTestSuite) TestMaxDepthDirective() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_max_depth", opts)
+
+	// The max-depth directive of Chain overrides the global max recursion
+	funcTestCases := s.GetTestCase(organism.Files, "ChainLength")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerChain4 := Chain{Next: nil, Value: -80}",
+		"pointerChain3 := Chain{Next: &pointerChain4, Value: -45}",
+		"pointerChain2 := Chain{Next: &pointerChain3, Value: -73}",
+		"pointerChain := Chain{Next: &pointerChain2, Value: -92}",
+		"pointerC2 := Chain{Next: &pointerChain, Value: 70}",
+		"pointerC := Chain{Next: &pointerC2, Value: -41}",
+		"c := &pointerC",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "ListLength")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerList := List{Next: nil, Value: 89}",
+		"pointerL2 := List{Next: &pointerList, Value: -47}",
+		"pointerL := List{Next: &pointerL2, Value: 28}",
+		"l := &pointerL",
+	}, funcTestCases[0].Stmts)
 }
 
-func (s *PrintStmtTestSuite) GetTestCase(files []*File, funcName string) []*testcase.TestCase {
-	for _, file := range files {
-		if x, ok := file.TestCases[funcName]; ok {
-			return x
+func (s *PrintStmtTestSuite) TestNonNilPointers() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		NonNilPointers:   true,
+	}
+	organism := s.generate("../../test/data/inputs/example_max_depth", opts)
+
+	// Recursive chains end with a pointer to an empty struct instead of nil
+	for _, funcName := range []string{"ChainLength", "ListLength"} {
+		funcTestCases := s.GetTestCase(organism.Files, funcName)
+		s.Require().Equal(1, len(funcTestCases))
+		for _, stmt := range funcTestCases[0].Stmts {
+			s.NotContains(stmt, "nil")
 		}
 	}
-	s.Fail("test not found")
-	return nil
+	s.Equal([]string{
+		"pointerList2 := List{}",
+		"pointerList := List{Next: &pointerList2, Value: 89}",
+		"pointerL2 := List{Next: &pointerList, Value: -47}",
+		"pointerL := List{Next: &pointerL2, Value: 28}",
+		"l := &pointerL",
+	}, s.GetTestCase(organism.Files, "ListLength")[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestExpectError() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_expect_error", opts)
+	funcTestCases := s.GetTestCase(organism.Files, "Divide")
+	s.Require().Equal(2, len(funcTestCases))
+	s.Nil(funcTestCases[0].ExpectError)
+	s.Equal(0, len(funcTestCases[0].RunTimeInfo.Expectations))
+
+	expectErrorTestCase := funcTestCases[1]
+	s.Require().NotNil(expectErrorTestCase.ExpectError)
+	s.Require().Equal(2, len(expectErrorTestCase.Stmts))
+	s.Equal("b := 0", expectErrorTestCase.Stmts[1])
+	s.Equal("Divide(a, b)", expectErrorTestCase.FuncStmt)
+	s.Equal("out, out2 := Divide(a, b)", expectErrorTestCase.FuncPrintStmt)
+	s.Equal([]string{"s.Error(out2)"}, expectErrorTestCase.RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestLogAssertions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		LogAssertions:    true,
+	}
+	organism := s.generate("../../test/data/inputs/example_expect_error", opts)
+	funcTestCases := s.GetTestCase(organism.Files, "Divide")
+	s.Require().Equal(2, len(funcTestCases))
+
+	printed := `<START;Divide0>
+{ "type": "int", "var_name": "out", "val": "3"}
+{ "type": "error", "var_name": "out2", "val": "nil"}
+<END;Divide0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{
+		`s.T().Logf("out: expected %v, actual %v", int(3), out)`,
+		`s.T().Logf("out2: expected no error, actual %v", out2)`,
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	s.Equal([]string{`s.T().Logf("out2: expected error, actual %v", out2)`}, funcTestCases[1].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestNamedFuncType() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_named_func", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Register")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"h := Handler(func(int) int {\n\to := -80\n\treturn o\n})"}, funcTestCases[0].Stmts)
+
+	// Named func types of other packages are converted using their selector
+	funcTestCases = s.GetTestCase(organism.Files, "Use")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"m := ext.Middleware(func(x int) int {\n\to := -45\n\treturn o\n})"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestGoroutineLeaks() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		GoroutineLeaks:   true,
+	}
+	organism := s.generate("../../test/data/inputs/example_goroutines", opts)
+
+	tests := []struct {
+		Func      string
+		LeakCheck bool
+	}{
+		{Func: "Leak", LeakCheck: true},
+		{Func: "Sum", LeakCheck: true},
+		{Func: "Double", LeakCheck: false},
+	}
+	for _, test := range tests {
+		s.Run(test.Func, func() {
+			funcTestCases := s.GetTestCase(organism.Files, test.Func)
+			s.Require().Equal(1, len(funcTestCases))
+			s.Equal(test.LeakCheck, funcTestCases[0].HasLeakCheck())
+		})
+	}
+	funcTestCases := s.GetTestCase(organism.Files, "Sum")
+	s.Equal("goroutines", funcTestCases[0].LeakCheckIdent)
+}
+
+func (s *PrintStmtTestSuite) TestConcurrentInvocations() {
+	opts := &Options{
+		MaxRecursion:          3,
+		OrganismAmount:        1,
+		TestCasesPerFunc:      1,
+		ConcurrentInvocations: 8,
+	}
+	organism := s.generate("../../test/data/inputs/example_race", opts)
+
+	tests := []struct {
+		Func      string
+		RaceCheck bool
+	}{
+		{Func: "Add", RaceCheck: true},
+		{Func: "Total", RaceCheck: true},
+		{Func: "CounterAdd", RaceCheck: false},
+	}
+	for _, test := range tests {
+		s.Run(test.Func, func() {
+			funcTestCases := s.GetTestCase(organism.Files, test.Func)
+			s.Require().Equal(1, len(funcTestCases))
+			s.Equal(test.RaceCheck, funcTestCases[0].HasRaceCheck())
+		})
+	}
+	funcTestCases := s.GetTestCase(organism.Files, "Add")
+	s.Equal("raceWg", funcTestCases[0].RaceCheckIdent)
+	s.Equal("invocation", funcTestCases[0].RaceIndexIdent)
+}
+
+func (s *PrintStmtTestSuite) TestComparers() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Comparers: map[string]string{
+			"Celsius": "math.Abs(float64({{.Actual}}-{{.Expected}})) < 0.01",
+		},
+	}
+	organism := s.generate("../../test/data/inputs/example_comparer", opts)
+
+	moveTestCases := s.GetTestCase(organism.Files, "Move")
+	s.Require().Equal(1, len(moveTestCases))
+	s.Equal([]string{
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"type_name\": \"%s\", \"pkg\": \"%s\", \"val\": %#v}`, `comparer`, `out`, `Point`, `comparer`, fmt.Sprintf(`%#v`, out))",
+		"fmt.Println(\"\")",
+	}, moveTestCases[0].ResultStmts)
+	toCelsiusTestCases := s.GetTestCase(organism.Files, "ToCelsius")
+	s.Require().Equal(1, len(toCelsiusTestCases))
+
+	// Pointers and unexported fields of other packages can't be reproduced from the Go syntax representation
+	extendTestCases := s.GetTestCase(organism.Files, "Extend")
+	s.Require().Equal(1, len(extendTestCases))
+	s.Contains(extendTestCases[0].ResultStmts, "fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out.Stops`, out.Stops)")
+	s.NotContains(strings.Join(extendTestCases[0].ResultStmts, "\n"), "`comparer`")
+	scaleTestCases := s.GetTestCase(organism.Files, "Scale")
+	s.Require().Equal(1, len(scaleTestCases))
+	s.Equal([]string{
+		"_ = out",
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `struct`, `out`)",
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out.X`, out.X)",
+		"fmt.Printf(`}`)",
+		"fmt.Println(\"\")",
+	}, scaleTestCases[0].ResultStmts)
+
+	printed := `<START;Move0>
+{ "type": "comparer", "var_name": "out", "type_name": "Point", "pkg": "comparer", "val": "comparer.Point{X:3, Y:-4, moves:1}"}
+<END;Move0>
+<START;ToCelsius0>
+{ "type": "comparer", "var_name": "out", "type_name": "Celsius", "pkg": "comparer", "val": "100"}
+<END;ToCelsius0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{"s.True(out.Equal(Point{X:3, Y:-4, moves:1}))"}, moveTestCases[0].RunTimeInfo.GetAssertStmts())
+	s.Equal([]string{"s.True(math.Abs(float64(out-100)) < 0.01)"}, toCelsiusTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestSelfQualified() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	// Self qualified packages don't compile, so the input is a separate module
+	organism := s.generate("../../test/data/inputs/example_self_qualified", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Use")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"b := Bar{X: -80}",
+		"pointerP := Bar{X: -45}",
+		"p := &pointerP",
+		"l := Level(-73)",
+	}, funcTestCases[0].Stmts)
+	s.Equal([]string{
+		"_ = out",
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `struct`, `out`)",
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out.X`, out.X)",
+		"fmt.Printf(`}`)",
+		"fmt.Println(\"\")",
+	}, funcTestCases[0].ResultStmts)
+}
+
+func (s *PrintStmtTestSuite) TestInvariant() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_invariant", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Reverse")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("out := Reverse(input)", funcTestCases[0].FuncPrintStmt)
+	s.Equal([]string{"s.True(len(out) == len(input))"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+
+	// Parameters renamed by the generator are substituted
+	funcTestCases = s.GetTestCase(organism.Files, "Split")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("head, tail := Split(s2, at)", funcTestCases[0].FuncPrintStmt)
+	s.Equal([]string{"s.True(len(head)+len(tail) == len(s2))"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestInterfaceDiamondEmbedding() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_interface_diamond", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Echo")
+	s.Require().Equal(1, len(funcTestCases))
+	// Close is embedded through both Reader and Writer, but only implemented once
+	s.Equal([]string{
+		"type TestReadwriter struct {\n}",
+		"func (s *TestReadwriter) Close() error {\n\to := func() error {\n\t\treturn nil\n\t}()\n\treturn o\n}",
+		"func (s *TestReadwriter) Read() string {\n\to2 := \"Gerson Beahan\"\n\treturn o2\n}",
+		"func (s *TestReadwriter) Write(msg string) error {\n\to3 := func() error {\n\t\treturn fmt.Errorf(\"very error\")\n\t}()\n\treturn o3\n}",
+	}, funcTestCases[0].Decls)
+}
+
+func (s *PrintStmtTestSuite) TestInterfaceKeyedMaps() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	// The any identifier requires a newer go version than the module, so the input is constrained to go1.18
+	organism := s.generate("../../test/data/inputs/example_map_any", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Count")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"m := map[any]int{uint64(35): -73}"}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Describe")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"m := map[interface {\n}]fmt.Stringer{int16(70): &testM{}, uintptr(0): &testM2{}, int32(90): &testM3{}, 35.816935: &testM{}, complex128(-68): &testM{}, \"Sheldon Kassulke\": &testM{}}"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestBranchHints() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+		BranchHintBias:   0.5,
+	}
+	organism := s.generate("../../test/data/inputs/example_branch_hints", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Access")
+	s.Require().Equal(10, len(funcTestCases))
+	stmts := []string{}
+	for _, funcTestCase := range funcTestCases {
+		stmts = append(stmts, funcTestCase.Stmts...)
+	}
+	s.Contains(stmts, `role := "admin"`)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Bucket")
+	s.Require().Equal(10, len(funcTestCases))
+	stmts = []string{}
+	for _, funcTestCase := range funcTestCases {
+		stmts = append(stmts, funcTestCase.Stmts...)
+	}
+	// Values next to the boundary are used as well, unless they overflow the type
+	s.Equal([]string{"size := int8(127)", "ratio := 0.5", "size := int8(-85)", "ratio := 0.5", "size := int8(127)", "ratio := 72.498287", "size := int8(126)", "ratio := 0.5", "size := int8(90)", "ratio := 39.343833", "size := int8(73)", "ratio := 0.5", "size := int8(-92)", "ratio := 0.5", "size := int8(-35)", "ratio := -39.695464", "size := int8(127)", "ratio := 8.831115", "size := int8(-3)", "ratio := 0.5"}, stmts)
+}
+
+func (s *PrintStmtTestSuite) TestChanFactories() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		ChanFactories:    true,
+	}
+	organism := s.generate("../../test/data/inputs/example_chan_factory", opts)
+
+	// Channels created by a factory are owned by the package, so they aren't closed
+	funcTestCases := s.GetTestCase(organism.Files, "Drain")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"events := NewEvents(-45)"}, funcTestCases[0].Stmts)
+	s.Empty(funcTestCases[0].ChanIdents)
+
+	// Defined channel types use their constructor as well
+	funcTestCases = s.GetTestCase(organism.Files, "Next")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"ticks := NewTicks()"}, funcTestCases[0].Stmts)
+
+	// Channels without a factory are made
+	funcTestCases = s.GetTestCase(organism.Files, "Forward")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"names2 := make(chan string)", "names := names2", "name := \"Cordia Jacobi\""}, funcTestCases[0].Stmts)
+	s.Equal([]string{"names2"}, funcTestCases[0].ChanIdents)
+}
+
+func (s *PrintStmtTestSuite) TestOverflowBias() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+		OverflowBias:     0.5,
+	}
+	organism := s.generate("../../test/data/inputs/example_overflow", opts)
+
+	stmts := func(funcName string) []string {
+		funcTestCases := s.GetTestCase(organism.Files, funcName)
+		s.Require().Equal(10, len(funcTestCases))
+		res := []string{}
+		for _, funcTestCase := range funcTestCases {
+			res = append(res, funcTestCase.Stmts...)
+		}
+		return res
+	}
+	add := stmts("Add")
+	s.Contains(add, "a := 9223372036854775807")
+	s.Contains(add, "b := -9223372036854775808")
+
+	// Only operands of additions and multiplications use the bounds of their type
+	scale := stmts("Scale")
+	s.Contains(scale, "x := int8(127)")
+	s.NotContains(scale, "factor := uint16(65535)")
+	s.NotContains(strings.Join(stmts("Sub"), "\n"), "9223372036854775807")
+}
+
+func (s *PrintStmtTestSuite) TestSignalChannels() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 2,
+		SignalChannels:   true,
+	}
+	seed.SetRandomSeed(2)
+	generator, err := New("../../test/data/inputs/example_signal_chan", opts)
+	s.Require().NoError(err)
+	organisms := generator.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+
+	funcTestCases := s.GetTestCase(organisms[0].Files, "Wait")
+	s.Require().Equal(2, len(funcTestCases))
+	s.Equal([]string{"done2 := make(chan struct{})", "close(done2)", "done := done2", "values2 := make(chan int, 6)", "values2 <- 71", "values2 <- -99", "values2 <- -16", "values2 <- -5", "values2 <- 74", "values2 <- -66", "close(values2)", "values := values2"}, funcTestCases[0].Stmts)
+	s.Equal([]string{"done2 := make(chan struct{}, 1)", "done2 <- struct{}{}", "done := done2", "values2 := make(chan int)", "close(values2)", "values := values2"}, funcTestCases[1].Stmts)
+	// Signal channels are not closed after calling the function
+	s.Empty(funcTestCases[0].ChanIdents)
+}
+
+func (s *PrintStmtTestSuite) TestRecvChanOfStructs() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_recv_chan", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "CountHighPriority")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"events2 := make(chan Event, 7)",
+		"events2 <- Event{ID: -80, Kind: Kind{Name: \"Gerson Beahan\", Priority: -92}, Tags: []string{\"Nickolas Emard\", \"Hollis Dickens\", \"Stacy Dietrich\", \"Aleen Legros\", \"Adelia Metz\", \"Sunny Gerlach\", \"Austin Hackett\", \"Briana Bauch\", \"Delaney Howell\"}}",
+		"events2 <- Event{ID: -49, Kind: Kind{Name: \"Christian Bartoletti\", Priority: 2}, Tags: []string{\"Mathias Hauck\", \"Verla Abshire\", \"Elias Roob\", \"Victoria Green\", \"Alba Reynolds\", \"Guido Witting\", \"Emmy Becker\", \"Ariane Lebsack\"}}",
+		"events2 <- Event{ID: -94, Kind: Kind{Name: \"Helmer Crooks\", Priority: -61}, Tags: []string{}}",
+		"events2 <- Event{}",
+		"events2 <- Event{}",
+		"events2 <- Event{}",
+		"events2 <- Event{}",
+		"close(events2)",
+		"events := events2",
+		"threshold := -31",
+	}, funcTestCases[0].Stmts)
+	// Receive only channels are closed after sending the values, instead of after calling the function
+	s.Empty(funcTestCases[0].ChanIdents)
+}
+
+func (s *PrintStmtTestSuite) TestTimeField() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_time_field", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "NewRecord")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"_ = out", "fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `struct`, `out`)", "fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out.ID`, out.ID)", "fmt.Printf(`}`)", "fmt.Println(\"\")", "fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `struct`, `out`)", "fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%d.%09d\", \"zero\": %t, \"now\": %t}`, `time`, `out.CreatedAt`, out.CreatedAt.Unix(), out.CreatedAt.Nanosecond(), out.CreatedAt.IsZero(), time.Since(out.CreatedAt) < time.Second && time.Since(out.CreatedAt) > -time.Second)", "fmt.Printf(`}`)", "fmt.Println(\"\")"}, funcTestCases[0].ResultStmts)
+
+	printed := `<START;NewRecord0>
+{ "type": "struct", "var_name": "out", "child": { "type": "int", "var_name": "out.ID", "val": "-73"}}
+{ "type": "struct", "var_name": "out", "child": { "type": "time", "var_name": "out.CreatedAt", "val": "1600000000.000000000", "zero": false, "now": true}}
+<END;NewRecord0>
+<START;Epoch0>
+{ "type": "struct", "var_name": "out", "child": { "type": "int", "var_name": "out.ID", "val": "1"}}
+{ "type": "struct", "var_name": "out", "child": { "type": "time", "var_name": "out.CreatedAt", "val": "946684800.000000000", "zero": false, "now": false}}
+<END;Epoch0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{"s.EqualValues(int(-73),out.ID)", "s.WithinDuration(time.Now(),out.CreatedAt,time.Second)"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	funcTestCases = s.GetTestCase(organism.Files, "Epoch")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"s.EqualValues(int(1),out.ID)", "s.True(time.Unix(946684800, 0).Equal(out.CreatedAt))"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestHelpers() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 3,
+		Helpers:          true,
+	}
+	organism := s.generate("../../test/data/inputs/example_helpers", opts)
+
+	organism.HoistHelpers()
+	s.Require().Equal(1, len(organism.Files))
+	s.Equal([]string{"func newTestConfig(tb testing.TB) Config {\n\ttb.Helper()\n\treturn Config{Verbose: false, Strict: false}\n}", "func newTestConfig2(tb testing.TB) Config {\n\ttb.Helper()\n\treturn Config{Verbose: true, Strict: false}\n}"}, organism.Files[0].Helpers)
+	stmts := []string{}
+	for _, funcName := range []string{"Describe", "Mode"} {
+		for _, funcTestCase := range s.GetTestCase(organism.Files, funcName) {
+			stmts = append(stmts, funcTestCase.Stmts...)
+		}
+	}
+	// Constructions used by a single test case only are not hoisted
+	s.Equal([]string{"c := newTestConfig(s.T())", "run := 28", "c := newTestConfig2(s.T())", "run := -61", "c := newTestConfig(s.T())", "run := -77", "pointerC := Config{Verbose: false, Strict: true}", "c := &pointerC", "pointerC := Config{Verbose: true, Strict: true}", "c := &pointerC", "pointerC := newTestConfig2(s.T())", "c := &pointerC"}, stmts)
+}
+
+func (s *PrintStmtTestSuite) TestUnnamedStructEmbeddedInterface() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_unnamed_embedded", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Count")
+	s.Require().Equal(1, len(funcTestCases))
+	// Embedded interfaces are assigned a synthetic implementation using the name of the embedded type as key
+	s.Equal([]string{"type testX struct {\n}", "func (s *testX) Read(p []byte) (n int, err error) {\n\to := -80\n\to2 := func() error {\n\t\treturn fmt.Errorf(\"very error\")\n\t}()\n\treturn o, o2\n}"}, funcTestCases[0].Decls)
+	s.Equal([]string{"x := struct {\n\tio.Reader\n\tN\tint\n}{Reader: &testX{}, N: -73}"}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Shout")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"type TestNamer struct {\n}", "func (s *TestNamer) Name() string {\n\to := \"Lina Carroll\"\n\treturn o\n}"}, funcTestCases[0].Decls)
+	s.Equal([]string{"x := struct {\n\tNamer\n\tTimes\tint\n}{Namer: &TestNamer{}, Times: -41}"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestPointerConstructor() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_pointer_constructor", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Call")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"svc := NewService(\"Cordia Jacobi\")", "method := \"Nickolas Emard\""}, funcTestCases[0].Stmts)
+
+	// Without a constructor the address of a filled literal is used
+	funcTestCases = s.GetTestCase(organism.Files, "Join")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"pointerB := strings.Builder{}", "b := &pointerB", "s2 := \"Hollis Dickens\""}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestFallibleConstructor() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_fallible_constructor", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Fetch")
+	s.Require().Equal(1, len(funcTestCases))
+	// The generated endpoint is rejected by the constructor, so the test case is skipped instead of failing
+	s.Equal([]string{
+		"client, err := NewClient(\"Cordia Jacobi\")",
+		"if err != nil {\n\ts.T().Skipf(\"NewClient rejected the generated arguments: %v\", err)\n}",
+		"c := client",
+		"path := \"Nickolas Emard\"",
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestGenericInstances() {
+	opts := &Options{
+		MaxRecursion:     2,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	// Generics require a newer go version than the module, so the input is constrained to go1.18
+	organism := s.generate("../../test/data/inputs/example_generic_instance", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Peek")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerS2 := \"Sunny Gerlach\"",
+		"pointerStack := \"Alba Reynolds\"",
+		"pointerS22 := Stack[string]{Items: []string{\"Austin Hackett\", \"Briana Bauch\", \"Delaney Howell\", \"Sheldon Kassulke\", \"Talia Hudson\", \"Mathias Hauck\", \"Verla Abshire\", \"Elias Roob\", \"Victoria Green\"}, Top: &pointerStack, Next: nil}",
+		"s2 := Stack[string]{Items: []string{\"Bart Beatty\", \"Cordia Jacobi\", \"Nickolas Emard\", \"Hollis Dickens\", \"Stacy Dietrich\", \"Aleen Legros\", \"Adelia Metz\"}, Top: &pointerS2, Next: &pointerS22}",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Swap")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"p := Pair[string, int]{Key: \"Guido Witting\", Value: 5}"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestGenericFuncInstantiations() {
+	opts := &Options{
+		MaxRecursion:          2,
+		OrganismAmount:        1,
+		TestCasesPerFunc:      3,
+		GenericInstantiations: 3,
+	}
+	organism := s.generate("../../test/data/inputs/example_generic_func", opts)
+
+	funcStmts := func(funcName string) []string {
+		res := []string{}
+		for _, testCase := range s.GetTestCase(organism.Files, funcName) {
+			res = append(res, testCase.FuncStmt)
+		}
+		return res
+	}
+	// Every test case uses a distinct instantiation, picked from the terms of the constraint
+	s.Equal([]string{"Sum[int](values)", "Sum[int64](values)", "Sum[float64](values)"}, funcStmts("Sum"))
+	s.Equal([]string{
+		"Lookup[int, string](m, key, fallback)",
+		"Lookup[string, bool](m, key, fallback)",
+		"Lookup[bool, float64](m, key, fallback)",
+	}, funcStmts("Lookup"))
+	// Core types referring to other type parameters use the type arguments picked for these type parameters
+	s.Equal([]string{
+		"Clone[[]string, string](s2)",
+		"Clone[[]bool, bool](s2)",
+		"Clone[[]float64, float64](s2)",
+	}, funcStmts("Clone"))
+	s.Equal([]string{
+		"Size[map[string]bool, string, bool](m)",
+		"Size[map[bool]float64, bool, float64](m)",
+		"Size[map[float64]struct {\n\tID\tint\n\tName\tstring\n}, float64, struct {\n\tID\tint\n\tName\tstring\n}](m)",
+	}, funcStmts("Size"))
+	// Values of struct type arguments are generated like any unnamed struct
+	s.Contains(s.GetTestCase(organism.Files, "Size")[2].Stmts[0], `{ID: 58, Name: "Ana Christiansen"}`)
+	// Mutations keep the instantiation of the mutated test case
+	mutation := s.GetTestCase(organism.Files, "Sum")[1].NewCase()
+	s.Equal("Sum[int64](values)", mutation.FuncStmt)
+	// Type arguments can't be determined for constraints with methods
+	for _, f := range organism.Files {
+		s.NotContains(f.TestCases, "Describe")
+	}
+}
+
+func (s *PrintStmtTestSuite) TestImportedGenericInstances() {
+	opts := &Options{
+		MaxRecursion:     2,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_generic_import", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Depth")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerS2 := container.Stack[string]{Items: []string{\"Sunny Gerlach\", \"Austin Hackett\", \"Briana Bauch\", \"Delaney Howell\", \"Sheldon Kassulke\", \"Talia Hudson\", \"Mathias Hauck\", \"Verla Abshire\", \"Elias Roob\"}, Next: nil}",
+		"s2 := container.Stack[string]{Items: []string{\"Bart Beatty\", \"Cordia Jacobi\", \"Nickolas Emard\", \"Hollis Dickens\", \"Stacy Dietrich\", \"Aleen Legros\", \"Adelia Metz\"}, Next: &pointerS2}",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Key")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"p := container.Pair[string, int]{Key: \"Victoria Green\", Value: -92}"}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Size")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"s2 := container.Set[int](map[int]struct {\n}{12: struct{}{}})"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestGenericInterfaces() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_generic_interface", opts)
+
+	// Methods of the implementation use the type arguments of the instantiation
+	funcTestCases := s.GetTestCase(organism.Files, "Lookup")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"s2 := &TestStore{}", "key := \"Austin Hackett\""}, funcTestCases[0].Stmts)
+	s.Equal([]string{
+		"type TestStore struct {\n}",
+		"func (s *TestStore) Get(key string) (int, bool) {\n\to := -80\n\to2 := true\n\treturn o, o2\n}",
+		"func (s *TestStore) Keys() []string {\n\to3 := []string{\"Cordia Jacobi\", \"Nickolas Emard\", \"Hollis Dickens\", \"Stacy Dietrich\", \"Aleen Legros\", \"Adelia Metz\", \"Sunny Gerlach\"}\n\treturn o3\n}",
+	}, funcTestCases[0].Decls)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Drain")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"s2 := &TestSource{}", "n := -85"}, funcTestCases[0].Stmts)
+	s.Equal([]string{
+		"type TestSource struct {\n}",
+		"func (s *TestSource) Next() float64 {\n\to := 35.816935\n\treturn o\n}",
+	}, funcTestCases[0].Decls)
+}
+
+func (s *PrintStmtTestSuite) TestMixedInterfaceSlices() {
+	opts := &Options{
+		MaxRecursion:         3,
+		OrganismAmount:       1,
+		TestCasesPerFunc:     1,
+		MixedInterfaceSlices: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_mixed_interface", opts)
+
+	// Elements are either Celsius, *Point or the synthetic implementation, Code has another String signature
+	funcTestCases := s.GetTestCase(organism.Files, "Describe")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerItems := Point{X: 31, Y: 41}",
+		"pointerItems2 := Point{X: 90, Y: -37}",
+		"pointerItems3 := Point{X: -95, Y: -12}",
+		"items := []fmt.Stringer{&testItems{}, &testItems2{}, &pointerItems, Celsius(62.727992), &pointerItems2, &testItems3{}, &pointerItems3}",
+	}, funcTestCases[0].Stmts)
+	s.Equal(6, len(funcTestCases[0].Decls))
+}
+
+func (s *PrintStmtTestSuite) TestInterfaceImplementers() {
+	opts := &Options{
+		MaxRecursion:          3,
+		OrganismAmount:        1,
+		TestCasesPerFunc:      8,
+		InterfaceImplementers: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_pointer_receiver", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Grow")
+	s.Require().Equal(8, len(funcTestCases))
+	// Square implements Shape through pointer receivers only, so its address is passed
+	s.Equal([]string{"pointerS2 := Square{Side: -92}", "s2 := &pointerS2", "factor := 12"}, funcTestCases[5].Stmts)
+	s.Equal([]string{"pointerS2 := Square{Side: 5}", "s2 := &pointerS2", "factor := 71"}, funcTestCases[7].Stmts)
+	// The synthetic implementation is still used for the other test cases
+	s.Equal([]string{"s2 := &TestShape{}", "factor := 2"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestInterfaceImplementerSignatures() {
+	opts := &Options{
+		MaxRecursion:          3,
+		OrganismAmount:        1,
+		TestCasesPerFunc:      20,
+		InterfaceImplementers: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_implementer_signatures", opts)
+
+	stmts := func(funcName string) string {
+		res := []string{}
+		for _, testCase := range s.GetTestCase(organism.Files, funcName) {
+			res = append(res, testCase.Stmts...)
+		}
+		return strings.Join(res, "\n")
+	}
+	// Parameter types are compared including their package, not only predeclared types
+	flush := stmts("Flush")
+	s.Contains(flush, "Buffer{")
+	s.NotContains(flush, "Lines{")
+	s.NotContains(flush, "Detached{")
+	save := stmts("Save")
+	s.Contains(save, "Memory{")
+	s.NotContains(save, "Local{")
+}
+
+func (s *PrintStmtTestSuite) TestGenericInstanceAlias() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_generic_alias", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Size")
+	s.Require().Equal(1, len(funcTestCases))
+	// The alias is unwound to the instantiation, substituting int for the type parameter
+	s.Equal([]string{"s2 := Stack[int]{Items: []int{-80, -45, -73, -92, 70, -41, 89}}"}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Enabled")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerE := Pair[string, bool]{Key: \"Alejandra Kunde\", Value: true}",
+		"e := &pointerE",
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestOracle() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_oracle", opts)
+	printed := `<START;Sum0>
+{ "type": "int", "var_name": "out", "val": "3"}
+<END;Sum0>
+<START;MinMax0>
+{ "type": "int", "var_name": "out", "val": "1"}
+{ "type": "int", "var_name": "out2", "val": "2"}
+<END;MinMax0>
+`
+	organism.UpdateAssertStmts(printed, true)
+
+	// Captured values are replaced by a comparison against the oracle
+	funcTestCases := s.GetTestCase(organism.Files, "Sum")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("out := Sum(input)", funcTestCases[0].FuncPrintStmt)
+	// The oracle is called before the function under test, which could modify the input
+	s.Equal([]string{"expected := naiveSum(input)"}, funcTestCases[0].OracleCallStmts)
+	s.Equal([]string{"s.Equal(expected,out)"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+
+	funcTestCases = s.GetTestCase(organism.Files, "MinMax")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("out, out2 := MinMax(input)", funcTestCases[0].FuncPrintStmt)
+	s.Equal([]string{"expected, expected2 := naiveMinMax(input)"}, funcTestCases[0].OracleCallStmts)
+	s.Equal([]string{
+		"s.Equal(expected,out)",
+		"s.Equal(expected2,out2)",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestIdempotent() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_idempotent", opts)
+	printed := `<START;Normalize0>
+{ "type": "string", "var_name": "out", "val": "bart beatty"}
+<END;Normalize0>
+<START;Clamp0>
+{ "type": "int", "var_name": "out", "val": "0"}
+{ "type": "int", "var_name": "out2", "val": "0"}
+<END;Clamp0>
+`
+	organism.UpdateAssertStmts(printed, true)
+
+	// The captured values are asserted, followed by calling the function on its own results
+	funcTestCases := s.GetTestCase(organism.Files, "Normalize")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("out := Normalize(s2)", funcTestCases[0].FuncPrintStmt)
+	s.Equal([]string{
+		"s.EqualValues(string(`bart beatty`),out)",
+		"s.Equal(out,Normalize(out))",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+
+	funcTestCases = s.GetTestCase(organism.Files, "Clamp")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("out, out2 := Clamp(low, high)", funcTestCases[0].FuncPrintStmt)
+	s.Equal([]string{
+		"s.EqualValues(int(0),out)",
+		"s.EqualValues(int(0),out2)",
+		"again, again2 := Clamp(out, out2)",
+		"s.Equal(out,again)",
+		"s.Equal(out2,again2)",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+
+	funcTestCases = s.GetTestCase(organism.Files, "CounterNormalize")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"s.Equal(out,c.Normalize(out))"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+
+	funcTestCases = s.GetTestCase(organism.Files, "trim")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestEmptyMapField() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+	}
+	organism := s.generate("../../test/data/inputs/example_map_field", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Add")
+	s.Require().Equal(10, len(funcTestCases))
+	// The map field is never omitted, since writing to a nil map panics
+	for _, funcTestCase := range funcTestCases {
+		s.Contains(funcTestCase.Stmts[0], "Data: map[string][]int{")
+	}
+	// An empty generated map is emitted as a non-nil composite literal
+	s.Equal("index := Index{Data: map[string][]int{}}", funcTestCases[8].Stmts[0])
+}
+
+func (s *PrintStmtTestSuite) TestJSONRawMessage() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 3,
+	}
+	organism := s.generate("../../test/data/inputs/example_json_raw", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Decode")
+	s.Require().Equal(3, len(funcTestCases))
+	stmts := []string{}
+	for _, funcTestCase := range funcTestCases {
+		stmts = append(stmts, funcTestCase.Stmts...)
+	}
+	s.Equal([]string{
+		"msg := json.RawMessage(`{\"Bart Beatty\":{\"Cordia Jacobi\":70,\"Lawson Kreiger\":false,\"Stacy Dietrich\":41},\"Eunice Kunde\":false,\"Sunny Gerlach\":[]}`)",
+		"msg := json.RawMessage(`false`)",
+		"msg := json.RawMessage(`-12`)",
+	}, stmts)
+}
+
+func (s *PrintStmtTestSuite) TestJSONRawMessageFields() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_json_raw_field", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Encode")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerE := json.RawMessage(`false`)",
+		"e := Event{Kind: \"Bart Beatty\", Payload: json.RawMessage(`{\"Austin Hackett\":[],\"Cordia Jacobi\":{\"Aleen Legros\":90,\"Alejandra Kunde\":true,\"Nickolas Emard\":89},\"Merle Quigley\":true}`), Meta: &pointerE}",
+	}, funcTestCases[0].Stmts)
+
+	rawMessage := regexp.MustCompile("json\\.RawMessage\\(`([^`]*)`\\)")
+	for _, funcName := range []string{"Encode", "Count"} {
+		for _, funcTestCase := range s.GetTestCase(organism.Files, funcName) {
+			for _, stmt := range funcTestCase.Stmts {
+				for _, match := range rawMessage.FindAllStringSubmatch(stmt, -1) {
+					s.True(json.Valid([]byte(match[1])), "invalid JSON in %s: %s", funcName, match[1])
+				}
+			}
+		}
+	}
+}
+
+func (s *PrintStmtTestSuite) TestJSONNumber() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 3,
+	}
+	organism := s.generate("../../test/data/inputs/example_json_number", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Cents")
+	s.Require().Equal(3, len(funcTestCases))
+	stmts := []string{}
+	for _, funcTestCase := range funcTestCases {
+		stmts = append(stmts, funcTestCase.Stmts...)
+	}
+	s.Equal([]string{
+		"amount := json.Number(\"88.101818\")",
+		"amount := json.Number(\"-92\")",
+		"amount := json.Number(\"-41\")",
+	}, stmts)
+}
+
+func (s *PrintStmtTestSuite) TestCmpAssertions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		CmpAssertions:    true,
+	}
+	organism := s.generate("../../test/data/inputs/example_cmp", opts)
+
+	// The struct with slices is printed as a whole
+	funcTestCases := s.GetTestCase(organism.Files, "Merge")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"pkg\": \"%s\", \"val\": %#v}`, `cmp`, `out`, `example`, fmt.Sprintf(`%#v`, out))",
+		"fmt.Println(\"\")",
+	}, funcTestCases[0].ResultStmts)
+
+	// Nested pointers are printed as addresses, so the list is asserted field by field
+	funcTestCases = s.GetTestCase(organism.Files, "Push")
+	s.Require().Equal(1, len(funcTestCases))
+	s.NotContains(strings.Join(funcTestCases[0].ResultStmts, "\n"), "`cmp`")
+
+	printed := fmt.Sprintf(`<START;Merge0>
+{ "type": "cmp", "var_name": "out", "pkg": "example", "val": %q}
+<END;Merge0>
+`, `example.Order{ID:-80, Items:[]example.Item{example.Item{Name:"Gerson Beahan", Quantity:-92}}, Tags:[]string(nil)}`)
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+	funcTestCases = s.GetTestCase(organism.Files, "Merge")
+	s.Equal([]string{
+		"s.Empty(cmp.Diff(Order{ID:-80, Items:[]Item{Item{Name:\"Gerson Beahan\", Quantity:-92}}, Tags:[]string(nil)}, out, " +
+			"cmpopts.SortSlices(func(a, b interface{}) bool { return fmt.Sprint(a) < fmt.Sprint(b) }), " +
+			"cmpopts.EquateEmpty(), cmp.Exporter(func(reflect.Type) bool { return true })))",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	s.True(organism.Files[0].UsesCmp())
+}
+
+func (s *PrintStmtTestSuite) TestCmpLogAssertions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		CmpAssertions:    true,
+		LogAssertions:    true,
+	}
+	organism := s.generate("../../test/data/inputs/example_cmp", opts)
+
+	// The diff is logged instead of asserted
+	printed := fmt.Sprintf(`<START;Merge0>
+{ "type": "cmp", "var_name": "out", "pkg": "example", "val": %q}
+<END;Merge0>
+`, `example.Order{ID:-80, Items:[]example.Item(nil), Tags:[]string(nil)}`)
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+	funcTestCases := s.GetTestCase(organism.Files, "Merge")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"s.T().Logf(\"diff: expected empty, actual %v\", cmp.Diff(Order{ID:-80, Items:[]Item(nil), Tags:[]string(nil)}, out, " +
+			"cmpopts.SortSlices(func(a, b interface{}) bool { return fmt.Sprint(a) < fmt.Sprint(b) }), " +
+			"cmpopts.EquateEmpty(), cmp.Exporter(func(reflect.Type) bool { return true })))",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestAllocs() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Allocs:           true,
+	}
+	organism := s.generate("../../test/data/inputs/example_allocs", opts)
+
+	// The allocations are measured and printed, so the measured amount is asserted
+	funcTestCases := s.GetTestCase(organism.Files, "Join")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("allocs := testing.AllocsPerRun(100, func() {\n\tJoin(words)\n})", funcTestCases[0].AllocsStmt)
+	s.Equal([]string{
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%v\"}`, `allocs`, `allocs`, allocs)",
+		"fmt.Println(\"\")",
+	}, funcTestCases[0].AllocsPrintStmts)
+	printed := `<START;Join0>
+{ "type": "string", "var_name": "out", "val": "Bart Beatty Cordia Jacobi"}
+{ "type": "allocs", "var_name": "allocs", "val": "1"}
+<END;Join0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{
+		"s.EqualValues(string(`Bart Beatty Cordia Jacobi`),out)",
+		"s.LessOrEqual(allocs,float64(1))",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	s.True(funcTestCases[0].HasAllocsCheck())
+	s.Equal([]string{"s.EqualValues(string(`Bart Beatty Cordia Jacobi`),out)"}, funcTestCases[0].ResultAssertStmts())
+	s.Equal([]string{"s.LessOrEqual(allocs,float64(1))"}, funcTestCases[0].AllocsAssertStmts())
+
+	// The maximum of the directive is asserted instead of capturing the allocations
+	funcTestCases = s.GetTestCase(organism.Files, "Count")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("allocs := testing.AllocsPerRun(100, func() {\n\tCount(words)\n})", funcTestCases[0].AllocsStmt)
+	s.Equal([]string{"_ = allocs"}, funcTestCases[0].AllocsPrintStmts)
+	s.Equal([]string{"s.LessOrEqual(allocs,float64(0))"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	// The allocations are asserted after they are measured, which is after the results are asserted
+	s.Empty(funcTestCases[0].ResultAssertStmts())
+	s.Equal([]string{"s.LessOrEqual(allocs,float64(0))"}, funcTestCases[0].AllocsAssertStmts())
+
+	// Functions receiving channels aren't measured
+	funcTestCases = s.GetTestCase(organism.Files, "Notify")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Empty(funcTestCases[0].AllocsIdent)
+	s.False(funcTestCases[0].HasAllocsCheck())
+}
+
+func (s *PrintStmtTestSuite) TestPureDirective() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_pure", opts)
+
+	// The parameters of pure functions are printed before the call
+	funcTestCases := s.GetTestCase(organism.Files, "Median")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"values := []int{-47, 28, 31, 41, -61, 90}"}, funcTestCases[0].Stmts)
+	s.Require().Equal(1, len(funcTestCases[0].PureStmts))
+	s.Contains(funcTestCases[0].PureStmts[0], "`values[cMRAj]`, values[cMRAj]")
+
+	funcTestCases = s.GetTestCase(organism.Files, "Double")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Empty(funcTestCases[0].PureStmts)
+
+	// The values captured before the call are asserted after the call, so sorting the values in place fails the test
+	printed := `<START;Median0>
+{ "type": "arr", "arr_ident": "cMRAj", "var_name": "values", "val": "0", "child": { "type": "int", "var_name": "values[cMRAj]", "val": "-47"}}
+{ "type": "arr", "arr_ident": "cMRAj", "var_name": "values", "val": "1", "child": { "type": "int", "var_name": "values[cMRAj]", "val": "28"}}
+{ "type": "int", "var_name": "out", "val": "28"}
+<END;Median0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	funcTestCases = s.GetTestCase(organism.Files, "Median")
+	s.Equal([]string{
+		"s.EqualValues(int(-47),values[0])",
+		"s.EqualValues(int(28),values[1])",
+		"s.EqualValues(int(28),out)",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestStringerAssertions() {
+	opts := &Options{
+		MaxRecursion:       3,
+		OrganismAmount:     1,
+		TestCasesPerFunc:   1,
+		StringerAssertions: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_stringer", opts)
+
+	// The result of String is printed instead of the underlying value
+	funcTestCases := s.GetTestCase(organism.Files, "Mix")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": %#v}`, `stringer`, `out`, out.String())",
+		"fmt.Println(\"\")",
+	}, funcTestCases[0].ResultStmts)
+
+	// Pointer receivers are called on the dereferenced variable
+	funcTestCases = s.GetTestCase(organism.Files, "Move")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Contains(strings.Join(funcTestCases[0].ResultStmts, "\n"), "`stringer`, `pointerOut`, pointerOut.String())")
+
+	// Types which aren't a stringer are asserted field by field
+	funcTestCases = s.GetTestCase(organism.Files, "Grow")
+	s.Require().Equal(1, len(funcTestCases))
+	s.NotContains(strings.Join(funcTestCases[0].ResultStmts, "\n"), "`stringer`")
+
+	printed := `<START;Mix0>
+{ "type": "stringer", "var_name": "out", "val": "green"}
+<END;Mix0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	funcTestCases = s.GetTestCase(organism.Files, "Mix")
+	s.Equal([]string{"s.Equal(\"green\",out.String())"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestCtxValues() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_ctx_value", opts)
+
+	// Values specified by ctx-value directives are injected in the order of the directives
+	funcTestCases := s.GetTestCase(organism.Files, "Tenant")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		`ctx := context.WithValue(context.WithValue(context.Background(), TenantKey, "acme"), ctxKey("region"), "eu")`,
+	}, funcTestCases[0].Stmts)
+
+	// Without directives a background context is used
+	funcTestCases = s.GetTestCase(organism.Files, "Deadline")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"ctx := context.Background()"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestRegexps() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_regexp", opts)
+
+	// Regexps are compiled from a valid pattern instead of filling their fields
+	funcTestCases := s.GetTestCase(organism.Files, "Match")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		`re := regexp.MustCompile("Cordia Jacobix?Lawson Kreigerx?\\w+")`,
+		`value := "Marc Murphy"`,
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Validate")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		`r := Rule{Name: "Eunice Kunde", Pattern: regexp.MustCompile("Ariane Rice(foo|bar)Briana Bauch[A-Z0-9]*$")}`,
+		`value := "Jarod Wolff"`,
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestNamedTypeKinds() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_named_kinds", opts)
+
+	// Bidirectional channels are made of the defined type directly
+	funcTestCases := s.GetTestCase(organism.Files, "Wait")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"signal := make(Signal)", "s2 := signal"}, funcTestCases[0].Stmts)
+
+	// Directional channels are filled using a bidirectional channel, which is converted afterwards
+	funcTestCases = s.GetTestCase(organism.Files, "Sum")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("r := Results(results)", funcTestCases[0].Stmts[len(funcTestCases[0].Stmts)-1])
+
+	funcTestCases = s.GetTestCase(organism.Files, "Handle")
+	s.Require().Equal(1, len(funcTestCases))
+	s.True(strings.HasPrefix(funcTestCases[0].Stmts[0], "r := Router{\"Alejandra Kunde\": Handler(func(path string) int {\n\to := 31\n\treturn o\n}), "))
+
+	funcTestCases = s.GetTestCase(organism.Files, "Call")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"h := Handler(func(path string) int {\n\to := 65\n\treturn o\n})"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestTempFiles() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		TempFiles:        true,
+	}
+	organism := s.generate("../../test/data/inputs/example_temp_file", opts)
+
+	// The temp file is filled with generated content and rewound, so the function reads the content
+	funcTestCases := s.GetTestCase(organism.Files, "CountLines")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"file, err := os.CreateTemp(\"\", \"f-*\")",
+		"s.Require().NoError(err)",
+		"s.T().Cleanup(func() {\n\tfile.Close()\n\tos.Remove(file.Name())\n})",
+		"_, err = file.WriteString(\"Bart Beatty\")",
+		"s.Require().NoError(err)",
+		"_, err = file.Seek(0, io.SeekStart)",
+		"s.Require().NoError(err)",
+		"f := file",
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestTemplates() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_template", opts)
+
+	// Templates are parsed from a valid template text instead of filling their fields
+	funcTestCases := s.GetTestCase(organism.Files, "Render")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"t := template.Must(template.New(\"t\").Parse(\"{{if .}}{{.}}{{else}}empty{{end}}Cordia Jacobi {{if .}}{{.}}{{else}}empty{{end}}Lawson Kreiger {{/* comment */}}\"))",
+		"data := \"Alejandra Kunde\"",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "RenderPage")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"p := Page{Title: \"Marc Murphy\", Layout: htmltemplate.Must(htmltemplate.New(\"Layout\").Parse(\"Adelia Metz {{with .}}{{.}}{{end}}Ariane Rice {{.}}\"))}",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Name")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"t := *template.Must(template.New(\"t\").Parse(\"Briana Bauch {{printf \\\"%v\\\" .}}Jarod Wolff {{.}}Talia Hudson {{/* comment */}}\"))",
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestTestMain() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		FilePerFunc:      true,
+		TestMain:         true,
+	}
+	organism := s.generate("../../test/data/inputs/example_test_main", opts)
+
+	// The fixture functions aren't tested themselves
+	s.Require().Equal(2, len(organism.OutputFiles()))
+	for _, f := range organism.Files {
+		s.NotContains(f.TestCases, "openStore")
+		s.NotContains(f.TestCases, "closeStore")
+	}
+
+	// A single TestMain is created for all test files of the package
+	s.Equal(`func TestMain(m *testing.M) {
+	if err := openStore(); err != nil {
+		fmt.Println("setup failed:", err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	closeStore()
+	os.Exit(code)
+}`, organism.TestMain())
+
+	opts.TestMain = false
+	organism = s.generate("../../test/data/inputs/example_test_main", opts)
+	s.Equal("", organism.TestMain())
+}
+
+func (s *PrintStmtTestSuite) TestExportShims() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		ExportShims:      true,
+	}
+	organism := s.generate("../../test/data/inputs/example_export_shim", opts)
+
+	// Unexported functions are called through their exported wrapper
+	funcTestCases := s.GetTestCase(organism.Files, "sum")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("ExportSum(a, b)", funcTestCases[0].FuncStmt)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Double")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("Double(a, b)", funcTestCases[0].FuncStmt)
+
+	// Generic functions can't be wrapped without instantiating them
+	funcTestCases = s.GetTestCase(organism.Files, "first")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("first[int](values)", funcTestCases[0].FuncStmt)
+
+	// Methods are called on their receiver
+	s.Equal([]string{"var ExportSum = sum"}, organism.ExportShims())
+}
+
+func (s *PrintStmtTestSuite) TestMutualRecursion() {
+	opts := &Options{
+		MaxRecursion:     2,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_mutual_recursion", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "ChainLength")
+	s.Require().Equal(1, len(funcTestCases))
+	// The alternating chain of employees and managers terminates with nil pointers
+	s.Equal([]string{
+		"pointerManager := Manager{Self: nil, Reports: []*Employee{nil, nil, nil, nil, nil, nil, nil}}",
+		"pointerEmployee := Employee{Name: \"Cordia Jacobi\", Manager: &pointerManager}",
+		"pointerE := Manager{Self: &pointerEmployee, Reports: []*Employee{nil, nil, nil, nil, nil, nil, nil, nil, nil}}",
+		"e := Employee{Name: \"Bart Beatty\", Manager: &pointerE}",
+	}, funcTestCases[0].Stmts)
+
+	// The generated statements compile against the input package
+	src, err := ioutil.ReadFile("../../test/data/inputs/example_mutual_recursion/mutual_recursion.go")
+	s.Require().NoError(err)
+	src = append(src, []byte("\nfunc generated() {\n"+strings.Join(funcTestCases[0].Stmts, "\n")+"\n"+funcTestCases[0].FuncStmt+"\n}\n")...)
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, "mutual_recursion.go", src, 0)
+	s.Require().NoError(err)
+	_, err = (&types.Config{}).Check("mutualrecursion", fset, []*ast.File{astFile}, nil)
+	s.NoError(err)
+}
+
+func (s *PrintStmtTestSuite) TestFuncSlice() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_func_slice", opts)
+
+	// Every element of the slice literal is a distinct closure
+	funcTestCases := s.GetTestCase(organism.Files, "Pipe")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"fns := []func(int) int{func(int) int {\n\to := -80\n\treturn o\n}, func(int) int {\n\to2 := -45\n\treturn o2\n}, func(int) int {\n\to3 := -73\n\treturn o3\n}, func(int) int {\n\to4 := -92\n\treturn o4\n}, func(int) int {\n\to5 := 70\n\treturn o5\n}, func(int) int {\n\to6 := -41\n\treturn o6\n}, func(int) int {\n\to7 := 89\n\treturn o7\n}}",
+	}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestBigNumbers() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_big", opts)
+
+	// Pointers are created by the constructors directly
+	funcTestCases := s.GetTestCase(organism.Files, "Double")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"x := big.NewInt(int64(-80))"}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Half")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"x := *new(big.Float).SetFloat64(88.101818)"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestSourcePositions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		SourcePositions:  true,
+	}
+	organism := s.generate("../../test/data/inputs/example_multi_file", opts)
+
+	positions := map[string]string{}
+	for _, f := range organism.Files {
+		for funcName, testCases := range f.TestCases {
+			s.Require().Equal(1, len(testCases))
+			positions[funcName] = testCases[0].SourcePosition()
+		}
+	}
+	s.Equal(map[string]string{
+		"MultFileStruct":     "multi_file.go:3",
+		"MultFileInterface":  "multi_file.go:6",
+		"MultFileCustomType": "multi_file.go:9",
+	}, positions)
+
+	// Positions are omitted if the option is disabled
+	opts.SourcePositions = false
+	funcTestCases := s.GetTestCase(s.generate("../../test/data/inputs/example_multi_file", opts).Files, "MultFileStruct")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("", funcTestCases[0].SourcePosition())
+}
+
+func (s *PrintStmtTestSuite) TestBuildTags() {
+	if runtime.GOOS != "linux" {
+		s.T().Skip("fixture declares the linux definition of the struct")
+	}
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	seed.SetRandomSeed(1)
+	generator, err := New("../../test/data/inputs/example_build_tags", opts)
+	s.Require().NoError(err)
+	// Files excluded by the build constraints of the current build context aren't parsed
+	s.Equal(2, len(generator.PackageInfo.GetRootPkg()))
+	organisms := generator.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+
+	funcTestCases := s.GetTestCase(organisms[0].Files, "Configure")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"p := Poller{Events: -80, Epoll: true}"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestValueBudget() {
+	// countElements counts the elements of the composite literals in given statements
+	countElements := func(stmts []string) int {
+		astFile, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+strings.Join(stmts, "\n")+"\n}", 0)
+		s.Require().NoError(err)
+		count := 0
+		ast.Inspect(astFile, func(n ast.Node) bool {
+			if compositeLit, ok := n.(*ast.CompositeLit); ok {
+				count += len(compositeLit.Elts)
+			}
+			return true
+		})
+		return count
+	}
+	maxElements := func(budget int) int {
+		opts := &Options{
+			MaxRecursion:     3,
+			OrganismAmount:   1,
+			TestCasesPerFunc: 20,
+			ValueBudget:      budget,
+		}
+		funcTestCases := s.GetTestCase(s.generate("../../test/data/inputs/example_value_budget", opts).Files, "Nested")
+		s.Require().Equal(20, len(funcTestCases))
+		res := 0
+		for _, funcTestCase := range funcTestCases {
+			if count := countElements(funcTestCase.Stmts); count > res {
+				res = count
+			}
+		}
+		return res
+	}
+	const budget = 30
+	s.Greater(maxElements(0), budget)
+	s.LessOrEqual(maxElements(budget), budget)
+}
+
+func (s *PrintStmtTestSuite) TestRangeDirective() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 20,
+	}
+	organism := s.generate("../../test/data/inputs/example_range", opts)
+
+	// Only the parameter with a range directive is bounded
+	funcTestCases := s.GetTestCase(organism.Files, "Percentage")
+	s.Require().Equal(20, len(funcTestCases))
+	outOfRange := 0
+	for _, testCase := range funcTestCases {
+		s.Require().Equal(2, len(testCase.Stmts))
+		total, err := strconv.Atoi(strings.TrimPrefix(testCase.Stmts[0], "total := "))
+		s.Require().NoError(err)
+		if total < 0 || total > 100 {
+			outOfRange++
+		}
+		percent, err := strconv.Atoi(strings.TrimPrefix(testCase.Stmts[1], "percent := "))
+		s.Require().NoError(err)
+		s.True(percent >= 0 && percent <= 100, testCase.Stmts[1])
+	}
+	s.Greater(outOfRange, 0)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Scale")
+	s.Require().Equal(20, len(funcTestCases))
+	s.Equal([]string{"value := -65.346752", "factor := 0.5410998550087353"}, funcTestCases[0].Stmts)
+
+	// The range is intersected with the limits of the parameter type
+	funcTestCases = s.GetTestCase(organism.Files, "Level")
+	s.Require().Equal(20, len(funcTestCases))
+	for _, testCase := range funcTestCases {
+		s.Require().Equal(1, len(testCase.Stmts))
+		s.Require().True(strings.HasPrefix(testCase.Stmts[0], "level := int8("), testCase.Stmts[0])
+		level, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(testCase.Stmts[0], "level := int8("), ")"))
+		s.Require().NoError(err)
+		s.True(level >= 0 && level <= 127, testCase.Stmts[0])
+	}
+}
+
+func (s *PrintStmtTestSuite) TestResetDirective() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 2,
+	}
+	organism := s.generate("../../test/data/inputs/example_reset", opts)
+
+	// Package state is reset at the start of every test case
+	funcTestCases := s.GetTestCase(organism.Files, "Next")
+	s.Require().Equal(2, len(funcTestCases))
+	s.Equal([]string{"resetCounter()", "step := -80"}, funcTestCases[0].Stmts)
+	s.Equal([]string{"resetCounter()", "step := -45"}, funcTestCases[1].Stmts)
+
+	// Functions without reset directive are unaffected
+	funcTestCases = s.GetTestCase(organism.Files, "resetCounter")
+	s.Require().Equal(2, len(funcTestCases))
+	s.Equal(0, len(funcTestCases[0].Stmts))
+}
+
+func (s *PrintStmtTestSuite) TestSealedInterface() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_sealed", opts)
+
+	// An implementer of the defining package is used instead of a synthetic implementation
+	funcTestCases := s.GetTestCase(organism.Files, "Area")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"pointerS2 := shape.Square{Side: 20.932058}", "s2 := &pointerS2"}, funcTestCases[0].Stmts)
+	s.Equal(0, len(funcTestCases[0].Decls))
+
+	// Without exported implementers nil is used
+	funcTestCases = s.GetTestCase(organism.Files, "Value")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"t := func() shape.Token {\n\treturn nil\n}()"}, funcTestCases[0].Stmts)
+	s.Equal(0, len(funcTestCases[0].Decls))
+
+	// Implementers of sealed interfaces of dependencies are used as well
+	funcTestCases = s.GetTestCase(s.generate("../../test/data/inputs/example_priv_ret_interface", opts).Files, "AstSelection")
+	s.Require().Equal(1, len(funcTestCases))
+	stmts := funcTestCases[0].Stmts
+	s.Require().Greater(len(stmts), 1)
+	s.True(strings.HasPrefix(stmts[len(stmts)-2], "pointerX := ast.FragmentSpread{"))
+	s.Equal("x := &pointerX", stmts[len(stmts)-1])
+	s.Equal(0, len(funcTestCases[0].Decls))
+}
+
+func (s *PrintStmtTestSuite) TestAliasing() {
+	tests := []struct {
+		Name    string
+		Bias    float64
+		Aliased bool
+	}{
+		{
+			Name:    "always alias",
+			Bias:    1,
+			Aliased: true,
+		},
+		{
+			Name:    "never alias",
+			Bias:    0,
+			Aliased: false,
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:     3,
+				OrganismAmount:   1,
+				TestCasesPerFunc: 5,
+				AliasBias:        test.Bias,
+			}
+			organism := s.generate("../../test/data/inputs/example_alias", opts)
+
+			funcTestCases := s.GetTestCase(organism.Files, "Merge")
+			s.Require().Equal(5, len(funcTestCases))
+			for _, funcTestCase := range funcTestCases {
+				if test.Aliased {
+					s.Contains(funcTestCase.Stmts, "src := dst")
+					s.Equal(map[string]string{"src": "dst"}, funcTestCase.Aliases)
+				} else {
+					s.NotContains(funcTestCase.Stmts, "src := dst")
+					s.Empty(funcTestCase.Aliases)
+				}
+			}
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestCleanup() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+	}
+	organism := s.generate("../../test/data/inputs/example_cleanup", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Size")
+	s.Require().Equal(10, len(funcTestCases))
+	decorated := 0
+	for _, funcTestCase := range funcTestCases {
+		if funcTestCase.Stmts[0] != "f, cleanup := tempFile()" {
+			continue
+		}
+		decorated++
+		s.Equal("s.T().Cleanup(cleanup)", funcTestCase.Stmts[1])
+	}
+	s.Greater(decorated, 0)
+}
+
+func (s *PrintStmtTestSuite) TestCorpus() {
+	generate := func() []string {
+		opts := &Options{
+			MaxRecursion:     3,
+			OrganismAmount:   1,
+			TestCasesPerFunc: 10,
+			Corpus:           "../../test/data/inputs/example_corpus/corpus.txt",
+		}
+		organism := s.generate("../../test/data/inputs/example_corpus", opts)
+
+		funcTestCases := s.GetTestCase(organism.Files, "Register")
+		s.Require().Equal(10, len(funcTestCases))
+		stmts := []string{}
+		for _, funcTestCase := range funcTestCases {
+			stmts = append(stmts, funcTestCase.Stmts...)
+		}
+		return stmts
+	}
+	stmts := generate()
+	s.Contains(stmts, `email := "alice@example.com"`)
+	s.Contains(stmts, `name := "Alice Smith"`)
+	s.Contains(stmts, `age := 42`)
+	// Corpus values are selected deterministically for the same seed
+	s.Equal(stmts, generate())
+}
+
+func (s *PrintStmtTestSuite) TestCorpusNotFound() {
+	_, err := New("../../test/data/inputs/example_corpus", &Options{Corpus: "does/not/exist.txt"})
+	s.Error(err)
+}
+
+func (s *PrintStmtTestSuite) TestGlobalNamesUnique() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   2,
+		TestCasesPerFunc: 3,
+		Helpers:          true,
+	}
+	seed.SetRandomSeed(1)
+	generator, err := New("../../test/data/inputs/example_global_names", opts)
+	s.Require().NoError(err)
+	organisms := generator.GetTestCases()
+	s.Require().Equal(2, len(organisms))
+
+	// Files share the package scope and crossover combines test cases of different organisms,
+	// so global declarations must be unique across all of them
+	names := make(map[string]int)
+	for _, organism := range organisms {
+		organism.HoistHelpers()
+		for _, f := range organism.Files {
+			decls := append([]string{}, f.Helpers...)
+			for _, testCases := range f.TestCases {
+				for _, testCase := range testCases {
+					decls = append(decls, testCase.Decls...)
+				}
+			}
+			for _, decl := range decls {
+				for _, name := range s.declaredNames(decl) {
+					names[name]++
+				}
+			}
+		}
+	}
+	s.Require().NotEmpty(names)
+	for name, amount := range names {
+		s.Equal(1, amount, "global %s declared multiple times", name)
+	}
+}
+
+func (s *PrintStmtTestSuite) TestSyntheticTypeNamesStable() {
+	syntheticNames := func() []string {
+		opts := &Options{
+			MaxRecursion:     3,
+			OrganismAmount:   2,
+			TestCasesPerFunc: 3,
+		}
+		seed.SetRandomSeed(1)
+		generator, err := New("../../test/data/inputs/example_global_names", opts)
+		s.Require().NoError(err)
+		res := []string{}
+		for _, organism := range generator.GetTestCases() {
+			for _, decl := range organism.SharedDecls() {
+				res = append(res, s.declaredNames(decl)...)
+			}
+		}
+		return res
+	}
+	// Synthetic implementations are named after the interface and numbered, independent of random values
+	names := syntheticNames()
+	s.Equal([]string{
+		"TestReader", "TestReader2", "TestReader3", "TestReader4", "TestReader5", "TestReader6",
+		"TestReader7", "TestReader8", "TestReader9", "TestReader10", "TestReader11", "TestReader12",
+	}, names)
+	s.Equal(names, syntheticNames())
+}
+
+// declaredNames retrieves the names of the types and functions declared by given declaration
+func (s *PrintStmtTestSuite) declaredNames(decl string) []string {
+	astFile, err := parser.ParseFile(token.NewFileSet(), "", "package p\n"+decl, 0)
+	s.Require().NoError(err)
+	res := []string{}
+	for _, d := range astFile.Decls {
+		switch t := d.(type) {
+		case *ast.FuncDecl:
+			if t.Recv == nil {
+				res = append(res, t.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range t.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					res = append(res, typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+	return res
+}
+
+func (s *PrintStmtTestSuite) TestSyncTypes() {
+	tests := []struct {
+		Name           string
+		FuncName       string
+		SyncMapEntries bool
+		Expected       []string
+	}{
+		{
+			Name:     "empty sync map",
+			FuncName: "Count",
+			Expected: []string{"cache := &sync.Map{}"},
+		},
+		{
+			Name:           "populated sync map",
+			FuncName:       "Count",
+			SyncMapEntries: true,
+			Expected:       []string{"syncCache := &sync.Map{}", "syncCache.Store(\"Bart Beatty\", -73)", "syncCache.Store(\"Lina Carroll\", -41)", "syncCache.Store(\"Hollis Dickens\", 28)", "syncCache.Store(\"Marc Murphy\", -61)", "cache := syncCache"},
+		},
+		{
+			Name:     "zero values",
+			FuncName: "Run",
+			Expected: []string{"once := &sync.Once{}", "wg := &sync.WaitGroup{}", "mu := &sync.Mutex{}", "f := func() {\n\treturn\n}"},
+		},
+		{
+			Name:     "mutex held by value",
+			FuncName: "Inc",
+			Expected: []string{"pointerG := Guarded{mu: sync.Mutex{}, N: -80}", "g := &pointerG"},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:     3,
+				OrganismAmount:   1,
+				TestCasesPerFunc: 1,
+				SyncMapEntries:   test.SyncMapEntries,
+			}
+			organism := s.generate("../../test/data/inputs/example_sync", opts)
+
+			funcTestCases := s.GetTestCase(organism.Files, test.FuncName)
+			s.Require().Equal(1, len(funcTestCases))
+			s.Equal(test.Expected, funcTestCases[0].Stmts)
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestSideEffectAssertions() {
+	tests := []struct {
+		Name                 string
+		FuncName             string
+		SkipResultAssertions bool
+		SideEffectAssertions bool
+		ResultStmts          []string
+		ResultUsageStmts     []string
+	}{
+		{
+			Name:             "no side effects asserted by default",
+			FuncName:         "Increment",
+			ResultStmts:      []string{},
+			ResultUsageStmts: []string{},
+		},
+		{
+			Name:                 "mutated pointer argument",
+			FuncName:             "Increment",
+			SideEffectAssertions: true,
+			ResultStmts:          []string{"if c == nil {\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"nil\" } `, `pointer`, `c`)\n\tfmt.Println(\"\")\n} else {\n\tpointerOut := *c\n\t_ = pointerOut\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `pointer`, `c`)\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"child\": `, `struct`, `pointerOut`)\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `pointerOut.Hits`, pointerOut.Hits)\n\tfmt.Printf(`}`)\n\tfmt.Printf(`}`)\n\tfmt.Println(\"\")\n}"},
+			ResultUsageStmts:     []string{},
+		},
+		{
+			Name:                 "skip result assertions",
+			FuncName:             "Add",
+			SkipResultAssertions: true,
+			ResultStmts:          []string{},
+			ResultUsageStmts:     []string{"_ = out"},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:         3,
+				OrganismAmount:       1,
+				TestCasesPerFunc:     1,
+				SkipResultAssertions: test.SkipResultAssertions,
+				SideEffectAssertions: test.SideEffectAssertions,
+			}
+			organism := s.generate("../../test/data/inputs/example_side_effects", opts)
+
+			funcTestCases := s.GetTestCase(organism.Files, test.FuncName)
+			s.Require().Equal(1, len(funcTestCases))
+			s.Equal(test.ResultStmts, funcTestCases[0].ResultStmts)
+			s.Equal(test.ResultUsageStmts, funcTestCases[0].ResultUsageStmts)
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestTypedNil() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+		TypedNilBias:     0.5,
+	}
+	organism := s.generate("../../test/data/inputs/example_typed_nil", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Describe")
+	s.Require().Equal(10, len(funcTestCases))
+	typedNils := 0
+	for _, funcTestCase := range funcTestCases {
+		if len(funcTestCase.TypedNils) == 0 {
+			s.NotContains(funcTestCase.Stmts[0], "(nil)")
+			continue
+		}
+		typedNils++
+		s.Equal([]string{funcTestCase.TypedNils[0]}, funcTestCase.TypedNils)
+		s.Equal(fmt.Sprintf("l := (*%s)(nil)", funcTestCase.TypedNils[0]), funcTestCase.Stmts[0])
+	}
+	s.Greater(typedNils, 0)
+	s.Less(typedNils, 10)
+}
+
+func (s *PrintStmtTestSuite) TestReturnedFuncCalls() {
+	tests := []struct {
+		Name        string
+		FuncName    string
+		Calls       int
+		Stmts       []string
+		ResultStmts []string
+	}{
+		{
+			Name:        "returned funcs not invoked by default",
+			FuncName:    "Counter",
+			Stmts:       []string{"start := -80"},
+			ResultStmts: []string{},
+		},
+		{
+			Name:        "counter closure",
+			FuncName:    "Counter",
+			Calls:       2,
+			Stmts:       []string{"start := -80"},
+			ResultStmts: []string{"func() {\n\tdefer func() {\n\t\t_ = recover()\n\t}()\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out()`, out())\n\tfmt.Println(\"\")\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out()`, out())\n\tfmt.Println(\"\")\n}()"},
+		},
+		{
+			Name:        "closure with arguments",
+			FuncName:    "Scale",
+			Calls:       1,
+			Stmts:       []string{"factor := -45", "outArg := -73", "_ = outArg"},
+			ResultStmts: []string{"func() {\n\tdefer func() {\n\t\t_ = recover()\n\t}()\n\tfmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"val\": \"%#v\"}`, `int`, `out(outArg)`, out(outArg))\n\tfmt.Println(\"\")\n}()"},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:      3,
+				OrganismAmount:    1,
+				TestCasesPerFunc:  1,
+				ReturnedFuncCalls: test.Calls,
+			}
+			organism := s.generate("../../test/data/inputs/example_returned_funcs", opts)
+
+			funcTestCases := s.GetTestCase(organism.Files, test.FuncName)
+			s.Require().Equal(1, len(funcTestCases))
+			s.Equal(test.Stmts, funcTestCases[0].Stmts)
+			s.Equal(test.ResultStmts, funcTestCases[0].ResultStmts)
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestGomock() {
+	opts := &Options{
+		MaxRecursion:     2,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Gomock:           true,
+	}
+	// The mock requires gomock, which isn't a dependency of the module, so the input is a separate module
+	organism := s.generate("../../test/data/inputs/example_gomock", opts)
+
+	// Instead of a synthetic implementation, the pre-generated mock expects calls to every method
+	funcTestCases := s.GetTestCase(organism.Files, "Lookup")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"ctrl := gomock.NewController(s.T())",
+		"mockStore := NewMockStore(ctrl)",
+		"mockStore.EXPECT().Get(gomock.Any()).Return(\"Bart Beatty\", func() error {\n\treturn fmt.Errorf(\"very error\")\n}()).AnyTimes()",
+		"mockStore.EXPECT().Delete(gomock.Any()).AnyTimes()",
+		"st := mockStore",
+		"key := \"Lina Carroll\"",
+	}, funcTestCases[0].Stmts)
+	s.Equal([]string{}, funcTestCases[0].Decls)
+
+	// Methods of embedded interfaces, of the same and of other packages, are expected as well
+	funcTestCases = s.GetTestCase(organism.Files, "Evict")
+	s.Require().Equal(1, len(funcTestCases))
+	expected := []string{}
+	for _, stmt := range funcTestCases[0].Stmts {
+		if strings.HasPrefix(stmt, "mockCache.EXPECT().") {
+			expected = append(expected, strings.SplitN(strings.TrimPrefix(stmt, "mockCache.EXPECT()."), "(", 2)[0])
+		}
+	}
+	s.Equal([]string{"Get", "Delete", "Close", "Flush"}, expected)
+}
+
+func (s *PrintStmtTestSuite) TestVariadicNamedTypes() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_variadic_named", opts)
+
+	// The elements carry the named type and are spread in the call
+	funcTestCases := s.GetTestCase(organism.Files, "Join")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"sep := \"Bart Beatty\"",
+		"items := []Item{Item(\"Cordia Jacobi\"), Item(\"Nickolas Emard\"), Item(\"Hollis Dickens\"), Item(\"Stacy Dietrich\"), Item(\"Aleen Legros\"), Item(\"Adelia Metz\"), Item(\"Sunny Gerlach\")}",
+	}, funcTestCases[0].Stmts)
+	s.Equal("Join(sep, items...)", funcTestCases[0].FuncStmt)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Highest")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"pointerTasks := Task{Name: \"Austin Hackett\", Priority: Priority(25)}",
+		"pointerTasks2 := Task{Name: \"Charlie Lebsack\", Priority: Priority(91)}",
+		"pointerTasks3 := Task{Name: \"Sheldon Kassulke\", Priority: Priority(9)}",
+		"tasks := []*Task{&pointerTasks, &pointerTasks2, &pointerTasks3, nil, nil, nil, nil, nil, nil}",
+	}, funcTestCases[0].Stmts)
+	s.Equal("Highest(tasks...)", funcTestCases[0].FuncStmt)
+
+	funcTestCases = s.GetTestCase(organism.Files, "Total")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"durations := []time.Duration{time.Duration(int64(2)), time.Duration(int64(38)), time.Duration(int64(90)), time.Duration(int64(81)), time.Duration(int64(13)), time.Duration(int64(65)), time.Duration(int64(73)), time.Duration(int64(-82))}"}, funcTestCases[0].Stmts)
+	s.Equal("Total(durations...)", funcTestCases[0].FuncStmt)
+}
+
+func (s *PrintStmtTestSuite) TestPanicReports() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		PanicReports:     true,
+	}
+	organism := s.generate("../../test/data/inputs/example_panic", opts)
+	stack := `goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:24 +0x5e
+example/panics.(*PositiveSuite).TestMustPositive0.func1()
+	/tmp/panics/positive_test.go:15 +0xa5
+panic({0x5f1a40, 0xc000012345})
+	/usr/local/go/src/runtime/panic.go:770 +0x132
+example/panics.MustPositive(0xffffffffffffffb9)
+	/tmp/panics/positive.go:8 +0xc5
+example/panics.(*PositiveSuite).TestMustPositive0(0xc0000b6000)
+	/tmp/panics/positive_test.go:20 +0x45
+`
+	printed := fmt.Sprintf(`<START;MustPositive0>
+Recovered in TestMustPositive0 negative value: -71
+Panic stack of TestMustPositive0 %q
+<END;MustPositive0>
+`, stack)
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+
+	// The panicking case is documented by a skipped failing test
+	funcTestCases := s.GetTestCase(organism.Files, "MustPositive")
+	s.Require().Equal(1, len(funcTestCases))
+	s.True(funcTestCases[0].ReportsPanic())
+	s.Equal([]string{
+		"FIXME: panics: negative value: -71",
+		"example/panics.MustPositive(0xffffffffffffffb9)",
+		"/tmp/panics/positive.go:8 +0xc5",
+		"example/panics.(*PositiveSuite).TestMustPositive0(0xc0000b6000)",
+		"/tmp/panics/positive_test.go:20 +0x45",
+	}, funcTestCases[0].PanicReport())
+	s.Equal(`s.T().Skip("FIXME: panics: negative value: -71")`, funcTestCases[0].PanicSkipStmt())
+
+	// Without the option the panic is asserted
+	funcTestCases[0].Opts.PanicReports = false
+	s.False(funcTestCases[0].ReportsPanic())
+}
+
+func (s *PrintStmtTestSuite) TestInterfaceEmbeddedImport() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_interface_embed_import", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Describe")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"src := &TestSource{}"}, funcTestCases[0].Stmts)
+	s.Equal([]string{
+		"type TestSource struct {\n}",
+		"func (s *TestSource) Read(p []byte) (n int, err error) {\n\to := -80\n\to2 := func() error {\n\t\treturn fmt.Errorf(\"very error\")\n\t}()\n\treturn o, o2\n}",
+		"func (s *TestSource) Name() string {\n\to3 := \"Cordia Jacobi\"\n\treturn o3\n}",
+	}, funcTestCases[0].Decls)
+
+	// Types in the signatures of embedded methods are qualified by the package declaring them
+	funcTestCases = s.GetTestCase(organism.Files, "Latest")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"snapshot := &TestSnapshot{}"}, funcTestCases[0].Stmts)
+	s.Equal([]string{
+		"type TestSnapshot struct {\n}",
+		"func (s *TestSnapshot) WriteTo(w io.Writer) (n int64, err error) {\n\to := int64(70)\n\to2 := func() error {\n\t\treturn nil\n\t}()\n\treturn o, o2\n}",
+		"func (s *TestSnapshot) Format(f fmt.State, verb rune) {\n\treturn\n}",
+		"func (s *TestSnapshot) Version() int {\n\to3 := 89\n\treturn o3\n}",
+	}, funcTestCases[0].Decls)
+}
+
+func (s *PrintStmtTestSuite) TestFilePerFunc() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 2,
+		FilePerFunc:      true,
+	}
+	organism := s.generate("../../test/data/inputs/example_global_names", opts)
+
+	// Every function has its own test file without declarations
+	files := organism.OutputFiles()
+	s.Require().Equal(2, len(files))
+	fileNames := []string{}
+	for _, f := range files {
+		_, fileName := filepath.Split(f.FileName)
+		fileNames = append(fileNames, fileName)
+		s.Equal(1, len(f.TestCases))
+		s.True(f.DeclsShared)
+	}
+	sort.Strings(fileNames)
+	s.Equal([]string{"double.go", "triple.go"}, fileNames)
+
+	// The declarations of all test cases are shared exactly once
+	decls := organism.SharedDecls()
+	expected := []string{}
+	for _, f := range organism.Files {
+		for _, testCases := range f.TestCases {
+			for _, testCase := range testCases {
+				s.Require().NotEmpty(testCase.Decls)
+				expected = append(expected, testCase.Decls...)
+			}
+		}
+	}
+	s.ElementsMatch(expected, decls)
+	seen := make(map[string]bool)
+	for _, decl := range decls {
+		s.False(seen[decl], "declared twice: %s", decl)
+		seen[decl] = true
+	}
+}
+
+func (s *PrintStmtTestSuite) TestFilePerFuncDisabled() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_global_names", opts)
+	s.Equal(organism.Files, organism.OutputFiles())
+}
+
+func (s *PrintStmtTestSuite) TestDefaultTags() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 6,
+		DefaultTags:      true,
+	}
+	organism := s.generate("../../test/data/inputs/example_default_tag", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Address")
+	s.Require().Equal(6, len(funcTestCases))
+	defaults, random := 0, 0
+	for _, testCase := range funcTestCases {
+		s.Require().Equal(1, len(testCase.Stmts))
+		if strings.Contains(testCase.Stmts[0], `Host: "localhost"`) {
+			defaults++
+		} else {
+			random++
+		}
+	}
+	s.Greater(defaults, 0)
+	s.Greater(random, 0)
+	// Fields without default tag are still generated at random
+	s.Regexp(`^c := Config\{Host: "localhost", Port: 8080, Debug: true, Ratio: float32\(0.5\), Timeout: time.Duration\(5000000000\), Name: ".+"\}$`, funcTestCases[1].Stmts[0])
+}
+
+func (s *PrintStmtTestSuite) TestUnresolvedArrayElem() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 6,
+	}
+	organism := s.generate("../../test/data/inputs/example_unresolved_array", opts)
+
+	// Only the cases of which the slice is empty are generated, the others would contain malformed elements
+	funcTestCases := s.GetTestCase(organism.Files, "Count")
+	s.Require().Equal(2, len(funcTestCases))
+	for _, testCase := range funcTestCases {
+		s.False(testCase.Invalid)
+		s.Equal([]string{"items := []ext.Item{}"}, testCase.Stmts)
+	}
+	// Functions without unresolved types are unaffected
+	s.Equal(6, len(s.GetTestCase(organism.Files, "Double")))
+}
+
+func (s *PrintStmtTestSuite) TestWholeSliceAssertions() {
+	opts := &Options{
+		MaxRecursion:         3,
+		OrganismAmount:       1,
+		TestCasesPerFunc:     1,
+		WholeSliceAssertions: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_whole_slice", opts)
+
+	evensTestCases := s.GetTestCase(organism.Files, "Evens")
+	s.Require().Equal(1, len(evensTestCases))
+	s.Equal([]string{
+		"fmt.Printf(`{ \"type\": \"%s\", \"var_name\": \"%s\", \"type_name\": \"%s\", \"pkg\": \"%s\", \"val\": %#v}`, `slice`, `out`, `int`, `wholeslice`, fmt.Sprintf(`%#v`, out))",
+		"fmt.Println(\"\")",
+	}, evensTestCases[0].ResultStmts)
+	// Slices of structs are still asserted per element
+	diagonalTestCases := s.GetTestCase(organism.Files, "Diagonal")
+	s.Require().Equal(1, len(diagonalTestCases))
+	s.Require().Equal(1, len(diagonalTestCases[0].ResultStmts))
+	s.Contains(diagonalTestCases[0].ResultStmts[0], "`arr`")
+	s.NotContains(diagonalTestCases[0].ResultStmts[0], "`slice`")
+
+	printed := `<START;Evens0>
+{ "type": "slice", "var_name": "out", "type_name": "int", "pkg": "wholeslice", "val": "[]int{0, 2, 4}"}
+<END;Evens0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{"s.Equal([]int{0, 2, 4},out)"}, evensTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestUnorderedDirective() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_unordered", opts)
+
+	// The keys are sorted before being printed, so the output doesn't depend on map iteration order
+	keysTestCases := s.GetTestCase(organism.Files, "Keys")
+	s.Require().Equal(1, len(keysTestCases))
+	s.Require().Equal(2, len(keysTestCases[0].ResultStmts))
+	s.Contains(keysTestCases[0].ResultStmts[0], "append([]string(nil), out...)")
+	s.Contains(keysTestCases[0].ResultStmts[0], "sort.Slice(")
+	s.Contains(keysTestCases[0].ResultStmts[0], "`unordered`")
+	// Functions without the directive are asserted per element
+	valuesTestCases := s.GetTestCase(organism.Files, "Values")
+	s.Require().Equal(1, len(valuesTestCases))
+	s.Require().Equal(1, len(valuesTestCases[0].ResultStmts))
+	s.Contains(valuesTestCases[0].ResultStmts[0], "`arr`")
+
+	// Both validation runs capture the sorted keys, so the case is valid and asserted using ElementsMatch
+	printed := `<START;Keys0>
+{ "type": "unordered", "var_name": "out", "type_name": "string", "pkg": "unordered", "val": "[]string{\"a\", \"b\", \"c\"}"}
+<END;Keys0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+	s.Equal([]string{`s.ElementsMatch([]string{"a", "b", "c"},out)`}, keysTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestHeaderTemplate() {
+	tests := []struct {
+		Name           string
+		HeaderTemplate string
+		Expected       string
+	}{
+		{
+			Name:     "default header",
+			Expected: DefaultHeader,
+		},
+		{
+			Name:           "custom header",
+			HeaderTemplate: "// Copyright ACME, see LICENSE\n{{ .Header }}\n// Package {{ .File.PackageName }}",
+			Expected:       "// Copyright ACME, see LICENSE\n" + DefaultHeader + "\n// Package globalnames",
+		},
+		{
+			Name:           "header template which can't be rendered",
+			HeaderTemplate: "{{ .File.Unknown }}",
+			Expected:       DefaultHeader,
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:     3,
+				OrganismAmount:   1,
+				TestCasesPerFunc: 1,
+				HeaderTemplate:   test.HeaderTemplate,
+			}
+			organism := s.generate("../../test/data/inputs/example_global_names", opts)
+			for _, f := range organism.Files {
+				s.Equal(test.Expected, f.Header())
+			}
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestInvalidHeaderTemplate() {
+	_, err := New("../../test/data/inputs/example_global_names", &Options{HeaderTemplate: "{{ .Header"})
+	s.ErrorIs(err, ErrInvalidHeaderTemplate)
+}
+
+func (s *PrintStmtTestSuite) TestTestNameTemplate() {
+	tests := []struct {
+		Name             string
+		TestNameTemplate string
+		Expected         map[string][]string
+	}{
+		{
+			Name: "default names",
+			Expected: map[string][]string{
+				"Double": {"TestDouble0", "TestDouble1"},
+				"Triple": {"TestTriple0", "TestTriple1"},
+			},
+		},
+		{
+			Name:             "custom names",
+			TestNameTemplate: "Test_{{ .Func }}_{{ .Index }}",
+			Expected: map[string][]string{
+				"Double": {"Test_Double_0", "Test_Double_1"},
+				"Triple": {"Test_Triple_0", "Test_Triple_1"},
+			},
+		},
+		{
+			Name:             "colliding names without test prefix",
+			TestNameTemplate: "Unit{{ .Func }}",
+			Expected: map[string][]string{
+				"Double": {"TestUnitDouble", "TestUnitDouble_2"},
+				"Triple": {"TestUnitTriple", "TestUnitTriple_2"},
+			},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			opts := &Options{
+				MaxRecursion:     3,
+				OrganismAmount:   1,
+				TestCasesPerFunc: 2,
+				TestNameTemplate: test.TestNameTemplate,
+			}
+			organism := s.generate("../../test/data/inputs/example_global_names", opts)
+			names := make(map[string][]string)
+			for _, f := range organism.Files {
+				for funcName, testCases := range f.TestCases {
+					for index := range testCases {
+						names[funcName] = append(names[funcName], f.TestName(funcName, index))
+					}
+				}
+			}
+			s.Equal(test.Expected, names)
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestInvalidTestNameTemplate() {
+	// Templates which can't be parsed, can't be rendered or don't render an identifier are rejected
+	for _, testNameTemplate := range []string{"{{ .Func", "{{ .Unknown }}", "{{ .Func }}-{{ .Index }}"} {
+		_, err := New("../../test/data/inputs/example_global_names", &Options{TestNameTemplate: testNameTemplate})
+		s.ErrorIs(err, ErrInvalidTestNameTemplate, testNameTemplate)
+	}
+}
+
+func (s *PrintStmtTestSuite) TestOptionsAreCopied() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		TestNameTemplate: "Unit{{ .Func }}",
+		SkipAssertFields: []string{".*ID$"},
+	}
+	generator, err := New("../../test/data/inputs/example_global_names", opts)
+	s.Require().NoError(err)
+	s.NotNil(generator.Opts.testNameTemplate)
+	s.Len(generator.Opts.skipAssertFields, 1)
+	// The options of the caller keep only the configured values
+	s.Nil(opts.testNameTemplate)
+	s.Nil(opts.skipAssertFields)
+	s.Nil(opts.corpus)
+}
+
+func (s *PrintStmtTestSuite) TestTestNamesAreCached() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 2,
+		TestNameTemplate: "Unit{{ .Func }}",
+	}
+	organism := s.generate("../../test/data/inputs/example_global_names", opts)
+	var f *File
+	for _, file := range organism.Files {
+		if _, ok := file.TestCases["Double"]; ok {
+			f = file
+		}
+	}
+	s.Require().NotNil(f)
+	s.Equal("TestUnitDouble_2", f.TestName("Double", 1))
+	s.Equal(f.TestNames(), f.testNames)
+
+	// Names are rendered again if test cases are removed
+	f.TestCases["Double"] = f.TestCases["Double"][:1]
+	s.Equal("TestUnitDouble", f.TestName("Double", 0))
+	s.Equal([]string{"TestUnitDouble"}, f.testNames["Double"])
+}
+
+func (s *PrintStmtTestSuite) TestCustomAssertions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		AssertPackage:    "example.com/testing/myassert",
+		AssertFuncs:      map[string]string{"EqualValues": "myassert.Eq"},
+	}
+	organism := s.generate("../../test/data/inputs/example_idempotent", opts)
+	printed := `<START;Normalize0>
+{ "type": "string", "var_name": "out", "val": "bart beatty"}
+<END;Normalize0>
+`
+	organism.UpdateAssertStmts(printed, true)
+
+	// Assertions which aren't mapped keep using testify
+	funcTestCases := s.GetTestCase(organism.Files, "Normalize")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"myassert.Eq(s.T(), string(`bart beatty`), out)",
+		"s.Equal(out,Normalize(out))",
+	}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+	s.Equal(`myassert "example.com/testing/myassert"`, organism.Files[0].AssertImport())
+}
+
+func (s *PrintStmtTestSuite) TestCustomAssertionsLogged() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		LogAssertions:    true,
+		AssertPackage:    "example.com/testing/myassert",
+		AssertFuncs:      map[string]string{"EqualValues": "myassert.Eq"},
+	}
+	organism := s.generate("../../test/data/inputs/example_idempotent", opts)
+	printed := `<START;Normalize0>
+{ "type": "string", "var_name": "out", "val": "bart beatty"}
+<END;Normalize0>
+`
+	organism.UpdateAssertStmts(printed, true)
+
+	// Logged assertions don't call the custom assertion functions
+	funcTestCases := s.GetTestCase(organism.Files, "Normalize")
+	s.Require().Equal(1, len(funcTestCases))
+	for _, stmt := range funcTestCases[0].RunTimeInfo.GetAssertStmts() {
+		s.NotContains(stmt, "myassert.")
+	}
+	s.Equal("", organism.Files[0].AssertImport())
+}
+
+func (s *PrintStmtTestSuite) TestInvalidAssertMapping() {
+	tests := []struct {
+		Name    string
+		Package string
+		Funcs   map[string]string
+	}{
+		{
+			Name:  "Funcs without package",
+			Funcs: map[string]string{"Equal": "myassert.Eq"},
+		},
+		{
+			Name:    "Package without funcs",
+			Package: "example.com/testing/myassert",
+		},
+		{
+			Name:    "Unknown assertion",
+			Package: "example.com/testing/myassert",
+			Funcs:   map[string]string{"Equals": "myassert.Eq"},
+		},
+		{
+			Name:    "Unqualified func",
+			Package: "example.com/testing/myassert",
+			Funcs:   map[string]string{"Equal": "Eq"},
+		},
+		{
+			Name:    "Different packages",
+			Package: "example.com/testing/myassert",
+			Funcs:   map[string]string{"Equal": "myassert.Eq", "Nil": "other.Nil"},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			_, err := New("../../test/data/inputs/example_error", &Options{AssertPackage: test.Package, AssertFuncs: test.Funcs})
+			s.ErrorIs(err, ErrInvalidAssertMapping)
+		})
+	}
+}
+
+func (s *PrintStmtTestSuite) TestWrappedErrors() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 12,
+		WrappedErrors:    true,
+	}
+	organism := s.generate("../../test/data/inputs/example_wrapped_error", opts)
+
+	testCases := s.GetTestCase(organism.Files, "StatusCode")
+	s.Require().Equal(12, len(testCases))
+	customErrors, genericErrors, nilErrors := 0, 0, 0
+	for _, testCase := range testCases {
+		stmts := strings.Join(testCase.Stmts, "\n")
+		switch {
+		case strings.Contains(stmts, "fmt.Errorf(\"wrapped: %w\", &pointerErr)"):
+			s.Contains(stmts, "pointerErr := NotFoundError{Resource: ")
+			customErrors++
+		case strings.Contains(stmts, "fmt.Errorf(\"wrapped: %w\", errors.New("):
+			genericErrors++
+		default:
+			s.Contains(stmts, "return nil")
+			nilErrors++
+		}
+	}
+	s.Equal(2, customErrors)
+	s.Equal(4, genericErrors)
+	s.Equal(6, nilErrors)
+}
+
+func (s *PrintStmtTestSuite) TestErrorAs() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_error_as", opts)
+
+	// Non nil errors are checked for the error types of the package in their chain
+	testCases := s.GetTestCase(organism.Files, "Validate")
+	s.Require().Equal(1, len(testCases))
+	s.Require().Equal(1, len(testCases[0].ResultStmts))
+	s.Contains(testCases[0].ResultStmts[0], "if errors.As(out, new(*ValidationError)) {")
+	s.Contains(testCases[0].ResultStmts[0], "`error_as`, `out`, `*ValidationError`")
+
+	// Both validation runs find the wrapped validation error, so its type is asserted next to the presence of the error
+	printed := `<START;Validate0>
+{ "type": "error", "var_name": "out", "val": "notnil" }
+{ "type": "error_as", "var_name": "out", "type_name": "*ValidationError" }
+<END;Validate0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+	s.Equal([]string{"s.Error(out)", "s.ErrorAs(out,new(*ValidationError))"}, testCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestErrorAsCustomAssertion() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		AssertPackage:    "github.com/stretchr/testify/assert",
+		AssertFuncs:      map[string]string{"ErrorAs": "assert.ErrorAs"},
+	}
+	organism := s.generate("../../test/data/inputs/example_error_as", opts)
+	printed := `<START;Validate0>
+{ "type": "error", "var_name": "out", "val": "notnil" }
+{ "type": "error_as", "var_name": "out", "type_name": "*ValidationError" }
+<END;Validate0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	organism.UpdateAssertStmts(printed, false)
+
+	// The error precedes the target, like the arguments of errors.As
+	testCases := s.GetTestCase(organism.Files, "Validate")
+	s.Require().Equal(1, len(testCases))
+	s.Equal([]string{"s.Error(out)", "assert.ErrorAs(s.T(), out, new(*ValidationError))"}, testCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestSkipAssertFields() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		SkipAssertFields: []string{".*ID$", ".*At$"},
+	}
+	organism := s.generate("../../test/data/inputs/example_skip_assert_fields", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "NewOrder")
+	s.Require().Equal(1, len(funcTestCases))
+	resultStmts := strings.Join(funcTestCases[0].ResultStmts, "\n")
+	s.Contains(resultStmts, "`out.Amount`")
+	s.Contains(resultStmts, "`out.Customer.Name`")
+	s.NotContains(resultStmts, "`out.ID`")
+	s.NotContains(resultStmts, "`out.CreatedAt`")
+	s.NotContains(resultStmts, "`out.Customer.ID`")
+
+	printed := `<START;NewOrder0>
+{ "type": "struct", "var_name": "out", "child": { "type": "int", "var_name": "out.Amount", "val": "3"}}
+{ "type": "struct", "var_name": "out", "child": { "type": "struct", "var_name": "out.Customer", "child": { "type": "string", "var_name": "out.Customer.Name", "val": "alice"}}}
+<END;NewOrder0>
+`
+	organism.UpdateAssertStmts(printed, true)
+	s.Equal([]string{"s.EqualValues(int(3),out.Amount)", "s.EqualValues(string(`alice`),out.Customer.Name)"}, funcTestCases[0].RunTimeInfo.GetAssertStmts())
+}
+
+func (s *PrintStmtTestSuite) TestInvalidSkipAssertFields() {
+	_, err := New("../../test/data/inputs/example_skip_assert_fields", &Options{SkipAssertFields: []string{"(ID"}})
+	s.ErrorIs(err, ErrInvalidSkipAssertField)
+}
+
+func (s *PrintStmtTestSuite) TestUnexportedTypeConstructor() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_unexported_constructor", opts)
+
+	renderTestCases := s.GetTestCase(organism.Files, "Render")
+	s.Require().Equal(1, len(renderTestCases))
+	// The pointer returned by the constructor is dereferenced
+	s.Equal([]string{"w := *NewWidget(\"Lina Carroll\", -41)"}, renderTestCases[0].Stmts)
+	readTestCases := s.GetTestCase(organism.Files, "Read")
+	s.Require().Equal(1, len(readTestCases))
+	s.Equal([]string{"g := NewGauge(-47)"}, readTestCases[0].Stmts)
+	incrTestCases := s.GetTestCase(organism.Files, "Incr")
+	s.Require().Equal(1, len(incrTestCases))
+	// Pointers to values containing locks are used directly, these values aren't dereferenced to copy their locks
+	s.Equal([]string{"c := NewCounter(31)"}, incrTestCases[0].Stmts)
+	titleTestCases := s.GetTestCase(organism.Files, "Title")
+	s.Require().Equal(1, len(titleTestCases))
+	s.Equal([]string{
+		"pointerP := panel{title: \"Aleen Legros\", c: counter{mu: sync.Mutex{}, n: 90}}",
+		"p := &pointerP",
+	}, titleTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestTextUnmarshaler() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		TextUnmarshaler:  true,
+	}
+	organism := s.generate("../../test/data/inputs/example_text_unmarshaler", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Domain")
+	s.Require().Equal(1, len(funcTestCases))
+	// The generated text isn't an email address, so the email is constructed field by field instead of using the zero value
+	s.Equal([]string{
+		"var textEmail Email",
+		"if err := textEmail.UnmarshalText([]byte(\"Gerda Rosenbaum\")); err != nil {\n\ttextEmail = Email{local: \"Christian Bartoletti\", domain: \"Tomasa Steuber\"}\n}",
+		"e := textEmail",
+	}, funcTestCases[0].Stmts)
+
+	// Value receivers can't be used for unmarshalling, fall back to field construction
+	funcTestCases = s.GetTestCase(organism.Files, "LevelValue")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"l := Level{Value: 13}"}, funcTestCases[0].Stmts)
+}
+
+func (s *PrintStmtTestSuite) TestTextUnmarshalerTexts() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 10,
+		TextUnmarshaler:  true,
+		Corpus:           "../../test/data/inputs/example_text_unmarshaler/corpus.txt",
+	}
+	organism := s.generate("../../test/data/inputs/example_text_unmarshaler", opts)
+
+	// Texts of the corpus and the constants of the type are unmarshalled next to generated texts
+	stmts := func(funcName string) string {
+		funcTestCases := s.GetTestCase(organism.Files, funcName)
+		s.Require().Equal(10, len(funcTestCases))
+		res := []string{}
+		for _, funcTestCase := range funcTestCases {
+			res = append(res, funcTestCase.Stmts...)
+		}
+		return strings.Join(res, "\n")
+	}
+	domain := stmts("Domain")
+	s.Contains(domain, `textEmail.UnmarshalText([]byte("bob@example.com"))`)
+	isRed := stmts("IsRed")
+	s.Contains(isRed, `textColor.UnmarshalText([]byte("red"))`)
+	s.Contains(isRed, `textColor.UnmarshalText([]byte("blue"))`)
+}
+
+func (s *PrintStmtTestSuite) TestRegenerateCase() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		SeedOffsets:      true,
+	}
+	organism := s.generate("../../test/data/inputs/example_struct", opts)
+	funcTestCases := s.GetTestCase(organism.Files, "StructFuncComposite")
+	s.Require().Equal(1, len(funcTestCases))
+	testCase := funcTestCases[0]
+	seedOffset := testCase.SeedOffset
+	stmts := testCase.Stmts
+	funcStmt := testCase.FuncStmt
+
+	// Creating the case again uses a new seed offset
+	testCase.Create()
+	s.NotEqual(seedOffset, testCase.SeedOffset)
+	s.NotEqual(stmts, testCase.Stmts)
+
+	testCase.RegenerateCase(seedOffset)
+	s.Equal(seedOffset, testCase.SeedOffset)
+	s.Equal(stmts, testCase.Stmts)
+	s.Equal(funcStmt, testCase.FuncStmt)
+}
+
+func (s *PrintStmtTestSuite) TestFunctionalOptions() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_functional_options", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Address")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"optionsServer := New(WithHost(\"Nickolas Emard\"))",
+		"s2 := optionsServer",
+	}, funcTestCases[0].Stmts)
+
+	funcTestCases = s.GetTestCase(organism.Files, "IsSecure")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"optionsServer := New(WithPort(41), WithTLS())",
+		"s2 := *optionsServer",
+	}, funcTestCases[0].Stmts)
+
+	// NewDefault is declared in a later file, the constructor of the first file is used every generation
+	for i := 0; i < 10; i++ {
+		generator, err := New("../../test/data/inputs/example_functional_options", opts)
+		s.Require().NoError(err)
+		organisms := generator.GetTestCases()
+		s.Require().Equal(1, len(organisms))
+		funcTestCases := s.GetTestCase(organisms[0].Files, "Address")
+		s.Require().Equal(1, len(funcTestCases))
+		s.True(strings.HasPrefix(funcTestCases[0].Stmts[0], "optionsServer := New("), funcTestCases[0].Stmts[0])
+	}
+}
+
+func (s *PrintStmtTestSuite) TestJSONOmitEmpty() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 6,
+	}
+	organism := s.generate("../../test/data/inputs/example_omitempty", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Marshal")
+	s.Require().Equal(6, len(funcTestCases))
+	present, absent := 0, 0
+	for _, testCase := range funcTestCases {
+		s.Require().Equal(1, len(testCase.Stmts))
+		s.Contains(testCase.Stmts[0], "Name: ")
+		if strings.Contains(testCase.Stmts[0], "Nickname: ") {
+			present++
+		} else {
+			absent++
+		}
+	}
+	s.Greater(present, 0)
+	s.Greater(absent, 0)
+}
+
+func (s *PrintStmtTestSuite) TestPromotedMethods() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		PromotedMethods:  true,
+	}
+	organism := s.generate("../../test/data/inputs/example_promoted", opts)
+	funcNames := []string{}
+	for _, f := range organism.Files {
+		for funcName := range f.TestCases {
+			funcNames = append(funcNames, funcName)
+		}
+	}
+	s.ElementsMatch([]string{"WrapperAdd", "WrapperMerge", "WrapperValue"}, funcNames)
+
+	funcTestCases := s.GetTestCase(organism.Files, "WrapperAdd")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"wrapper := Wrapper{Counter: ext.Counter{N: -47}, Name: \"Stacy Dietrich\"}",
+		"x := 41",
+	}, funcTestCases[0].Stmts)
+	s.Equal("wrapper.Add(x)", funcTestCases[0].FuncStmt)
+
+	// Declared methods shadow promoted methods, also if they're declared in another file than the type
+	funcTestCases = s.GetTestCase(organism.Files, "WrapperValue")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("w.Value()", funcTestCases[0].FuncStmt)
+	funcTestCases = s.GetTestCase(organism.Files, "WrapperMerge")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("w.Merge(other)", funcTestCases[0].FuncStmt)
+}
+
+func (s *PrintStmtTestSuite) TestAmbiguousPromotedMethods() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		PromotedMethods:  true,
+	}
+	organism := s.generate("../../test/data/inputs/example_promoted_ambiguous", opts)
+
+	// Add and Value are provided by multiple embedded fields, so only Merge and Ratio are promoted
+	funcNames := []string{}
+	for funcName := range organism.Files[0].TestCases {
+		funcNames = append(funcNames, funcName)
+	}
+	s.ElementsMatch([]string{"LabelValue", "MeterMerge", "MeterRatio"}, funcNames)
+
+	funcTestCases := s.GetTestCase(organism.Files, "MeterRatio")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("meter.Ratio(max)", funcTestCases[0].FuncStmt)
+}
+
+func (s *PrintStmtTestSuite) TestZeroValueBodies() {
+	declsSize := func(zeroValueBodies bool) (int, []string) {
+		opts := &Options{
+			MaxRecursion:     3,
+			OrganismAmount:   1,
+			TestCasesPerFunc: 1,
+			ZeroValueBodies:  zeroValueBodies,
+		}
+		organism := s.generate("../../test/data/inputs/example_fat_interface", opts)
+		funcTestCases := s.GetTestCase(organism.Files, "Describe")
+		s.Require().Equal(1, len(funcTestCases))
+		size := 0
+		for _, decl := range funcTestCases[0].Decls {
+			size += len(decl)
+		}
+		return size, funcTestCases[0].Decls
+	}
+	fullSize, _ := declsSize(false)
+	zeroSize, decls := declsSize(true)
+	s.Less(zeroSize, fullSize)
+	s.Require().Equal(7, len(decls))
+	// Methods called by the function under test keep generated return values
+	s.True(strings.HasPrefix(decls[3], "func (s *TestStore) Report(id int) Report {\n\to3 := Report{Title: \"Lina Carroll\""))
+	s.Equal([]string{
+		"func (s *TestStore) Reports() []Report {\n\tvar o4 []Report\n\treturn o4\n}",
+		"func (s *TestStore) Rows(key string) ([]Row, error) {\n\tvar o5 []Row\n\tvar o6 error\n\treturn o5, o6\n}",
+		"func (s *TestStore) Index() map[string]Row {\n\tvar o7 map[string]Row\n\treturn o7\n}",
+	}, decls[4:])
+}
+
+func (s *PrintStmtTestSuite) TestReachableMethodsOnly() {
+	opts := &Options{
+		MaxRecursion:         3,
+		OrganismAmount:       1,
+		TestCasesPerFunc:     1,
+		ReachableMethodsOnly: true,
+	}
+	organism := s.generate("../../test/data/inputs/example_reachable_methods", opts)
+
+	// Version is called by a helper of the function under test, so it's reachable as well
+	funcTestCases := s.GetTestCase(organism.Files, "Describe")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{
+		"type TestServer struct {\n}",
+		"func (s *TestServer) Name() string {\n\to := \"Bart Beatty\"\n\treturn o\n}",
+		"func (s *TestServer) Version() int {\n\to2 := -73\n\treturn o2\n}",
+		"func (s *TestServer) Ping(msg string) (string, error) {\n\tvar o3 string\n\tvar o4 error\n\treturn o3, o4\n}",
+		"func (s *TestServer) Get(id int) []byte {\n\tvar o5 []byte\n\treturn o5\n}",
+		"func (s *TestServer) List(offset, limit int) ([]string, error) {\n\tvar o6 []string\n\tvar o7 error\n\treturn o6, o7\n}",
+		"func (s *TestServer) Delete(id int) error {\n\tvar o8 error\n\treturn o8\n}",
+		"func (s *TestServer) Stats() map[string]int {\n\tvar o9 map[string]int\n\treturn o9\n}",
+	}, funcTestCases[0].Decls)
+
+	funcTestCases = s.GetTestCase(organism.Files, "version")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal("func (s *TestServer2) Name() string {\n\tvar o string\n\treturn o\n}", funcTestCases[0].Decls[1])
+	s.Equal("func (s *TestServer2) Version() int {\n\to2 := -92\n\treturn o2\n}", funcTestCases[0].Decls[2])
+}
+
+func (s *PrintStmtTestSuite) TestReflectValue() {
+	opts := &Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	organism := s.generate("../../test/data/inputs/example_reflect", opts)
+
+	funcTestCases := s.GetTestCase(organism.Files, "Kind")
+	s.Require().Equal(1, len(funcTestCases))
+	s.Equal([]string{"v := reflect.ValueOf(uint64(35))"}, funcTestCases[0].Stmts)
+}
+
+func TestPrintStmtTestSuite(t *testing.T) {
+	suite.Run(t, new(PrintStmtTestSuite))
+}
+
+func (s *PrintStmtTestSuite) GetTestCase(files []*File, funcName string) []*testcase.TestCase {
+	for _, file := range files {
+		if x, ok := file.TestCases[funcName]; ok {
+			return x
+		}
+	}
+	s.Fail("test not found")
+	return nil
+}
+
+// generate generates the single organism of the input in given directory, seeded so the generated values are deterministic
+func (s *PrintStmtTestSuite) generate(dir string, opts *Options) *Organism {
+	seed.SetRandomSeed(1)
+	generator, err := New(dir, opts)
+	s.Require().NoError(err)
+	organisms := generator.GetTestCases()
+	s.Require().Equal(1, len(organisms))
+	return organisms[0]
 }
@@ -0,0 +1,51 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// DefaultHeader header of the generated test files if no header template is configured
+const DefaultHeader = "// Code generated by finalunit, visit us at https://github.com/wimspaargaren/final-unit"
+
+// ErrInvalidHeaderTemplate header template can't be parsed
+var ErrInvalidHeaderTemplate = fmt.Errorf("invalid header template")
+
+// HeaderInput input of a header template
+type HeaderInput struct {
+	// Header the default header
+	Header string
+	// File the file for which the test file is generated
+	File *File
+}
+
+// parseHeaderTemplate parses the header template, nil is returned if no header template is configured
+func parseHeaderTemplate(headerTemplate string) (*template.Template, error) {
+	if headerTemplate == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("header").Parse(headerTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidHeaderTemplate, err.Error())
+	}
+	return tmpl, nil
+}
+
+// Header renders the header placed at the top of the test file generated for this file,
+// the default header is used if no header template is configured or it can't be rendered
+func (f *File) Header() string {
+	if f.Opts == nil || f.Opts.headerTemplate == nil {
+		return DefaultHeader
+	}
+	buf := &bytes.Buffer{}
+	err := f.Opts.headerTemplate.Execute(buf, HeaderInput{
+		Header: DefaultHeader,
+		File:   f,
+	})
+	if err != nil {
+		f.Opts.logger().WithError(err).Errorf("unable to render header template for file: %s", f.FileName)
+		return DefaultHeader
+	}
+	return buf.String()
+}
@@ -0,0 +1,191 @@
+package gen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// HoistHelpers hoists the construction of values shared by multiple test cases into helper functions
+// for all files of the organism, if enabled
+func (o *Organism) HoistHelpers() {
+	for _, f := range o.Files {
+		if f.Opts.Helpers && f.Helpers == nil {
+			f.HoistHelpers()
+		}
+	}
+}
+
+// hoistCandidate statement of a test case assigning a self contained composite literal
+type hoistCandidate struct {
+	testCase *testcase.TestCase
+	index    int
+	ident    string
+}
+
+// HoistHelpers replaces composite literals which are constructed identically by multiple test cases
+// with a call to a helper function taking testing.TB, which is added to the helpers of the file
+func (f *File) HoistHelpers() {
+	f.Helpers = []string{}
+	candidates := make(map[string][]*hoistCandidate)
+	types := make(map[string]ast.Expr)
+	order := []string{}
+	for _, funcName := range f.sortedFuncNames() {
+		for _, testCase := range f.TestCases[funcName] {
+			defined := make(map[string]bool)
+			for i, stmtString := range testCase.Stmts {
+				stmt, err := parseStmt(stmtString)
+				if err != nil {
+					f.Opts.logger().WithError(err).Debugf("unable to parse statement: %s", stmtString)
+					break
+				}
+				ident, value, valueType, ok := compositeLitAssignment(stmt)
+				if ok && !referencesAny(value, defined) {
+					key := testcase.MustPrettyPrintElement(value)
+					if _, ok := candidates[key]; !ok {
+						order = append(order, key)
+						types[key] = valueType
+					}
+					candidates[key] = append(candidates[key], &hoistCandidate{
+						testCase: testCase,
+						index:    i,
+						ident:    ident,
+					})
+				}
+				for _, name := range definedIdents(stmt) {
+					defined[name] = true
+				}
+			}
+		}
+	}
+	for _, key := range order {
+		if !sharedByTestCases(candidates[key]) {
+			continue
+		}
+		typeString := testcase.MustPrettyPrintElement(types[key])
+		name := "new" + utils.UpperCaseFirstLetter(f.IdentGen.CreateGlobal(&ast.Ident{Name: helperBaseName(types[key])}).Name)
+		f.Helpers = append(f.Helpers, fmt.Sprintf("func %s(tb testing.TB) %s {\n\ttb.Helper()\n\treturn %s\n}", name, typeString, key))
+		for _, candidate := range candidates[key] {
+			candidate.testCase.Stmts[candidate.index] = fmt.Sprintf("%s := %s(s.T())", candidate.ident, name)
+		}
+	}
+}
+
+func (f *File) sortedFuncNames() []string {
+	res := []string{}
+	for funcName := range f.TestCases {
+		res = append(res, funcName)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// sharedByTestCases checks if the candidates are part of more than one test case
+func sharedByTestCases(candidates []*hoistCandidate) bool {
+	for _, candidate := range candidates {
+		if candidate.testCase != candidates[0].testCase {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStmt parses a single printed statement
+func parseStmt(stmt string) (ast.Stmt, error) {
+	astFile, err := parser.ParseFile(token.NewFileSet(), "", "package p\nfunc _() {\n"+stmt+"\n}", 0)
+	if err != nil {
+		return nil, err
+	}
+	body := astFile.Decls[0].(*ast.FuncDecl).Body
+	if len(body.List) != 1 {
+		return nil, fmt.Errorf("expected a single statement, got %d", len(body.List))
+	}
+	return body.List[0], nil
+}
+
+// compositeLitAssignment retrieves the identifier, the value and its type of a statement
+// defining a single variable using a non empty composite literal or a pointer to it, e.g. x := &T{Y: 1}
+func compositeLitAssignment(stmt ast.Stmt) (string, ast.Expr, ast.Expr, bool) {
+	assignStmt, ok := stmt.(*ast.AssignStmt)
+	if !ok || assignStmt.Tok != token.DEFINE || len(assignStmt.Lhs) != 1 || len(assignStmt.Rhs) != 1 {
+		return "", nil, nil, false
+	}
+	ident, ok := assignStmt.Lhs[0].(*ast.Ident)
+	if !ok {
+		return "", nil, nil, false
+	}
+	value := assignStmt.Rhs[0]
+	compositeLit, isPointer := value, false
+	if unaryExpr, ok := value.(*ast.UnaryExpr); ok && unaryExpr.Op == token.AND {
+		compositeLit, isPointer = unaryExpr.X, true
+	}
+	lit, ok := compositeLit.(*ast.CompositeLit)
+	if !ok || lit.Type == nil || len(lit.Elts) == 0 {
+		return "", nil, nil, false
+	}
+	if isPointer {
+		return ident.Name, value, &ast.StarExpr{X: lit.Type}, true
+	}
+	return ident.Name, value, lit.Type, true
+}
+
+// referencesAny checks if expression uses any of the given identifiers
+func referencesAny(e ast.Expr, idents map[string]bool) bool {
+	found := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && idents[ident.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// definedIdents retrieves the identifiers defined by a statement
+func definedIdents(stmt ast.Stmt) []string {
+	res := []string{}
+	switch t := stmt.(type) {
+	case *ast.AssignStmt:
+		for _, lhs := range t.Lhs {
+			if ident, ok := lhs.(*ast.Ident); ok {
+				res = append(res, ident.Name)
+			}
+		}
+	case *ast.DeclStmt:
+		genDecl, ok := t.Decl.(*ast.GenDecl)
+		if !ok {
+			return res
+		}
+		for _, spec := range genDecl.Specs {
+			if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+				for _, name := range valueSpec.Names {
+					res = append(res, name.Name)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// helperBaseName creates the base name of a helper constructing a value of given type
+func helperBaseName(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.StarExpr:
+		return helperBaseName(t.X)
+	case *ast.Ident:
+		return utils.LowerCaseFirstLetter(t.Name)
+	case *ast.SelectorExpr:
+		return utils.LowerCaseFirstLetter(t.Sel.Name)
+	case *ast.ArrayType:
+		return "slice"
+	case *ast.MapType:
+		return "map"
+	default:
+		return "value"
+	}
+}
@@ -0,0 +1,226 @@
+package gen
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// PromotedMethods creates function declarations for the exported methods which are promoted
+// to the struct types declared in given file by embedding a type of an imported package.
+// Methods provided by multiple embedded fields are ambiguous, so they aren't promoted
+func (f *File) PromotedMethods(path string, astFile *ast.File) []ast.Decl {
+	res := []ast.Decl{}
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			declared := f.declaredMethods(path, typeSpec.Name.Name)
+			methods := []*ast.FuncDecl{}
+			providers := make(map[string]int)
+			for _, field := range structType.Fields.List {
+				// Only embedded fields promote methods
+				if len(field.Names) != 0 {
+					continue
+				}
+				for _, method := range f.promotedMethodsForField(path, field) {
+					methods = append(methods, method)
+					providers[method.Name.Name]++
+				}
+				// Types of the current package aren't promoted, but their methods conflict with the promoted ones
+				for _, method := range f.localMethodsForField(path, field) {
+					providers[method.Name.Name]++
+				}
+			}
+			for _, method := range methods {
+				if declared[method.Name.Name] {
+					continue
+				}
+				// All embedded fields are at the same depth, so a method provided by more than one is ambiguous
+				if providers[method.Name.Name] > 1 {
+					f.Opts.logger().Debugf("unable to promote ambiguous method %s to %s", method.Name.Name, typeSpec.Name.Name)
+					continue
+				}
+				declared[method.Name.Name] = true
+				res = append(res, &ast.FuncDecl{
+					Recv: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{{Name: utils.LowerCaseFirstLetter(typeSpec.Name.Name)}},
+								Type:  &ast.Ident{Name: typeSpec.Name.Name, Obj: typeSpec.Name.Obj},
+							},
+						},
+					},
+					Name: method.Name,
+					Type: method.Type,
+				})
+			}
+		}
+	}
+	return res
+}
+
+// promotedMethodsForField retrieves the exported methods of the imported type embedded by given field
+// with their signature qualified by the selector used in the current file
+func (f *File) promotedMethodsForField(path string, field *ast.Field) []*ast.FuncDecl {
+	fieldType := field.Type
+	if starExpr, ok := fieldType.(*ast.StarExpr); ok {
+		fieldType = starExpr.X
+	}
+	selectorExpr, ok := fieldType.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	selectorIdent, ok := selectorExpr.X.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	pointer := &importer.PkgResolverPointer{
+		Dir:  f.PackageInfo.RootDir,
+		Pkg:  f.PackageInfo.RootPkg,
+		File: path,
+	}
+	found, _, newPointer := f.PackageInfo.FindImport(pointer, selectorIdent.Name, selectorExpr.Sel.Name)
+	if !found {
+		return nil
+	}
+	res := []*ast.FuncDecl{}
+	for _, method := range f.PackageInfo.MethodsForType(newPointer, selectorExpr.Sel.Name) {
+		if !method.Name.IsExported() {
+			continue
+		}
+		funcType, ok := qualifyExpr(method.Type, selectorIdent.Name).(*ast.FuncType)
+		if !ok {
+			f.Opts.logger().Debugf("unable to create promoted method %s for %s.%s", method.Name.Name, selectorIdent.Name, selectorExpr.Sel.Name)
+			continue
+		}
+		res = append(res, &ast.FuncDecl{
+			Name: method.Name,
+			Type: funcType,
+		})
+	}
+	return res
+}
+
+// localMethodsForField retrieves the methods of the type of the current package embedded by given field
+func (f *File) localMethodsForField(path string, field *ast.Field) []*ast.FuncDecl {
+	fieldType := field.Type
+	if starExpr, ok := fieldType.(*ast.StarExpr); ok {
+		fieldType = starExpr.X
+	}
+	ident, ok := fieldType.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	return f.PackageInfo.MethodsForType(&importer.PkgResolverPointer{
+		Dir:  f.PackageInfo.RootDir,
+		Pkg:  f.PackageInfo.RootPkg,
+		File: path,
+	}, ident.Name)
+}
+
+// declaredMethods retrieves the names of the methods declared for given type in any file of the current package
+func (f *File) declaredMethods(path, typeName string) map[string]bool {
+	res := make(map[string]bool)
+	for _, method := range f.PackageInfo.MethodsForType(&importer.PkgResolverPointer{
+		Dir:  f.PackageInfo.RootDir,
+		Pkg:  f.PackageInfo.RootPkg,
+		File: path,
+	}, typeName) {
+		res[method.Name.Name] = true
+	}
+	return res
+}
+
+// qualifyExpr qualifies the identifiers declared in an imported package with given selector,
+// nil is returned if the expression can't be qualified e.g. when it refers to other packages
+func qualifyExpr(e ast.Expr, selector string) ast.Expr { // nolint: gocyclo
+	switch t := e.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.Name) != nil {
+			return &ast.Ident{Name: t.Name}
+		}
+		// Unexported types are not accessible from the current package
+		if !t.IsExported() {
+			return nil
+		}
+		return &ast.SelectorExpr{
+			X:   &ast.Ident{Name: selector},
+			Sel: &ast.Ident{Name: t.Name},
+		}
+	case *ast.StarExpr:
+		x := qualifyExpr(t.X, selector)
+		if x == nil {
+			return nil
+		}
+		return &ast.StarExpr{X: x}
+	case *ast.ArrayType:
+		elt := qualifyExpr(t.Elt, selector)
+		if elt == nil {
+			return nil
+		}
+		return &ast.ArrayType{Len: t.Len, Elt: elt}
+	case *ast.Ellipsis:
+		elt := qualifyExpr(t.Elt, selector)
+		if elt == nil {
+			return nil
+		}
+		return &ast.Ellipsis{Elt: elt}
+	case *ast.MapType:
+		key := qualifyExpr(t.Key, selector)
+		value := qualifyExpr(t.Value, selector)
+		if key == nil || value == nil {
+			return nil
+		}
+		return &ast.MapType{Key: key, Value: value}
+	case *ast.ChanType:
+		value := qualifyExpr(t.Value, selector)
+		if value == nil {
+			return nil
+		}
+		return &ast.ChanType{Dir: t.Dir, Value: value}
+	case *ast.FuncType:
+		params := qualifyFieldList(t.Params, selector)
+		results := qualifyFieldList(t.Results, selector)
+		if params == nil || results == nil {
+			return nil
+		}
+		if t.Results == nil {
+			results = nil
+		}
+		return &ast.FuncType{Params: params, Results: results}
+	default:
+		return nil
+	}
+}
+
+// qualifyFieldList qualifies the types of a field list, nil is returned if a type can't be qualified
+func qualifyFieldList(fieldList *ast.FieldList, selector string) *ast.FieldList {
+	res := &ast.FieldList{}
+	if fieldList == nil {
+		return res
+	}
+	for _, field := range fieldList.List {
+		fieldType := qualifyExpr(field.Type, selector)
+		if fieldType == nil {
+			return nil
+		}
+		res.List = append(res.List, &ast.Field{
+			Names: field.Names,
+			Type:  fieldType,
+		})
+	}
+	return res
+}
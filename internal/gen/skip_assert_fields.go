@@ -0,0 +1,22 @@
+package gen
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ErrInvalidSkipAssertField pattern of fields skipped in assertions can't be compiled
+var ErrInvalidSkipAssertField = fmt.Errorf("invalid skip assert field pattern")
+
+// parseSkipAssertFields compiles the patterns of the names of fields which aren't asserted
+func parseSkipAssertFields(patterns []string) ([]*regexp.Regexp, error) {
+	res := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSkipAssertField, err.Error())
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
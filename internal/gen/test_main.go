@@ -0,0 +1,51 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TestMainFileName name of the file declaring the TestMain of the package, which differs from the conventional
+// main_test.go, so a hand-written file isn't overwritten
+const TestMainFileName = "finalunit_main_test.go"
+
+// TestMain creates the TestMain of the package calling the functions specified by the setup and teardown
+// directives around running the tests. An empty string is returned if disabled or no functions are specified.
+// Test cases are split over files per source file or function, so it's created once per organism
+func (o *Organism) TestMain() string {
+	if len(o.Files) == 0 || !o.Files[0].Opts.TestMain {
+		return ""
+	}
+	deco := o.Files[0].Deco
+	if deco == nil || (deco.Setup == nil && deco.Teardown == nil) {
+		return ""
+	}
+	lines := []string{"func TestMain(m *testing.M) {"}
+	if deco.Setup != nil {
+		if deco.Setup.ReturnsError {
+			lines = append(lines,
+				fmt.Sprintf("\tif err := %s(); err != nil {", deco.Setup.Name),
+				"\t\tfmt.Println(\"setup failed:\", err)",
+				"\t\tos.Exit(1)",
+				"\t}",
+			)
+		} else {
+			lines = append(lines, fmt.Sprintf("\t%s()", deco.Setup.Name))
+		}
+	}
+	lines = append(lines, "\tcode := m.Run()")
+	if deco.Teardown != nil {
+		if deco.Teardown.ReturnsError {
+			lines = append(lines,
+				fmt.Sprintf("\tif err := %s(); err != nil {", deco.Teardown.Name),
+				"\t\tfmt.Println(\"teardown failed:\", err)",
+				"\t\tcode = 1",
+				"\t}",
+			)
+		} else {
+			lines = append(lines, fmt.Sprintf("\t%s()", deco.Teardown.Name))
+		}
+	}
+	lines = append(lines, "\tos.Exit(code)", "}")
+	return strings.Join(lines, "\n")
+}
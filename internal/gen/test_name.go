@@ -0,0 +1,135 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ErrInvalidTestNameTemplate test name template can't be parsed
+var ErrInvalidTestNameTemplate = fmt.Errorf("invalid test name template")
+
+// TestNameInput input of a test name template
+type TestNameInput struct {
+	// Suite the name of the test suite of the file
+	Suite string
+	// Func the name of the function under test, prefixed by its receiver type for methods
+	Func string
+	// Index the index of the test case of the function
+	Index int
+}
+
+// parseTestNameTemplate parses the test name template, nil is returned if no test name template is configured.
+// The template is rendered for an example test case, so templates which don't render a valid method name are rejected
+func parseTestNameTemplate(testNameTemplate string) (*template.Template, error) {
+	if testNameTemplate == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("testName").Parse(testNameTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTestNameTemplate, err.Error())
+	}
+	_, err = renderTestName(tmpl, TestNameInput{Suite: "Example", Func: "Example", Index: 0})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTestNameTemplate, err.Error())
+	}
+	return tmpl, nil
+}
+
+// TestName retrieves the name of the test method of the test case with given index of a function. The names are
+// rendered once for the test cases of the file, they're only rendered again if test cases are added or removed
+func (f *File) TestName(funcName string, index int) string {
+	if f.Opts == nil || f.Opts.testNameTemplate == nil {
+		return defaultTestName(funcName, index)
+	}
+	key := f.testNamesKey()
+	if f.testNames == nil || f.testNamesFor != key {
+		f.testNames = f.TestNames()
+		f.testNamesFor = key
+	}
+	if index >= len(f.testNames[funcName]) {
+		return defaultTestName(funcName, index)
+	}
+	return f.testNames[funcName][index]
+}
+
+// testNamesKey identifies the test cases named by the cached test names, i.e. the amount of test cases per function
+func (f *File) testNamesKey() string {
+	funcNames := []string{}
+	for funcName := range f.TestCases {
+		funcNames = append(funcNames, funcName)
+	}
+	sort.Strings(funcNames)
+	var b strings.Builder
+	for _, funcName := range funcNames {
+		b.WriteString(funcName + ":" + strconv.Itoa(len(f.TestCases[funcName])) + ",")
+	}
+	return b.String()
+}
+
+// TestNames renders the names of the test methods of all test cases of the file using the test name template,
+// per function indexed like its test cases. Colliding names are made unique by suffixing a counter
+func (f *File) TestNames() map[string][]string {
+	res := make(map[string][]string)
+	// Names are assigned in the order the test cases are printed, so the names are stable
+	funcNames := []string{}
+	for funcName := range f.TestCases {
+		funcNames = append(funcNames, funcName)
+	}
+	sort.Strings(funcNames)
+	used := make(map[string]bool)
+	for _, funcName := range funcNames {
+		for index := range f.TestCases[funcName] {
+			name := f.renderTestName(funcName, index)
+			unique := name
+			for i := 2; used[unique]; i++ {
+				unique = name + "_" + strconv.Itoa(i)
+			}
+			used[unique] = true
+			res[funcName] = append(res[funcName], unique)
+		}
+	}
+	return res
+}
+
+// renderTestName renders the test name template for a test case of the file, the default name is used
+// if the template can't be rendered
+func (f *File) renderTestName(funcName string, index int) string {
+	name, err := renderTestName(f.Opts.testNameTemplate, TestNameInput{
+		Suite: f.SuiteName(),
+		Func:  funcName,
+		Index: index,
+	})
+	if err != nil {
+		f.Opts.logger().WithError(err).Errorf("unable to render test name template for func: %s", funcName)
+		return defaultTestName(funcName, index)
+	}
+	return name
+}
+
+// renderTestName renders the test name template, testify only runs methods prefixed by Test, so the prefix
+// is added if the template omits it. An error is returned if the rendered name isn't an identifier
+func renderTestName(tmpl *template.Template, input TestNameInput) (string, error) {
+	buf := &bytes.Buffer{}
+	err := tmpl.Execute(buf, input)
+	if err != nil {
+		return "", err
+	}
+	name := strings.TrimSpace(buf.String())
+	if !strings.HasPrefix(name, "Test") {
+		name = "Test" + name
+	}
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("rendered test name isn't an identifier: %q", name)
+	}
+	return name, nil
+}
+
+// defaultTestName the name of the test method of a test case if no test name template is configured
+func defaultTestName(funcName string, index int) string {
+	return fmt.Sprintf("Test%s%d", funcName, index)
+}
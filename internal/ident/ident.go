@@ -55,7 +55,8 @@ func (g *Gen) Create(i *ast.Ident) *ast.Ident {
 	}
 }
 
-// CreateGlobal creates new global scope identifier
+// CreateGlobal creates new global scope identifier, successive identifiers with the same name are numbered
+// e.g. TestReader, TestReader2, so names of e.g. synthetic implementations are deterministic and never collide
 func (g *Gen) CreateGlobal(i *ast.Ident) *ast.Ident {
 	i = globalIdentPrefix(i)
 	defer g.increaseGlobalMem(i.Name)
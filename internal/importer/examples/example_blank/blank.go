@@ -0,0 +1,10 @@
+package blank
+
+import (
+	_ "github.com/wimspaargaren/final-unit/internal/importer/examples/example_blank/pkg/types"
+	"github.com/wimspaargaren/final-unit/internal/importer/examples/example_blank/pkg/v1"
+)
+
+func Blank(x types.SomeType) int {
+	return x.X
+}
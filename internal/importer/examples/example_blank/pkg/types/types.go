@@ -0,0 +1,4 @@
+package types
+
+// Registered is set when the package is imported
+var Registered = true
@@ -0,0 +1,6 @@
+package types
+
+// SomeType type which should be resolved
+type SomeType struct {
+	X int
+}
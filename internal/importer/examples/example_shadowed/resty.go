@@ -0,0 +1,15 @@
+package resty
+
+import (
+	"github.com/go-resty/resty/v2"
+)
+
+// Client client wrapping a client of the imported package of the same name
+type Client struct {
+	Inner *resty.Client
+}
+
+// Wrap wraps a client of the imported package
+func Wrap(c *resty.Client) *Client {
+	return &Client{Inner: c}
+}
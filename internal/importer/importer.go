@@ -8,6 +8,8 @@ import (
 	"go/parser"
 	"go/token"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -36,12 +38,30 @@ type PackageInfo struct {
 	// dir is unique
 
 	PkgInfo map[string]map[string]*ast.Package
+	// FileSet positions of the parsed files of the root directory
+	FileSet *token.FileSet
+	// Logger logger used for diagnostics, the global logrus logger is used if nil
+	Logger log.FieldLogger
+
+	// rootTestFiles test files of the root package, parsed when first requested
+	rootTestFiles map[string]*ast.File
+	// importDirs directories of the resolved import paths
+	importDirs map[string]string
+}
+
+// logger retrieves the logger used for diagnostics
+func (p *PackageInfo) logger() log.FieldLogger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return log.StandardLogger()
 }
 
-// ParseRoot parse a root directory
-func ParseRoot(dir string) (*PackageInfo, error) {
+// ParseRoot parse a root directory, diagnostics are logged using given logger, or the global logrus logger if nil
+func ParseRoot(dir string, logger log.FieldLogger) (*PackageInfo, error) {
+	res := &PackageInfo{Logger: logger}
 	fset := token.NewFileSet()
-	pkgs, err := parser.ParseDir(fset, dir, FileFilter, parser.AllErrors)
+	pkgs, err := parser.ParseDir(fset, dir, res.BuildFileFilter(dir), parser.AllErrors)
 	if err != nil {
 		return nil, err
 	}
@@ -53,11 +73,11 @@ func ParseRoot(dir string) (*PackageInfo, error) {
 	}
 	// FIXME checks for parsing root
 	for k := range pkgs {
-		return &PackageInfo{
-			RootDir: dir,
-			PkgInfo: map[string]map[string]*ast.Package{dir: pkgs},
-			RootPkg: k,
-		}, nil
+		res.RootDir = dir
+		res.PkgInfo = map[string]map[string]*ast.Package{dir: pkgs}
+		res.RootPkg = k
+		res.FileSet = fset
+		return res, nil
 	}
 	return nil, nil
 }
@@ -67,8 +87,24 @@ func FileFilter(fileInfo os.FileInfo) bool {
 	return !strings.HasSuffix(fileInfo.Name(), "_test.go")
 }
 
+// BuildFileFilter filters the files of given dir which are excluded by FileFilter, or by the build
+// constraints of the current build context, e.g. files only declaring a struct for another platform
+func (p *PackageInfo) BuildFileFilter(dir string) func(os.FileInfo) bool {
+	return func(fileInfo os.FileInfo) bool {
+		if !FileFilter(fileInfo) {
+			return false
+		}
+		match, err := build.Default.MatchFile(dir, fileInfo.Name())
+		if err != nil {
+			p.logger().WithError(err).Debugf("unable to match build constraints of file: %s", fileInfo.Name())
+			return true
+		}
+		return match
+	}
+}
+
 // GetImportSpecForIdentifierAndFile Find an import spec for given identifier in given file
-func GetImportSpecForIdentifierAndFile(identifier string, file *ast.File) (*ast.ImportSpec, error) {
+func (p *PackageInfo) GetImportSpecForIdentifierAndFile(identifier string, file *ast.File) (*ast.ImportSpec, error) {
 	for _, i := range file.Imports {
 		if i.Name != nil {
 			if i.Name.Name == identifier {
@@ -78,17 +114,23 @@ func GetImportSpecForIdentifierAndFile(identifier string, file *ast.File) (*ast.
 	}
 	// we assume idents can not equal pkg
 	for _, i := range file.Imports {
+		if !isTypeSource(i) {
+			continue
+		}
 		if importIdentifier(i) == identifier {
 			return i, nil
 		}
 	}
-	return TryToFindIdentifier(identifier, file)
+	return p.TryToFindIdentifier(identifier, file)
 }
 
 // TryToFindIdentifier in case this is hit, we got unresolved imports
 // an example could be "somepkg/v2"
-func TryToFindIdentifier(identifier string, file *ast.File) (*ast.ImportSpec, error) {
+func (p *PackageInfo) TryToFindIdentifier(identifier string, file *ast.File) (*ast.ImportSpec, error) {
 	for _, i := range file.Imports {
+		if !isTypeSource(i) {
+			continue
+		}
 		importPath := strings.ReplaceAll(i.Path.Value, `"`, "")
 		ctx := build.Default
 		if importPath == "C" {
@@ -99,12 +141,12 @@ func TryToFindIdentifier(identifier string, file *ast.File) (*ast.ImportSpec, er
 			return nil, err
 		}
 		fset := token.NewFileSet()
-		pkgs, err := parser.ParseDir(fset, resPkg.Dir, FileFilter, parser.AllErrors)
+		pkgs, err := parser.ParseDir(fset, resPkg.Dir, p.BuildFileFilter(resPkg.Dir), parser.AllErrors)
 		if err != nil {
 			return nil, err
 		}
 		if len(pkgs) != 1 {
-			log.Warningf("need test case for multi pkgs in unresolvable import")
+			p.logger().Warningf("need test case for multi pkgs in unresolvable import")
 		}
 		for n := range pkgs {
 			if n == identifier {
@@ -115,6 +157,15 @@ func TryToFindIdentifier(identifier string, file *ast.File) (*ast.ImportSpec, er
 	return nil, ErrUnableToFindIdentifier
 }
 
+// isTypeSource checks if identifiers of an import can be resolved using its package name,
+// which is not the case for blank and dot imports
+func isTypeSource(i *ast.ImportSpec) bool {
+	if i.Name == nil {
+		return true
+	}
+	return i.Name.Name != "_" && i.Name.Name != "."
+}
+
 // ImportPathToFilePath converts an import path to file path
 func ImportPathToFilePath(i *ast.ImportSpec) (string, error) {
 	importPath := strings.ReplaceAll(i.Path.Value, `"`, "")
@@ -158,7 +209,7 @@ func (p *PackageInfo) checkPointer(pointer *PkgResolverPointer) error {
 // FileForPointer retrieve ast file for pointer
 func (p *PackageInfo) FileForPointer(pointer *PkgResolverPointer) *ast.File {
 	if err := p.checkPointer(pointer); err != nil {
-		log.Warningln(err)
+		p.logger().Warningln(err)
 		return nil
 	}
 	return p.PkgInfo[pointer.Dir][pointer.Pkg].Files[pointer.File]
@@ -167,7 +218,7 @@ func (p *PackageInfo) FileForPointer(pointer *PkgResolverPointer) *ast.File {
 // PkgForPointer retrieve ast package for pointer
 func (p *PackageInfo) PkgForPointer(pointer *PkgResolverPointer) *ast.Package {
 	if err := p.checkPointer(pointer); err != nil {
-		log.Warningln(err)
+		p.logger().Warningln(err)
 		return nil
 	}
 	return p.PkgInfo[pointer.Dir][pointer.Pkg]
@@ -180,9 +231,9 @@ func (p *PackageInfo) PkgsForDir(dir string) map[string]*ast.Package {
 		return pkgs
 	}
 	fset := token.NewFileSet()
-	pkgs, err := parser.ParseDir(fset, dir, FileFilter, parser.AllErrors)
+	pkgs, err := parser.ParseDir(fset, dir, p.BuildFileFilter(dir), parser.AllErrors)
 	if err != nil {
-		log.WithError(err).Errorf("unable to convert importSpec to file path: %s", dir)
+		p.logger().WithError(err).Errorf("unable to convert importSpec to file path: %s", dir)
 		return nil
 	}
 	p.PkgInfo[dir] = pkgs
@@ -199,27 +250,72 @@ func (p *PackageInfo) GetRootPkg() map[string]*ast.File {
 	return nil
 }
 
+// RootTestFiles retrieves the test files of the root directory which belong to the root package,
+// e.g. containing mocks. The files are parsed when first requested
+func (p *PackageInfo) RootTestFiles() map[string]*ast.File {
+	if p.rootTestFiles != nil {
+		return p.rootTestFiles
+	}
+	p.rootTestFiles = make(map[string]*ast.File)
+	testFileFilter := func(fileInfo os.FileInfo) bool {
+		if FileFilter(fileInfo) {
+			return false
+		}
+		match, err := build.Default.MatchFile(p.RootDir, fileInfo.Name())
+		return err == nil && match
+	}
+	pkgs, err := parser.ParseDir(token.NewFileSet(), p.RootDir, testFileFilter, 0)
+	if err != nil {
+		p.logger().WithError(err).Debugf("unable to parse test files of dir: %s", p.RootDir)
+		return p.rootTestFiles
+	}
+	// Test files of an external test package aren't accessible from the root package
+	if pkg, ok := pkgs[p.RootPkg]; ok {
+		p.rootTestFiles = pkg.Files
+	}
+	return p.rootTestFiles
+}
+
 // IsRoot check if pointer is in root
 // some decisions need to be based on this
 func (p *PackageInfo) IsRoot(pointer *PkgResolverPointer) bool {
 	return p.RootDir == pointer.Dir
 }
 
+// IsSelfQualified checks if selector refers to the package of the pointer itself,
+// e.g. foo.Bar used inside package foo without an import named foo. Imports are resolved first,
+// as the package name of an import may differ from its path e.g. package yaml imported as gopkg.in/yaml.v3
+func (p *PackageInfo) IsSelfQualified(pointer *PkgResolverPointer, selector string) bool {
+	if selector != pointer.Pkg {
+		return false
+	}
+	f := p.FileForPointer(pointer)
+	if f == nil {
+		return false
+	}
+	_, err := p.GetImportSpecForIdentifierAndFile(selector, f)
+	return err != nil
+}
+
 // FindImport finds a imported object on selector and identifier
 func (p *PackageInfo) FindImport(pointer *PkgResolverPointer, selector, identifier string) (bool, ast.Expr, *PkgResolverPointer) {
 	f := p.FileForPointer(pointer)
 	if f == nil {
-		log.Warningf("file not found for current pointer")
+		p.logger().Warningf("file not found for current pointer")
 		return false, nil, pointer
 	}
-	importSpec, err := GetImportSpecForIdentifierAndFile(selector, f)
+	importSpec, err := p.GetImportSpecForIdentifierAndFile(selector, f)
+	// Only if no import matches, the selector may refer to the package itself
+	if err != nil && selector == pointer.Pkg {
+		return p.FindInCurrent(pointer, identifier)
+	}
 	if err != nil {
-		log.WithError(err).Errorf("unable to get import spec for identifier and file: %s", selector)
+		p.logger().WithError(err).Errorf("unable to get import spec for identifier and file: %s", selector)
 		return false, nil, pointer
 	}
 	dir, err := ImportPathToFilePath(importSpec)
 	if err != nil {
-		log.WithError(err).Errorf("unable to convert importSpec to file path: %s", importSpec.Path.Value)
+		p.logger().WithError(err).Errorf("unable to convert importSpec to file path: %s", importSpec.Path.Value)
 		return false, nil, pointer
 	}
 	pkgs := p.PkgsForDir(dir)
@@ -237,10 +333,72 @@ func (p *PackageInfo) FindImport(pointer *PkgResolverPointer, selector, identifi
 		}
 	}
 
-	log.Warningf("was not able to find identifier in import. selector: %s, identifier: %s, pointer; %v", selector, identifier, pointer)
+	p.logger().Warningf("was not able to find identifier in import. selector: %s, identifier: %s, pointer; %v", selector, identifier, pointer)
 	return false, nil, pointer
 }
 
+// ImportDir resolves the absolute directory of the package referred to by selector in the file of given pointer,
+// which is the directory of the pointer itself for self qualified selectors. Resolved import paths are cached,
+// as resolving an import path may invoke the go command
+func (p *PackageInfo) ImportDir(pointer *PkgResolverPointer, selector string) (string, bool) {
+	if p.IsSelfQualified(pointer, selector) {
+		dir, err := filepath.Abs(pointer.Dir)
+		return dir, err == nil
+	}
+	f := p.FileForPointer(pointer)
+	if f == nil {
+		return "", false
+	}
+	importSpec, err := p.GetImportSpecForIdentifierAndFile(selector, f)
+	if err != nil {
+		p.logger().WithError(err).Debugf("unable to get import spec for identifier and file: %s", selector)
+		return "", false
+	}
+	importPath := strings.Trim(importSpec.Path.Value, `"`)
+	if dir, ok := p.importDirs[importPath]; ok {
+		return dir, true
+	}
+	dir, err := ImportPathToFilePath(importSpec)
+	if err != nil {
+		p.logger().WithError(err).Debugf("unable to convert importSpec to file path: %s", importSpec.Path.Value)
+		return "", false
+	}
+	if p.importDirs == nil {
+		p.importDirs = make(map[string]string)
+	}
+	p.importDirs[importPath] = dir
+	return dir, true
+}
+
+// MethodsForType retrieves the method declarations of the type with given name
+// in the package of given pointer, sorted by method name
+func (p *PackageInfo) MethodsForType(pointer *PkgResolverPointer, typeName string) []*ast.FuncDecl {
+	pkg := p.PkgForPointer(pointer)
+	if pkg == nil {
+		return nil
+	}
+	res := []*ast.FuncDecl{}
+	for _, f := range pkg.Files {
+		for _, d := range f.Decls {
+			funcDecl, ok := d.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 {
+				continue
+			}
+			recvType := funcDecl.Recv.List[0].Type
+			if starExpr, ok := recvType.(*ast.StarExpr); ok {
+				recvType = starExpr.X
+			}
+			if ident, ok := recvType.(*ast.Ident); ok && ident.Name == typeName {
+				res = append(res, funcDecl)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name.Name < res[j].Name.Name
+	})
+	return res
+}
+
 // FindInCurrent tries to find an indentifier in current package
 func (p *PackageInfo) FindInCurrent(pointer *PkgResolverPointer, identifier string) (bool, ast.Expr, *PkgResolverPointer) {
 	pkg := p.PkgForPointer(pointer)
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -24,7 +25,7 @@ func (s *ImporterTestSuite) TestV2Import() {
 		Pkg:  pkg,
 		File: file,
 	}
-	res, err := ParseRoot(pointer.Dir)
+	res, err := ParseRoot(pointer.Dir, nil)
 	s.Require().NoError(err)
 
 	// Dir info needed in recursion
@@ -38,7 +39,7 @@ func (s *ImporterTestSuite) TestV2Import() {
 	simpleGoFile := rootPkg.Files[pointer.File]
 	s.NotNil(simpleGoFile)
 	s.Require().NoError(err)
-	s.resolveImport("resty", "/resty/v2", simpleGoFile)
+	s.resolveImport(res, "resty", "/resty/v2", simpleGoFile)
 }
 
 func (s *ImporterTestSuite) TestResolveImportsForPointer() {
@@ -52,7 +53,7 @@ func (s *ImporterTestSuite) TestResolveImportsForPointer() {
 		Pkg:  pkg,
 		File: file,
 	}
-	res, err := ParseRoot(pointer.Dir)
+	res, err := ParseRoot(pointer.Dir, nil)
 	s.Require().NoError(err)
 
 	// MUUCHO IMPORTANTE:
@@ -70,9 +71,9 @@ func (s *ImporterTestSuite) TestResolveImportsForPointer() {
 	// File info needed in recursion
 	simpleGoFile := rootPkg.Files[pointer.File]
 	s.NotNil(simpleGoFile)
-	s.resolveImport("fmt", "/fmt", simpleGoFile)
-	s.resolveImport("foo", "github.com/gofrs/uuid", simpleGoFile)
-	s.resolveImport("somepkg", "/somepkg", simpleGoFile)
+	s.resolveImport(res, "fmt", "/fmt", simpleGoFile)
+	s.resolveImport(res, "foo", "github.com/gofrs/uuid", simpleGoFile)
+	s.resolveImport(res, "somepkg", "/somepkg", simpleGoFile)
 }
 
 func (s *ImporterTestSuite) TestFindInCurrent() {
@@ -86,7 +87,7 @@ func (s *ImporterTestSuite) TestFindInCurrent() {
 		Pkg:  pkg,
 		File: file,
 	}
-	res, err := ParseRoot(pointer.Dir)
+	res, err := ParseRoot(pointer.Dir, nil)
 	s.Require().NoError(err)
 	s.True(res.IsRoot(pointer))
 	found, expr, newPointer := res.FindInCurrent(pointer, "StructWeAreLookingFor")
@@ -111,7 +112,7 @@ func (s *ImporterTestSuite) TestFindInImport() {
 		Pkg:  pkg,
 		File: file,
 	}
-	res, err := ParseRoot(pointer.Dir)
+	res, err := ParseRoot(pointer.Dir, nil)
 	s.Require().NoError(err)
 	s.True(res.IsRoot(pointer))
 
@@ -139,6 +140,54 @@ func (s *ImporterTestSuite) TestFindInImport() {
 	s.True(strings.HasSuffix(newPointer.File, "internal/importer/examples/example_simple/pkg/somepkg/somepkg_addon.go"))
 }
 
+func (s *ImporterTestSuite) TestFindImportNamedAsCurrent() {
+	pointer := &PkgResolverPointer{
+		Dir:  "examples/example_shadowed",
+		Pkg:  "resty",
+		File: "examples/example_shadowed/resty.go",
+	}
+	res, err := ParseRoot(pointer.Dir, nil)
+	s.Require().NoError(err)
+
+	// The imported package is named like the current package, so the import is resolved instead of the current package
+	s.False(res.IsSelfQualified(pointer, "resty"))
+	found, _, newPointer := res.FindImport(pointer, "resty", "Client")
+	s.True(found)
+	s.False(res.IsRoot(newPointer))
+	s.Contains(newPointer.Dir, "go-resty/resty/v2")
+}
+
+func (s *ImporterTestSuite) TestImportDir() {
+	pointer := &PkgResolverPointer{
+		Dir:  "examples/example_simple",
+		Pkg:  "simple",
+		File: "examples/example_simple/simple.go",
+	}
+	res, err := ParseRoot(pointer.Dir, nil)
+	s.Require().NoError(err)
+
+	dir, ok := res.ImportDir(pointer, "somepkg")
+	s.True(ok)
+	s.True(strings.HasSuffix(dir, "internal/importer/examples/example_simple/pkg/somepkg"))
+	// The package itself is resolved to its absolute directory
+	dir, ok = res.ImportDir(pointer, "simple")
+	s.True(ok)
+	s.True(strings.HasSuffix(dir, "internal/importer/examples/example_simple"))
+	_, ok = res.ImportDir(pointer, "unknown")
+	s.False(ok)
+}
+
+func (s *ImporterTestSuite) TestInjectedLogger() {
+	logger := log.New()
+	res, err := ParseRoot("examples/example_simple", logger)
+	s.Require().NoError(err)
+	s.Equal(logger, res.logger())
+	// Without a logger the global logger is used
+	res, err = ParseRoot("examples/example_simple", nil)
+	s.Require().NoError(err)
+	s.Equal(log.StandardLogger(), res.logger())
+}
+
 func (s *ImporterTestSuite) TestOtherExample() {
 	dir := "examples/example_other"
 	// Package info needed in recursion
@@ -150,7 +199,7 @@ func (s *ImporterTestSuite) TestOtherExample() {
 		Pkg:  pkg,
 		File: file,
 	}
-	res, err := ParseRoot(pointer.Dir)
+	res, err := ParseRoot(pointer.Dir, nil)
 	s.Require().NoError(err)
 	s.True(res.IsRoot(pointer))
 
@@ -168,8 +217,32 @@ func (s *ImporterTestSuite) TestOtherExample() {
 	s.Contains(newPointer.File, "/time/time.go")
 }
 
-func (s *ImporterTestSuite) resolveImport(identifier, expectedPath string, file *ast.File) {
-	importSpec, err := GetImportSpecForIdentifierAndFile(identifier, file)
+func (s *ImporterTestSuite) TestBlankImport() {
+	dir := "examples/example_blank"
+	pkg := "blank"
+	file := "examples/example_blank/blank.go"
+	pointer := &PkgResolverPointer{
+		Dir:  dir,
+		Pkg:  pkg,
+		File: file,
+	}
+	res, err := ParseRoot(pointer.Dir, nil)
+	s.Require().NoError(err)
+	blankGoFile := res.FileForPointer(pointer)
+	s.Require().NotNil(blankGoFile)
+	// The blank imported package shares the identifier, but should not be used for resolving
+	s.resolveImport(res, "types", "example_blank/pkg/v1", blankGoFile)
+
+	found, expr, newPointer := res.FindImport(pointer, "types", "SomeType")
+	s.Require().True(found)
+	x, ok := expr.(*ast.Ident)
+	s.Require().True(ok)
+	s.Equal("SomeType", x.Name)
+	s.Contains(newPointer.Dir, "example_blank/pkg/v1")
+}
+
+func (s *ImporterTestSuite) resolveImport(res *PackageInfo, identifier, expectedPath string, file *ast.File) {
+	importSpec, err := res.GetImportSpecForIdentifierAndFile(identifier, file)
 	s.Require().NoError(err)
 	path, err := ImportPathToFilePath(importSpec)
 	s.NoError(err)
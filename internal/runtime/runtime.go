@@ -1,51 +1,141 @@
 // Package runtime analyses runtime output and converts it into assert statements
 package runtime
 
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
 // Info information about values on runtime
 type Info struct {
-	Panics      bool
+	Panics bool
+	// PanicMessage value the function panicked with at runtime
+	PanicMessage string
+	// PanicFrames frames of the stack captured when the function panicked at runtime,
+	// from the panicking function up to the test function
+	PanicFrames []string
 	AssertStmts []Stmt
 	SecondRun   []Stmt
-	Printer     StmtPrinter
+	// ExtraRuns assert statements of additional capture passes, if present the statements
+	// agreed upon by a majority of all runs are used instead of requiring the first two runs to be equal
+	ExtraRuns [][]Stmt
+	// Expectations assert statements forced by directives, these replace
+	// the error assertions derived from runtime output for the same value
+	Expectations []Stmt
+	// ExpectationsOnly only asserts the expectations, omitting the statements derived from runtime output,
+	// e.g. when the results are compared against an oracle
+	ExpectationsOnly bool
+	// Comparers comparison expression templates used for asserting values per type name
+	Comparers map[string]string
+	Printer   StmtPrinter
+	// Logger logger used for diagnostics while parsing runtime output, the global logrus logger is used if nil
+	Logger log.FieldLogger
 }
 
 // NewInfo creates new runtime info for given printer
 func NewInfo(printer StmtPrinter) *Info {
 	return &Info{
-		Printer: printer,
+		Comparers: make(map[string]string),
+		Printer:   printer,
 	}
 }
 
 // GetAssertStmts retrieve the assert statements
 func (info *Info) GetAssertStmts() []string {
 	res := []string{}
-	for _, stmt := range info.AssertStmts {
+	for _, stmt := range info.Stmts() {
 		res = append(res, info.Printer.PrintStmt(stmt))
 	}
 	return res
 }
 
+// Stmts retrieves the statements which are asserted, being the statements derived from runtime output
+// followed by the expectations
+func (info *Info) Stmts() []Stmt {
+	res := []Stmt{}
+	assertStmts := info.AssertStmts
+	if majority, ok := info.majorityRun(); ok {
+		assertStmts = majority
+	}
+	for _, stmt := range assertStmts {
+		if info.ExpectationsOnly || info.isOverridden(stmt) {
+			continue
+		}
+		res = append(res, stmt)
+	}
+	return append(res, info.Expectations...)
+}
+
+// isOverridden checks if an error assertion is replaced by an expectation for the same value
+func (info *Info) isOverridden(stmt Stmt) bool {
+	assertStmt, ok := stmt.(*AssertStmt)
+	if !ok {
+		return false
+	}
+	if assertStmt.AssertStmtType != AssertStmtTypeError && assertStmt.AssertStmtType != AssertStmtTypeNoError {
+		return false
+	}
+	for _, expectation := range info.Expectations {
+		expectStmt, ok := expectation.(*AssertStmt)
+		if ok && expectStmt.Expected == assertStmt.Expected {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValid verifies that created runtime info is valid
 // used when generating end result
 func (info *Info) IsValid() bool {
-	if len(info.AssertStmts) != len(info.SecondRun) {
+	if len(info.ExtraRuns) == 0 {
+		return stmtsEqual(info.AssertStmts, info.SecondRun)
+	}
+	_, ok := info.majorityRun()
+	return ok
+}
+
+// majorityRun retrieves the statements agreed upon by a strict majority of all capture runs,
+// false is returned if there are no extra runs or no majority exists
+func (info *Info) majorityRun() ([]Stmt, bool) {
+	if len(info.ExtraRuns) == 0 {
+		return nil, false
+	}
+	runs := append([][]Stmt{info.AssertStmts, info.SecondRun}, info.ExtraRuns...)
+	for i, run := range runs {
+		agreeing := 0
+		for _, other := range runs {
+			if stmtsEqual(run, other) {
+				agreeing++
+			}
+		}
+		if agreeing*2 > len(runs) {
+			return runs[i], true
+		}
+	}
+	return nil, false
+}
+
+// stmtsEqual checks if the statements of two runs are equal
+func stmtsEqual(run, other []Stmt) bool {
+	if len(run) != len(other) {
 		return false
 	}
 
-	for i := 0; i < len(info.AssertStmts); i++ {
-		if info.AssertStmts[i].Type() != info.SecondRun[i].Type() {
+	for i := 0; i < len(run); i++ {
+		if run[i].Type() != other[i].Type() {
 			return false
 		}
-		assertStmt, ok := info.AssertStmts[i].(*AssertStmt)
-		assertStmt2, ok2 := info.SecondRun[i].(*AssertStmt)
+		assertStmt, ok := run[i].(*AssertStmt)
+		assertStmt2, ok2 := other[i].(*AssertStmt)
 		if ok && ok2 {
 			if *assertStmt != *assertStmt2 {
 				return false
 			}
 			continue
 		}
-		assignStmt, ok := info.AssertStmts[i].(*AssertStmt)
-		assignStmt2, ok2 := info.SecondRun[i].(*AssertStmt)
+		assignStmt, ok := run[i].(*AssignStmt)
+		assignStmt2, ok2 := other[i].(*AssignStmt)
 		if ok && ok2 {
 			if *assignStmt != *assignStmt2 {
 				return false
@@ -59,9 +149,11 @@ func (info *Info) IsValid() bool {
 // AssertStmtsForTestCase creates assert statements for a testcase
 func (info *Info) AssertStmtsForTestCase(printed string, firstRun bool, funcName string, index int) {
 	outputParser := NewOutputParser()
+	outputParser.Comparers = info.Comparers
+	outputParser.Logger = info.Logger
 	stmts, panics := outputParser.Parse(printed, funcName, index)
 	if panics {
-		info.Panics = true
+		info.recordPanic(outputParser, printed, funcName, index)
 		return
 	}
 	if firstRun {
@@ -70,3 +162,26 @@ func (info *Info) AssertStmtsForTestCase(printed string, firstRun bool, funcName
 		info.SecondRun = append(info.SecondRun, stmts...)
 	}
 }
+
+// AddCaptureRun adds the assert statements of an additional capture pass for a testcase
+func (info *Info) AddCaptureRun(printed, funcName string, index int) {
+	outputParser := NewOutputParser()
+	outputParser.Comparers = info.Comparers
+	outputParser.Logger = info.Logger
+	stmts, panics := outputParser.Parse(printed, funcName, index)
+	if panics {
+		info.recordPanic(outputParser, printed, funcName, index)
+		return
+	}
+	info.ExtraRuns = append(info.ExtraRuns, stmts)
+}
+
+// recordPanic marks the test case as panicking, keeping the panic value and stack of the first observed panic
+func (info *Info) recordPanic(outputParser *OutputParser, printed, funcName string, index int) {
+	if !info.Panics {
+		message, stack, _ := outputParser.ParsePanic(printed, funcName, index)
+		info.PanicMessage = message
+		info.PanicFrames = PanicFrames(stack, fmt.Sprintf("Test%s%d", funcName, index))
+	}
+	info.Panics = true
+}
@@ -0,0 +1,15 @@
+package runtime
+
+import (
+	"fmt"
+)
+
+// AllocsAssertStmt creates the assert statement verifying the average amount of allocations of a call,
+// measured using testing.AllocsPerRun, doesn't exceed the amount captured at runtime
+func AllocsAssertStmt(runtimeOutput *Output) *AssertStmt {
+	return &AssertStmt{
+		AssertStmtType: AssertStmtTypeLessOrEqual,
+		Expected:       fmt.Sprintf("float64(%s)", runtimeOutput.Val),
+		Value:          runtimeOutput.VarName,
+	}
+}
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -56,11 +57,22 @@ type AssertStmtType string
 // Used assert statement types
 const (
 	AssertStmtTypeEqualValues AssertStmtType = "EqualValues"
+	AssertStmtTypeEqual       AssertStmtType = "Equal"
 	AssertStmtTypeNil         AssertStmtType = "Nil"
 	AssertStmtTypeNoError     AssertStmtType = "NoError"
 	AssertStmtTypeError       AssertStmtType = "Error"
 	AssertStmtTypeFalse       AssertStmtType = "False"
 	AssertStmtTypeTrue        AssertStmtType = "True"
+	// AssertStmtTypeEmpty asserts the value is empty, e.g. the diff of a go-cmp comparison
+	AssertStmtTypeEmpty AssertStmtType = "Empty"
+	// AssertStmtTypeElementsMatch asserts the value contains the expected elements, ignoring their order
+	AssertStmtTypeElementsMatch AssertStmtType = "ElementsMatch"
+	// AssertStmtTypeLessOrEqual asserts the value doesn't exceed the expected value, e.g. the allocations of a call
+	AssertStmtTypeLessOrEqual AssertStmtType = "LessOrEqual"
+	// AssertStmtTypeErrorAs asserts the chain of the value contains an error of the type of the expected target
+	AssertStmtTypeErrorAs AssertStmtType = "ErrorAs"
+	// AssertStmtTypeWithinDuration asserts that the value is within Delta of the expected time
+	AssertStmtTypeWithinDuration AssertStmtType = "WithinDuration"
 )
 
 // AssertStmt an assert statement
@@ -68,6 +80,8 @@ type AssertStmt struct {
 	AssertStmtType AssertStmtType
 	Expected       string
 	Value          string
+	// Delta maximum difference between expected and actual value, only used for WithinDuration
+	Delta string
 }
 
 // Type retrieves the type of assert stmt
@@ -88,6 +102,8 @@ type StmtPrinter interface {
 // TestifySuitePrinter printer for testify suites
 type TestifySuitePrinter struct {
 	Receiver string
+	// Logger logger used for diagnostics, the global logrus logger is used if nil
+	Logger log.FieldLogger
 }
 
 // NewTestifySuitePrinter new testify suite
@@ -105,7 +121,7 @@ func (t *TestifySuitePrinter) PrintStmt(stmt Stmt) string {
 	case *AssignStmt:
 		return t.PrintAssignStmt(tp)
 	default:
-		log.Warningf("unexpected stmt type")
+		t.logger().Warningf("unexpected stmt type")
 		return ""
 	}
 }
@@ -113,16 +129,24 @@ func (t *TestifySuitePrinter) PrintStmt(stmt Stmt) string {
 // PrintAssertStmt prints an assert statement for a testcase in a testify suite
 func (t *TestifySuitePrinter) PrintAssertStmt(astmt *AssertStmt) string {
 	switch astmt.AssertStmtType {
-	case AssertStmtTypeEqualValues:
+	case AssertStmtTypeEqualValues,
+		AssertStmtTypeEqual,
+		AssertStmtTypeElementsMatch:
 		return fmt.Sprintf("%s.%s(%s,%s)", t.Receiver, astmt.AssertStmtType, astmt.Expected, astmt.Value)
 	case AssertStmtTypeNil,
 		AssertStmtTypeNoError,
 		AssertStmtTypeError,
 		AssertStmtTypeFalse,
-		AssertStmtTypeTrue:
+		AssertStmtTypeTrue,
+		AssertStmtTypeEmpty:
 		return fmt.Sprintf("%s.%s(%s)", t.Receiver, astmt.AssertStmtType, astmt.Expected)
+	case AssertStmtTypeLessOrEqual,
+		AssertStmtTypeErrorAs:
+		return fmt.Sprintf("%s.%s(%s,%s)", t.Receiver, astmt.AssertStmtType, astmt.Value, astmt.Expected)
+	case AssertStmtTypeWithinDuration:
+		return fmt.Sprintf("%s.%s(%s,%s,%s)", t.Receiver, astmt.AssertStmtType, astmt.Expected, astmt.Value, astmt.Delta)
 	default:
-		log.Warningf("unexpected assert stmt type")
+		t.logger().Warningf("unexpected assert stmt type")
 		return fmt.Sprintf("// FIXME: unknown assertion %s.%s(%s,%s)", t.Receiver, astmt.AssertStmtType, astmt.Expected, astmt.Value)
 	}
 }
@@ -134,11 +158,90 @@ func (t *TestifySuitePrinter) PrintAssignStmt(astmt *AssignStmt) string {
 		AssignStmtTypeDefine:
 		return fmt.Sprintf("%s %s %s", astmt.LeftHand, astmt.AssignStmtType, astmt.RightHand)
 	default:
-		log.Warningf("unexpected assert stmt type")
+		t.logger().Warningf("unexpected assert stmt type")
 		return fmt.Sprintf("// FIXME: unknown assign %s %s %s", astmt.LeftHand, astmt.AssignStmtType, astmt.RightHand)
 	}
 }
 
+// logger retrieves the logger used for diagnostics
+func (t *TestifySuitePrinter) logger() log.FieldLogger {
+	if t.Logger != nil {
+		return t.Logger
+	}
+	return log.StandardLogger()
+}
+
 func (t *TestifySuitePrinter) String() string {
 	return "testify suite printer"
 }
+
+// TestifyLogPrinter printer for testify suites which logs expected and actual values
+// instead of asserting them, so generated tests never fail on a mismatch
+type TestifyLogPrinter struct {
+	TestifySuitePrinter
+}
+
+// NewTestifyLogPrinter new testify suite log printer
+func NewTestifyLogPrinter(receiver string) StmtPrinter {
+	return &TestifyLogPrinter{
+		TestifySuitePrinter: TestifySuitePrinter{
+			Receiver: receiver,
+		},
+	}
+}
+
+// PrintStmt prints a statement
+func (t *TestifyLogPrinter) PrintStmt(stmt Stmt) string {
+	switch tp := stmt.(type) {
+	case *AssertStmt:
+		return t.PrintAssertStmt(tp)
+	case *AssignStmt:
+		return t.PrintAssignStmt(tp)
+	default:
+		t.logger().Warningf("unexpected stmt type")
+		return ""
+	}
+}
+
+// PrintAssertStmt prints an assert statement as a log statement for a testcase in a testify suite
+func (t *TestifyLogPrinter) PrintAssertStmt(astmt *AssertStmt) string {
+	switch astmt.AssertStmtType {
+	case AssertStmtTypeEqualValues,
+		AssertStmtTypeEqual:
+		return t.printLogf(astmt.Value, "%v", astmt.Expected, astmt.Value)
+	case AssertStmtTypeNil:
+		return t.printLogf(astmt.Expected, "nil", astmt.Expected)
+	case AssertStmtTypeNoError:
+		return t.printLogf(astmt.Expected, "no error", astmt.Expected)
+	case AssertStmtTypeError:
+		return t.printLogf(astmt.Expected, "error", astmt.Expected)
+	case AssertStmtTypeFalse:
+		return t.printLogf(astmt.Expected, "false", astmt.Expected)
+	case AssertStmtTypeTrue:
+		return t.printLogf(astmt.Expected, "true", astmt.Expected)
+	case AssertStmtTypeElementsMatch:
+		return t.printLogf(astmt.Value, "elements of %v in any order", astmt.Expected, astmt.Value)
+	case AssertStmtTypeLessOrEqual:
+		return t.printLogf(astmt.Value, "at most %v", astmt.Expected, astmt.Value)
+	case AssertStmtTypeErrorAs:
+		return t.printLogf(astmt.Value, "error chain containing %T", astmt.Expected, astmt.Value)
+	case AssertStmtTypeWithinDuration:
+		return t.printLogf(astmt.Value, "within "+astmt.Delta+" of %v", astmt.Expected, astmt.Value)
+	case AssertStmtTypeEmpty:
+		// The value is a go-cmp diff, which is logged instead of repeating the compared expression as subject
+		return t.printLogf("diff", "empty", astmt.Expected)
+	default:
+		t.logger().Warningf("unexpected assert stmt type")
+		return fmt.Sprintf("// FIXME: unknown assertion %s.%s(%s,%s)", t.Receiver, astmt.AssertStmtType, astmt.Expected, astmt.Value)
+	}
+}
+
+// printLogf prints a Logf call describing the expected and actual value of given subject
+func (t *TestifyLogPrinter) printLogf(subject, expected string, args ...string) string {
+	format := strings.ReplaceAll(subject, "%", "%%") + ": expected " + expected + ", actual %v"
+	return fmt.Sprintf("%s.T().Logf(%s, %s)", t.Receiver, strconv.Quote(format), strings.Join(args, ", "))
+}
+
+func (t *TestifyLogPrinter) String() string {
+	return "testify log printer"
+}
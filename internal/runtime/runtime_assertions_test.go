@@ -72,6 +72,24 @@ func (s *RunTimeAssertionsTestSuite) TestAssertTestifyPrinterAssertStmts() {
 				},
 				Output: `s.EqualValues(exp,val)`,
 			},
+			{
+				Name: "less or equal",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeLessOrEqual,
+					Value:          "allocs",
+					Expected:       "float64(2)",
+				},
+				Output: `s.LessOrEqual(allocs,float64(2))`,
+			},
+			{
+				Name: "error as",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeErrorAs,
+					Value:          "err",
+					Expected:       "new(*NotFoundError)",
+				},
+				Output: `s.ErrorAs(err,new(*NotFoundError))`,
+			},
 			{
 				Name: "unknown type",
 				Input: &AssertStmt{
@@ -147,6 +165,168 @@ func (s *RunTimeAssertionsTestSuite) TestAssertTestifyPrinterAssignStmts() {
 	})
 }
 
+func (s *RunTimeAssertionsTestSuite) TestAssertTestifyLogPrinterAssertStmts() {
+	printer := NewTestifyLogPrinter("s")
+	s.Run(printer.String(), func() {
+		tests := []struct {
+			Name   string
+			Input  Stmt
+			Output string
+		}{
+			{
+				Name: "Error assertion",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeError,
+					Expected:       "err",
+				},
+				Output: `s.T().Logf("err: expected error, actual %v", err)`,
+			},
+			{
+				Name: "No Error assertion",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeNoError,
+					Expected:       "err",
+				},
+				Output: `s.T().Logf("err: expected no error, actual %v", err)`,
+			},
+			{
+				Name: "bool true",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeTrue,
+					Expected:       "bool",
+				},
+				Output: `s.T().Logf("bool: expected true, actual %v", bool)`,
+			},
+			{
+				Name: "bool false",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeFalse,
+					Expected:       "bool",
+				},
+				Output: `s.T().Logf("bool: expected false, actual %v", bool)`,
+			},
+			{
+				Name: "nil",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeNil,
+					Expected:       "var",
+				},
+				Output: `s.T().Logf("var: expected nil, actual %v", var)`,
+			},
+			{
+				Name: "equal vals",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeEqualValues,
+					Value:          `m["50%"]`,
+					Expected:       `"exp"`,
+				},
+				Output: `s.T().Logf("m[\"50%%\"]: expected %v, actual %v", "exp", m["50%"])`,
+			},
+			{
+				Name: "cmp diff",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeEmpty,
+					Expected:       `cmp.Diff(Point{X: 1}, point, cmpopts.EquateEmpty())`,
+				},
+				Output: `s.T().Logf("diff: expected empty, actual %v", cmp.Diff(Point{X: 1}, point, cmpopts.EquateEmpty()))`,
+			},
+			{
+				Name: "assign",
+				Input: &AssignStmt{
+					AssignStmtType: AssignStmtTypeDefine,
+					LeftHand:       "x",
+					RightHand:      "y",
+				},
+				Output: `x := y`,
+			},
+		}
+
+		for _, testCase := range tests {
+			s.Run(testCase.Name, func() {
+				printed := printer.PrintStmt(testCase.Input)
+				s.Equal(testCase.Output, printed)
+			})
+		}
+	})
+}
+
+func (s *RunTimeAssertionsTestSuite) TestCustomAssertPrinterAssertStmts() {
+	printer := NewCustomAssertPrinter(&TestifySuitePrinter{Receiver: "s"}, map[AssertStmtType]string{
+		AssertStmtTypeEqualValues:    "myassert.Eq",
+		AssertStmtTypeNoError:        "myassert.Ok",
+		AssertStmtTypeWithinDuration: "myassert.Near",
+		AssertStmtTypeLessOrEqual:    "assert.LessOrEqual",
+	})
+	s.Run(printer.String(), func() {
+		tests := []struct {
+			Name   string
+			Input  Stmt
+			Output string
+		}{
+			{
+				Name: "mapped equal vals",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeEqualValues,
+					Value:          "out",
+					Expected:       "int(3)",
+				},
+				Output: "myassert.Eq(s.T(), int(3), out)",
+			},
+			{
+				Name: "mapped no error",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeNoError,
+					Expected:       "err",
+				},
+				Output: "myassert.Ok(s.T(), err)",
+			},
+			{
+				Name: "mapped within duration",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeWithinDuration,
+					Expected:       "expected",
+					Value:          "out",
+					Delta:          "time.Second",
+				},
+				Output: "myassert.Near(s.T(), expected, out, time.Second)",
+			},
+			{
+				Name: "mapped less or equal",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeLessOrEqual,
+					Value:          "allocs",
+					Expected:       "float64(2)",
+				},
+				Output: "assert.LessOrEqual(s.T(), allocs, float64(2))",
+			},
+			{
+				Name: "unmapped nil",
+				Input: &AssertStmt{
+					AssertStmtType: AssertStmtTypeNil,
+					Expected:       "var",
+				},
+				Output: "s.Nil(var)",
+			},
+			{
+				Name: "assign",
+				Input: &AssignStmt{
+					AssignStmtType: AssignStmtTypeDefine,
+					LeftHand:       "x",
+					RightHand:      "y",
+				},
+				Output: `x := y`,
+			},
+		}
+
+		for _, testCase := range tests {
+			s.Run(testCase.Name, func() {
+				printed := printer.PrintStmt(testCase.Input)
+				s.Equal(testCase.Output, printed)
+			})
+		}
+	})
+}
+
 func TestRunTimeAssertionsTestSuite(t *testing.T) {
 	suite.Run(t, new(RunTimeAssertionsTestSuite))
 }
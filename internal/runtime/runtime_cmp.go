@@ -0,0 +1,22 @@
+package runtime
+
+import "fmt"
+
+// CmpOptions options of the go-cmp comparisons, ignoring the order of slices and the difference between
+// nil and empty slices and maps. Unexported fields are compared, as the tests are part of the package
+const CmpOptions = "cmpopts.SortSlices(func(a, b interface{}) bool { return fmt.Sprint(a) < fmt.Sprint(b) }), " +
+	"cmpopts.EquateEmpty(), cmp.Exporter(func(reflect.Type) bool { return true })"
+
+// CmpAssertStmts creates an assert statement verifying go-cmp finds no difference between a composite value
+// and its Go syntax representation captured at runtime
+func (o *OutputParser) CmpAssertStmts(runtimeOutput *Output, resStmts []Stmt) []Stmt {
+	// NaN and infinite floats are printed without a literal representation
+	if nonFiniteFloat.MatchString(runtimeOutput.Val) {
+		o.logger().Warningf("unable to assert value with non-finite floats: %s", runtimeOutput.VarName)
+		return resStmts
+	}
+	return append(resStmts, &AssertStmt{
+		AssertStmtType: AssertStmtTypeEmpty,
+		Expected:       fmt.Sprintf("cmp.Diff(%s, %s, %s)", unqualify(runtimeOutput.Val, runtimeOutput.Pkg), runtimeOutput.VarName, CmpOptions),
+	})
+}
@@ -0,0 +1,54 @@
+package runtime
+
+import (
+	"bytes"
+	"regexp"
+	"text/template"
+)
+
+// DefaultComparer comparison expression template used for types with an Equal(other T) bool method
+const DefaultComparer = "{{.Actual}}.Equal({{.Expected}})"
+
+// ComparerInput input of a comparison expression template
+type ComparerInput struct {
+	Actual   string
+	Expected string
+}
+
+// ComparerAssertStmts creates an assert statement verifying the comparison expression
+// of the comparer registered for the type of the runtime output holds
+func (o *OutputParser) ComparerAssertStmts(runtimeOutput *Output, resStmts []Stmt) []Stmt {
+	comparer, ok := o.Comparers[runtimeOutput.TypeName]
+	if !ok {
+		o.logger().Warningf("no comparer registered for type: %s", runtimeOutput.TypeName)
+		return []Stmt{}
+	}
+	tmpl, err := template.New(runtimeOutput.TypeName).Parse(comparer)
+	if err != nil {
+		o.logger().WithError(err).Errorf("unable to parse comparer for type: %s", runtimeOutput.TypeName)
+		return []Stmt{}
+	}
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, ComparerInput{
+		Actual:   runtimeOutput.VarName,
+		Expected: unqualify(runtimeOutput.Val, runtimeOutput.Pkg),
+	})
+	if err != nil {
+		o.logger().WithError(err).Errorf("unable to execute comparer for type: %s", runtimeOutput.TypeName)
+		return []Stmt{}
+	}
+	return append(resStmts, &AssertStmt{
+		AssertStmtType: AssertStmtTypeTrue,
+		Expected:       buf.String(),
+	})
+}
+
+// unqualify removes the qualifier of the package under test from a Go syntax representation,
+// since the generated tests are part of the package itself
+func unqualify(goSyntax, pkg string) string {
+	if pkg == "" {
+		return goSyntax
+	}
+	re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(pkg) + `\.`)
+	return re.ReplaceAllString(goSyntax, "${1}")
+}
@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AssertStmtTypes all assert statement types, which can be mapped to a custom assertion function
+var AssertStmtTypes = []AssertStmtType{
+	AssertStmtTypeEqualValues,
+	AssertStmtTypeEqual,
+	AssertStmtTypeNil,
+	AssertStmtTypeNoError,
+	AssertStmtTypeError,
+	AssertStmtTypeFalse,
+	AssertStmtTypeTrue,
+	AssertStmtTypeEmpty,
+	AssertStmtTypeElementsMatch,
+	AssertStmtTypeLessOrEqual,
+	AssertStmtTypeErrorAs,
+	AssertStmtTypeWithinDuration,
+}
+
+// CustomAssertPrinter printer calling the functions of a custom assertion package,
+// e.g. myassert.Eq(s.T(), expected, actual), assertions which aren't mapped are printed by the fallback printer
+type CustomAssertPrinter struct {
+	// Fallback printer used for assign statements and assertions without a custom function
+	Fallback StmtPrinter
+	// TestingT expression retrieving the testing.T passed to the custom functions
+	TestingT string
+	// Funcs qualified custom function per assert statement type
+	Funcs map[AssertStmtType]string
+}
+
+// NewCustomAssertPrinter new custom assertion printer for testify suites, assertions without a custom function
+// are printed by given suite printer
+func NewCustomAssertPrinter(fallback *TestifySuitePrinter, funcs map[AssertStmtType]string) StmtPrinter {
+	return &CustomAssertPrinter{
+		Fallback: fallback,
+		TestingT: fallback.Receiver + ".T()",
+		Funcs:    funcs,
+	}
+}
+
+// PrintStmt prints a statement
+func (c *CustomAssertPrinter) PrintStmt(stmt Stmt) string {
+	assertStmt, ok := stmt.(*AssertStmt)
+	if !ok {
+		return c.Fallback.PrintStmt(stmt)
+	}
+	fun, ok := c.Funcs[assertStmt.AssertStmtType]
+	if !ok {
+		return c.Fallback.PrintStmt(stmt)
+	}
+	args := []string{c.TestingT, assertStmt.Expected}
+	switch assertStmt.AssertStmtType {
+	case AssertStmtTypeEqualValues,
+		AssertStmtTypeEqual,
+		AssertStmtTypeElementsMatch:
+		args = append(args, assertStmt.Value)
+	case AssertStmtTypeLessOrEqual,
+		AssertStmtTypeErrorAs:
+		// The value precedes the expected bound or target, e.g. assert.ErrorAs(t, err, new(*NotFoundError))
+		args = []string{c.TestingT, assertStmt.Value, assertStmt.Expected}
+	case AssertStmtTypeWithinDuration:
+		args = append(args, assertStmt.Value, assertStmt.Delta)
+	}
+	return fmt.Sprintf("%s(%s)", fun, strings.Join(args, ", "))
+}
+
+func (c *CustomAssertPrinter) String() string {
+	return "custom assertion printer"
+}
@@ -0,0 +1,15 @@
+package runtime
+
+import (
+	"fmt"
+)
+
+// ErrorAsAssertStmt creates the assert statement verifying the chain of a returned error contains
+// an error of the type found at runtime using errors.As
+func ErrorAsAssertStmt(runtimeOutput *Output) *AssertStmt {
+	return &AssertStmt{
+		AssertStmtType: AssertStmtTypeErrorAs,
+		Expected:       fmt.Sprintf("new(%s)", runtimeOutput.TypeName),
+		Value:          runtimeOutput.VarName,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	log "github.com/sirupsen/logrus"
@@ -16,12 +17,28 @@ type Output struct {
 	MapKeyType string  `json:"map_key_type"`
 	Val        string  `json:"val"`
 	ArrIdent   string  `json:"arr_ident"`
+	TypeName   string  `json:"type_name"`
+	Pkg        string  `json:"pkg"`
+	Zero       bool    `json:"zero"`
+	Now        bool    `json:"now"`
 	Child      *Output `json:"child"`
 }
 
 // OutputParser parses runtime output strings
 type OutputParser struct {
 	mem *[]string
+	// Comparers comparison expression templates used for asserting values per type name
+	Comparers map[string]string
+	// Logger logger used for diagnostics, the global logrus logger is used if nil
+	Logger log.FieldLogger
+}
+
+// logger retrieves the logger used for diagnostics
+func (o *OutputParser) logger() log.FieldLogger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return log.StandardLogger()
 }
 
 // NewOutputParser creates a new output paraser
@@ -31,12 +48,17 @@ func NewOutputParser() *OutputParser {
 	}
 }
 
+// funcOutput retrieves the printed runtime output of the test case with given index of given function
+func funcOutput(printed, funcName string, index int) string {
+	re := regexp.MustCompile(fmt.Sprintf(`%s\n((.*)\n)*%s`, StartName(funcName, index), EndName(funcName, index)))
+	return re.FindString(printed)
+}
+
 // Parse parses printed runtime output to statements
 func (o *OutputParser) Parse(printed, funcName string, index int) ([]Stmt, bool) {
 	result := []Stmt{}
 	// Regex output for current organism
-	re := regexp.MustCompile(fmt.Sprintf(`%s\n((.*)\n)*%s`, StartName(funcName, index), EndName(funcName, index)))
-	curFuncOutput := re.FindString(printed)
+	curFuncOutput := funcOutput(printed, funcName, index)
 	// Check if function paniced
 	if strings.Contains(curFuncOutput, fmt.Sprintf("Recovered in Test%s%d", funcName, index)) {
 		return result, true
@@ -53,12 +75,63 @@ func (o *OutputParser) Parse(printed, funcName string, index int) ([]Stmt, bool)
 	return result, false
 }
 
+// ParsePanic retrieves the panic value and the stack captured when the test case with given index
+// of given function panicked, false is returned if it didn't panic
+func (o *OutputParser) ParsePanic(printed, funcName string, index int) (string, string, bool) {
+	recoveredPrefix := fmt.Sprintf("Recovered in Test%s%d", funcName, index)
+	stackPrefix := fmt.Sprintf("Panic stack of Test%s%d ", funcName, index)
+	message, stack, panics := "", "", false
+	for _, line := range strings.Split(funcOutput(printed, funcName, index), "\n") {
+		switch {
+		case strings.HasPrefix(line, recoveredPrefix):
+			panics = true
+			message = strings.TrimSpace(strings.TrimPrefix(line, recoveredPrefix))
+		case strings.HasPrefix(line, stackPrefix):
+			unquoted, err := strconv.Unquote(strings.TrimPrefix(line, stackPrefix))
+			if err != nil {
+				o.logger().WithError(err).Warningf("unable to parse panic stack of Test%s%d", funcName, index)
+				continue
+			}
+			stack = unquoted
+		}
+	}
+	return message, stack, panics
+}
+
+// PanicFrames retrieves the frames of a panic stack from the panicking function up to the test function
+// with given name, omitting the frames of the recovery and of the test runner
+func PanicFrames(stack, testName string) []string {
+	if strings.TrimSpace(stack) == "" {
+		return []string{}
+	}
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	start, end := 0, len(lines)
+	for i, line := range lines {
+		if start == 0 && strings.HasPrefix(line, "panic(") {
+			// Skip the frame of the panic call including its file position
+			start = i + 2
+		}
+		if start != 0 && strings.Contains(line, "."+testName+"(") {
+			end = i + 2
+			break
+		}
+	}
+	if start >= len(lines) || end > len(lines) || start >= end {
+		return []string{}
+	}
+	res := []string{}
+	for _, line := range lines[start:end] {
+		res = append(res, strings.TrimSpace(line))
+	}
+	return res
+}
+
 // ParseLine parses a line of output
 func (o *OutputParser) ParseLine(jsonString string) []Stmt {
 	data := Output{}
 	err := json.Unmarshal([]byte(jsonString), &data)
 	if err != nil {
-		log.WithError(err).WithField("line", jsonString).Errorf("unable to parse runtime output")
+		o.logger().WithError(err).WithField("line", jsonString).Errorf("unable to parse runtime output")
 		return []Stmt{}
 	}
 	return o.AssertStmts(&data, []Replacement{}, TypeCorrections{}, []Stmt{})
@@ -111,7 +184,7 @@ func (o *OutputParser) AssertStmts(data *Output, replacements []Replacement, typ
 		if data.Val != "nil" {
 			// sanity check
 			if data.Child == nil {
-				log.Warningf("unable to create assert stmts, expected pointer to have child")
+				o.logger().Warningf("unable to create assert stmts, expected pointer to have child")
 				return []Stmt{}
 			}
 			pointerStmt := fmt.Sprintf("%s := *%s", data.Child.VarName, data.VarName)
@@ -197,6 +270,22 @@ func (o *OutputParser) CreateAssertStmts(runtimeOutput *Output, replacements []R
 			AssertStmtType: AssertStmtTypeNil,
 			Expected:       runtimeOutput.VarName,
 		})
+	case "comparer":
+		return o.ComparerAssertStmts(runtimeOutput, resStmts)
+	case "slice":
+		return o.SliceAssertStmts(runtimeOutput, resStmts)
+	case "unordered":
+		return o.UnorderedAssertStmts(runtimeOutput, resStmts)
+	case "cmp":
+		return o.CmpAssertStmts(runtimeOutput, resStmts)
+	case "time":
+		return append(resStmts, TimeAssertStmt(runtimeOutput))
+	case "stringer":
+		return append(resStmts, StringerAssertStmt(runtimeOutput))
+	case "allocs":
+		return append(resStmts, AllocsAssertStmt(runtimeOutput))
+	case "error_as":
+		return append(resStmts, ErrorAsAssertStmt(runtimeOutput))
 	case "error":
 		if runtimeOutput.Val == "nil" {
 			return append(resStmts, &AssertStmt{
@@ -209,7 +298,7 @@ func (o *OutputParser) CreateAssertStmts(runtimeOutput *Output, replacements []R
 			Expected:       runtimeOutput.VarName,
 		})
 	default:
-		log.Warningf("unknown type: %s, value: %s", runtimeOutput.Type, runtimeOutput.Val)
+		o.logger().Warningf("unknown type: %s, value: %s", runtimeOutput.Type, runtimeOutput.Val)
 		return []Stmt{}
 	}
 }
@@ -76,6 +76,31 @@ func (s *RunTimeOutputParserTestSuite) TestExampleOutputs() {
 			Input:  `{ "type": "complex128", "var_name": "out6", "val": "(234.33333333333334+0i)"}`,
 			Output: []string{"s.EqualValues(complex128(234.33333333333334+0i),out6)"},
 		},
+		{
+			Name:   "time",
+			Input:  `{ "type": "struct", "var_name": "out", "child": { "type": "time", "var_name": "out.CreatedAt", "val": "1600000000.000000250", "zero": false, "now": false}}`,
+			Output: []string{"s.True(time.Unix(1600000000, 250).Equal(out.CreatedAt))"},
+		},
+		{
+			Name:   "time before 1678",
+			Input:  `{ "type": "time", "var_name": "out", "val": "-11676096000.000000000", "zero": false, "now": false}`,
+			Output: []string{"s.True(time.Unix(-11676096000, 0).Equal(out))"},
+		},
+		{
+			Name:   "zero time",
+			Input:  `{ "type": "time", "var_name": "out", "val": "-62135596800.000000000", "zero": true, "now": false}`,
+			Output: []string{"s.True(out.IsZero())"},
+		},
+		{
+			Name:   "time now",
+			Input:  `{ "type": "struct", "var_name": "out", "child": { "type": "time", "var_name": "out.CreatedAt", "val": "1600000000.000000000", "zero": false, "now": true}}`,
+			Output: []string{"s.WithinDuration(time.Now(),out.CreatedAt,time.Second)"},
+		},
+		{
+			Name:   "stringer",
+			Input:  `{ "type": "pointer", "var_name": "out", "child": { "type": "stringer", "var_name": "pointerOut", "val": "Point(1, \"a\")"}}`,
+			Output: []string{"pointerOut := *out", "s.Equal(\"Point(1, \\\"a\\\")\",pointerOut.String())"},
+		},
 	}
 
 	for _, testCase := range tests {
@@ -90,6 +115,17 @@ func (s *RunTimeOutputParserTestSuite) TestExampleOutputs() {
 	}
 }
 
+func (s *RunTimeOutputParserTestSuite) TestSuccessiveCalls() {
+	// Successive calls of a returned func are asserted in the order of invocation
+	printed := "<START;Counter0>\n" +
+		`{ "type": "int", "var_name": "out()", "val": "1"}` + "\n" +
+		`{ "type": "int", "var_name": "out()", "val": "2"}` + "\n" +
+		"<END;Counter0>"
+	info := NewInfo(NewTestifySuitePrinter("s"))
+	info.AssertStmtsForTestCase(printed, true, "Counter", 0)
+	s.Equal([]string{"s.EqualValues(int(1),out())", "s.EqualValues(int(2),out())"}, info.GetAssertStmts())
+}
+
 func TestRuntTimeTestSuite(t *testing.T) {
 	suite.Run(t, new(RunTimeOutputParserTestSuite))
 }
@@ -0,0 +1,38 @@
+package runtime
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nonFiniteFloat matches the Go syntax of floats which have no literal representation
+var nonFiniteFloat = regexp.MustCompile(`NaN|[+-]Inf`)
+
+// SliceAssertStmts creates an assert statement verifying a slice of comparable elements as a whole,
+// using its Go syntax representation as expected value
+func (o *OutputParser) SliceAssertStmts(runtimeOutput *Output, resStmts []Stmt) []Stmt {
+	// NaN and infinite floats are printed without a literal representation, e.g. []float64{NaN}
+	if strings.HasPrefix(runtimeOutput.TypeName, "float") && nonFiniteFloat.MatchString(runtimeOutput.Val) {
+		o.logger().Warningf("unable to assert slice with non-finite floats: %s", runtimeOutput.VarName)
+		return resStmts
+	}
+	return append(resStmts, &AssertStmt{
+		AssertStmtType: AssertStmtTypeEqual,
+		Expected:       unqualify(runtimeOutput.Val, runtimeOutput.Pkg),
+		Value:          runtimeOutput.VarName,
+	})
+}
+
+// UnorderedAssertStmts creates an assert statement verifying a slice contains the elements of the slice printed
+// at runtime in any order. The slice is sorted before printing, so the captured output doesn't depend on its order
+func (o *OutputParser) UnorderedAssertStmts(runtimeOutput *Output, resStmts []Stmt) []Stmt {
+	if strings.HasPrefix(runtimeOutput.TypeName, "float") && nonFiniteFloat.MatchString(runtimeOutput.Val) {
+		o.logger().Warningf("unable to assert slice with non-finite floats: %s", runtimeOutput.VarName)
+		return resStmts
+	}
+	return append(resStmts, &AssertStmt{
+		AssertStmtType: AssertStmtTypeElementsMatch,
+		Expected:       unqualify(runtimeOutput.Val, runtimeOutput.Pkg),
+		Value:          runtimeOutput.VarName,
+	})
+}
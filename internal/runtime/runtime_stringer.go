@@ -0,0 +1,16 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// StringerAssertStmt creates the assert statement for a value implementing fmt.Stringer,
+// comparing the result of its String method with the string captured at runtime
+func StringerAssertStmt(runtimeOutput *Output) *AssertStmt {
+	return &AssertStmt{
+		AssertStmtType: AssertStmtTypeEqual,
+		Expected:       strconv.Quote(runtimeOutput.Val),
+		Value:          fmt.Sprintf("%s.String()", runtimeOutput.VarName),
+	}
+}
@@ -1,8 +1,12 @@
 package runtime
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -24,6 +28,102 @@ func (s *RunTimeTestSuite) TestAssertStmtsForTestCase() {
 	s.Equal(4, len(info.SecondRun))
 }
 
+func (s *RunTimeTestSuite) TestGetAssertStmtsWithExpectations() {
+	info := &Info{
+		Printer: NewTestifySuitePrinter("s"),
+		AssertStmts: []Stmt{
+			&AssertStmt{AssertStmtType: AssertStmtTypeEqualValues, Expected: "0", Value: "res"},
+			&AssertStmt{AssertStmtType: AssertStmtTypeNoError, Expected: "err"},
+		},
+		Expectations: []Stmt{
+			&AssertStmt{AssertStmtType: AssertStmtTypeError, Expected: "err"},
+		},
+	}
+	s.Equal([]string{"s.EqualValues(0,res)", "s.Error(err)"}, info.GetAssertStmts())
+}
+
+func (s *RunTimeTestSuite) TestComparerAssertStmts() {
+	tests := []struct {
+		Name     string
+		Line     string
+		Expected []Stmt
+	}{
+		{
+			Name: "equal method",
+			Line: `{ "type": "comparer", "var_name": "out", "type_name": "Point", "pkg": "geo", "val": "geo.Point{X:1, Y:2}"}`,
+			Expected: []Stmt{
+				&AssertStmt{AssertStmtType: AssertStmtTypeTrue, Expected: "out.Equal(Point{X:1, Y:2})"},
+			},
+		},
+		{
+			Name: "other packages keep their qualifier",
+			Line: `{ "type": "comparer", "var_name": "out", "type_name": "Point", "pkg": "geo", "val": "geo.Point{Other:subgeo.Val{Name:\"x\"}}"}`,
+			Expected: []Stmt{
+				&AssertStmt{AssertStmtType: AssertStmtTypeTrue, Expected: `out.Equal(Point{Other:subgeo.Val{Name:"x"}})`},
+			},
+		},
+		{
+			Name:     "unregistered type",
+			Line:     `{ "type": "comparer", "var_name": "out", "type_name": "Line", "pkg": "geo", "val": "geo.Line{}"}`,
+			Expected: []Stmt{},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			parser := NewOutputParser()
+			parser.Comparers = map[string]string{"Point": DefaultComparer}
+			s.Equal(test.Expected, parser.ParseLine(test.Line))
+		})
+	}
+}
+
+func (s *RunTimeTestSuite) TestSliceAssertStmts() {
+	tests := []struct {
+		Name     string
+		Line     string
+		Expected []Stmt
+	}{
+		{
+			Name: "basic elements",
+			Line: `{ "type": "slice", "var_name": "out", "type_name": "int", "pkg": "geo", "val": "[]int{1, -2, 3}"}`,
+			Expected: []Stmt{
+				&AssertStmt{AssertStmtType: AssertStmtTypeEqual, Expected: "[]int{1, -2, 3}", Value: "out"},
+			},
+		},
+		{
+			Name: "nil slice",
+			Line: `{ "type": "slice", "var_name": "out", "type_name": "string", "pkg": "geo", "val": "[]string(nil)"}`,
+			Expected: []Stmt{
+				&AssertStmt{AssertStmtType: AssertStmtTypeEqual, Expected: "[]string(nil)", Value: "out"},
+			},
+		},
+		{
+			Name: "named slice of the package under test",
+			Line: `{ "type": "slice", "var_name": "out", "type_name": "string", "pkg": "geo", "val": "geo.Names{\"NaN\"}"}`,
+			Expected: []Stmt{
+				&AssertStmt{AssertStmtType: AssertStmtTypeEqual, Expected: `Names{"NaN"}`, Value: "out"},
+			},
+		},
+		{
+			Name:     "non-finite floats",
+			Line:     `{ "type": "slice", "var_name": "out", "type_name": "float64", "pkg": "geo", "val": "[]float64{1.5, NaN}"}`,
+			Expected: []Stmt{},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			s.Equal(test.Expected, NewOutputParser().ParseLine(test.Line))
+		})
+	}
+}
+
+func (s *RunTimeTestSuite) TestUnorderedAssertStmts() {
+	s.Equal([]Stmt{
+		&AssertStmt{AssertStmtType: AssertStmtTypeElementsMatch, Expected: `[]string{"a", "b"}`, Value: "out"},
+	}, NewOutputParser().ParseLine(`{ "type": "unordered", "var_name": "out", "type_name": "string", "pkg": "geo", "val": "[]string{\"a\", \"b\"}"}`))
+	s.Empty(NewOutputParser().ParseLine(`{ "type": "unordered", "var_name": "out", "type_name": "float64", "pkg": "geo", "val": "[]float64{NaN}"}`))
+}
+
 func (s *RunTimeTestSuite) TestAssertStmtsForPanicTestCase() {
 	info := &Info{
 		Printer: NewTestifySuitePrinter("s"),
@@ -32,6 +132,48 @@ func (s *RunTimeTestSuite) TestAssertStmtsForPanicTestCase() {
 	s.True(info.Panics)
 }
 
+func (s *RunTimeTestSuite) TestPanicReport() {
+	stack := `goroutine 7 [running]:
+runtime/debug.Stack()
+	/usr/local/go/src/runtime/debug/stack.go:24 +0x5e
+example/ranges.(*PercentageSuite).TestPercentage0.func1()
+	/tmp/ranges/percentage_test.go:15 +0xa5
+panic({0x5f1a40, 0xc000012345})
+	/usr/local/go/src/runtime/panic.go:770 +0x132
+example/ranges.Percentage(0x1, 0x65)
+	/tmp/ranges/percentage.go:9 +0xc5
+example/ranges.(*PercentageSuite).TestPercentage0(0xc0000b6000)
+	/tmp/ranges/percentage_test.go:20 +0x45
+reflect.Value.call({0x64f260, 0xc0000a2380, 0x13})
+	/usr/local/go/src/reflect/value.go:596 +0xce5
+`
+	printed := fmt.Sprintf(`<START;Percentage0>
+Recovered in TestPercentage0 invalid percentage: 101
+Panic stack of TestPercentage0 %q
+<END;Percentage0>
+`, stack)
+	info := NewInfo(NewTestifySuitePrinter("s"))
+	info.AssertStmtsForTestCase(printed, true, "Percentage", 0)
+	s.True(info.Panics)
+	s.Equal("invalid percentage: 101", info.PanicMessage)
+	s.Equal([]string{
+		"example/ranges.Percentage(0x1, 0x65)",
+		"/tmp/ranges/percentage.go:9 +0xc5",
+		"example/ranges.(*PercentageSuite).TestPercentage0(0xc0000b6000)",
+		"/tmp/ranges/percentage_test.go:20 +0x45",
+	}, info.PanicFrames)
+
+	// The panic of the first run is kept
+	info.AddCaptureRun(strings.ReplaceAll(printed, "101", "102"), "Percentage", 0)
+	s.Equal("invalid percentage: 101", info.PanicMessage)
+
+	// Without a captured stack only the message is known
+	info = NewInfo(NewTestifySuitePrinter("s"))
+	info.AssertStmtsForTestCase(panicOutput, true, "DoubleArray", 0)
+	s.Equal("", info.PanicMessage)
+	s.Empty(info.PanicFrames)
+}
+
 func (s *RunTimeTestSuite) TestIsValid() {
 	tests := []struct {
 		Name     string
@@ -62,6 +204,14 @@ func (s *RunTimeTestSuite) TestIsValid() {
 			},
 			Expected: false,
 		},
+		{
+			Name: "not equal assign val",
+			Input: &Info{
+				AssertStmts: []Stmt{&AssignStmt{LeftHand: "x", RightHand: "not equal val"}},
+				SecondRun:   []Stmt{&AssignStmt{LeftHand: "x", RightHand: "other val"}},
+			},
+			Expected: false,
+		},
 		{
 			Name: "equal",
 			Input: &Info{
@@ -80,6 +230,67 @@ func (s *RunTimeTestSuite) TestIsValid() {
 	}
 }
 
+func (s *RunTimeTestSuite) TestMajorityCaptureRuns() {
+	tests := []struct {
+		Name          string
+		Runs          []string
+		Valid         bool
+		ExpectedStmts []string
+	}{
+		{
+			Name:          "first run disagrees",
+			Runs:          []string{"1", "2", "2"},
+			Valid:         true,
+			ExpectedStmts: []string{"s.EqualValues(int(2),out)"},
+		},
+		{
+			Name:          "second run disagrees",
+			Runs:          []string{"1", "2", "1"},
+			Valid:         true,
+			ExpectedStmts: []string{"s.EqualValues(int(1),out)"},
+		},
+		{
+			Name:  "no majority",
+			Runs:  []string{"1", "2", "3", "3"},
+			Valid: false,
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			info := NewInfo(NewTestifySuitePrinter("s"))
+			for i, val := range test.Runs {
+				printed := fmt.Sprintf("<START;Count0>\n{ \"type\": \"int\", \"var_name\": \"out\", \"val\": \"%s\"}\n<END;Count0>\n", val)
+				if i < 2 {
+					info.AssertStmtsForTestCase(printed, i == 0, "Count", 0)
+					continue
+				}
+				info.AddCaptureRun(printed, "Count", 0)
+			}
+			s.Equal(test.Valid, info.IsValid())
+			if test.Valid {
+				s.Equal(test.ExpectedStmts, info.GetAssertStmts())
+			}
+		})
+	}
+}
+
+func (s *RunTimeTestSuite) TestInjectedLogger() {
+	logger, hook := logtest.NewNullLogger()
+	globalHook := logtest.NewGlobal()
+	defer globalHook.Reset()
+
+	info := NewInfo(NewTestifySuitePrinter("s"))
+	info.Logger = logger
+	info.AssertStmtsForTestCase(`<START;Unknown0>
+{ "type": "unknown", "var_name": "out", "val": "1"}
+<END;Unknown0>`, true, "Unknown", 0)
+
+	s.Require().Len(hook.AllEntries(), 1)
+	s.Equal(logrus.WarnLevel, hook.LastEntry().Level)
+	s.Equal("unknown type: unknown, value: 1", hook.LastEntry().Message)
+	s.Empty(globalHook.AllEntries())
+}
+
 func TestRunTimeTestSuite(t *testing.T) {
 	suite.Run(t, new(RunTimeTestSuite))
 }
@@ -0,0 +1,38 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TimeAssertStmt creates the assert statement for a time.Time value, times close to the moment
+// the output was captured are assumed to be set using time.Now and are asserted relative to the current time,
+// other times are asserted to be the same instant, ignoring their location and monotonic clock reading.
+// The instant is captured as seconds and nanoseconds, e.g. 946684800.000000000
+func TimeAssertStmt(runtimeOutput *Output) *AssertStmt {
+	if runtimeOutput.Zero {
+		return &AssertStmt{
+			AssertStmtType: AssertStmtTypeTrue,
+			Expected:       fmt.Sprintf("%s.IsZero()", runtimeOutput.VarName),
+		}
+	}
+	if runtimeOutput.Now {
+		return &AssertStmt{
+			AssertStmtType: AssertStmtTypeWithinDuration,
+			Expected:       "time.Now()",
+			Value:          runtimeOutput.VarName,
+			Delta:          "time.Second",
+		}
+	}
+	sec, nsec := runtimeOutput.Val, "0"
+	if i := strings.Index(runtimeOutput.Val, "."); i >= 0 {
+		sec, nsec = runtimeOutput.Val[:i], strings.TrimLeft(runtimeOutput.Val[i+1:], "0")
+		if nsec == "" {
+			nsec = "0"
+		}
+	}
+	return &AssertStmt{
+		AssertStmtType: AssertStmtTypeTrue,
+		Expected:       fmt.Sprintf("time.Unix(%s, %s).Equal(%s)", sec, nsec, runtimeOutput.VarName),
+	}
+}
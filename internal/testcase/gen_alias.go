@@ -0,0 +1,44 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// IsAliasable checks if values of the type refer to shared memory, so two parameters can refer to the same object
+func IsAliasable(e ast.Expr) bool {
+	switch t := e.(type) {
+	case *ast.StarExpr, *ast.MapType:
+		return true
+	case *ast.ArrayType:
+		return t.Len == nil
+	default:
+		return false
+	}
+}
+
+// Alias selects a preceding parameter of the same type to alias given parameter to, with a probability
+// of the alias bias. The choice is recorded in the aliases of the test case
+func (g *TestCase) Alias(e ast.Expr, param *ast.Ident, aliasable map[string][]*ast.Ident) (*ast.Ident, bool) {
+	if g.Opts.AliasBias <= 0 || !IsAliasable(e) {
+		return nil, false
+	}
+	candidates := aliasable[types.ExprString(e)]
+	index := g.Opts.ValTestCase.AliasIndex(g.Opts.AliasBias, len(candidates))
+	if index == -1 {
+		return nil, false
+	}
+	alias := candidates[index]
+	g.Aliases[param.Name] = alias.Name
+	g.logger().Debugf("aliasing parameter %s of func %s to %s", param.Name, g.FuncDecl.Name.Name, alias.Name)
+	return alias, true
+}
+
+// recordAliasable records given parameter as candidate for aliasing by subsequent parameters of the same type
+func recordAliasable(e ast.Expr, param *ast.Ident, aliasable map[string][]*ast.Ident) {
+	if !IsAliasable(e) {
+		return
+	}
+	key := types.ExprString(e)
+	aliasable[key] = append(aliasable[key], param)
+}
@@ -0,0 +1,126 @@
+package testcase
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// DefaultAllocsRuns amount of calls of which the allocations are averaged by testing.AllocsPerRun
+const DefaultAllocsRuns = 100
+
+// IsAllocsRelevant checks if the allocations of the function under test are asserted, i.e. allocation assertions
+// are enabled or an allocs directive is specified. Functions which spawn goroutines allocate nondeterministically.
+// Captured allocations aren't asserted if the results are only compared against an oracle
+func (g *TestCase) IsAllocsRelevant() bool {
+	_, fileName := filepath.Split(g.Pointer.File)
+	if !g.Opts.Allocs && !g.Deco.IsAllocs(fileName, g.FuncDecl.Name.Name) {
+		return false
+	}
+	if _, ok := g.Deco.GetMaxAllocs(fileName, g.FuncDecl.Name.Name); !ok && g.RunTimeInfo.ExpectationsOnly {
+		return false
+	}
+	return !g.SpawnsGoroutines()
+}
+
+// AllocsMeasureStmt creates the statement measuring the average amount of allocations of calling the function under test
+// e.g. allocs := testing.AllocsPerRun(100, func() { Sum(values) })
+func (g *TestCase) AllocsMeasureStmt(allocsIdent *ast.Ident, funcStmt ast.Stmt) ast.Stmt {
+	return assignStmt(allocsIdent, &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "testing"},
+			Sel: &ast.Ident{Name: "AllocsPerRun"},
+		},
+		Args: []ast.Expr{
+			&ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(DefaultAllocsRuns)},
+			&ast.FuncLit{
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: &ast.BlockStmt{List: []ast.Stmt{funcStmt}},
+			},
+		},
+	})
+}
+
+// AllocsToPrintStmts creates the statements printing the measured allocations, so the amount is captured at runtime.
+// The allocations are only used if the maximum is specified by the allocs directive, which is asserted instead
+func (g *TestCase) AllocsToPrintStmts(allocsIdent *ast.Ident) []ast.Stmt {
+	_, fileName := filepath.Split(g.Pointer.File)
+	if _, ok := g.Deco.GetMaxAllocs(fileName, g.FuncDecl.Name.Name); ok {
+		return []ast.Stmt{usageStmt(allocsIdent)}
+	}
+	return []ast.Stmt{
+		CreatePrintfStmt([]ast.Expr{
+			BasicLitString(`{ "type": "%s", "var_name": "%s", "val": "%v"}`),
+			BasicLitString("allocs"),
+			BasicLitString(allocsIdent.Name),
+			allocsIdent,
+		}),
+		Println(),
+	}
+}
+
+// usageStmt creates the statement using given identifier, e.g. _ = allocs
+func usageStmt(ident *ast.Ident) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{&ast.Ident{Name: "_"}},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{ident},
+	}
+}
+
+// AllocsExpectations creates the assertion of the maximum amount of allocations specified by the allocs directive
+func (g *TestCase) AllocsExpectations(allocsIdent *ast.Ident) []runtime.Stmt {
+	_, fileName := filepath.Split(g.Pointer.File)
+	maxAllocs, ok := g.Deco.GetMaxAllocs(fileName, g.FuncDecl.Name.Name)
+	if !ok {
+		return []runtime.Stmt{}
+	}
+	return []runtime.Stmt{&runtime.AssertStmt{
+		AssertStmtType: runtime.AssertStmtTypeLessOrEqual,
+		Expected:       fmt.Sprintf("float64(%d)", maxAllocs),
+		Value:          allocsIdent.Name,
+	}}
+}
+
+// HasAllocsCheck reports if the allocations of the test case are measured, which is decided when the test case
+// is created, so the value and assert templates measure the allocations of the same test cases
+func (g *TestCase) HasAllocsCheck() bool {
+	return g.AllocsIdent != "" && !g.Opts.Scaffold
+}
+
+// ResultAssertStmts retrieves the assert statements of the test case, except for those of the measured allocations
+func (g *TestCase) ResultAssertStmts() []string {
+	res, _ := g.partitionAllocsAssertStmts()
+	return res
+}
+
+// AllocsAssertStmts retrieves the assert statements of the measured allocations, which are asserted after
+// the allocations are measured. The allocations are used regardless, so the test compiles if none are asserted
+func (g *TestCase) AllocsAssertStmts() []string {
+	_, res := g.partitionAllocsAssertStmts()
+	if len(res) == 0 {
+		return []string{MustPrettyPrintElement(usageStmt(&ast.Ident{Name: g.AllocsIdent}))}
+	}
+	return res
+}
+
+func (g *TestCase) partitionAllocsAssertStmts() ([]string, []string) {
+	if !g.HasAllocsCheck() {
+		return g.RunTimeInfo.GetAssertStmts(), []string{}
+	}
+	results, allocs := []string{}, []string{}
+	usage := regexp.MustCompile(`\b` + regexp.QuoteMeta(g.AllocsIdent) + `\b`)
+	for _, stmt := range g.RunTimeInfo.GetAssertStmts() {
+		if usage.MatchString(stmt) {
+			allocs = append(allocs, stmt)
+		} else {
+			results = append(results, stmt)
+		}
+	}
+	return results, allocs
+}
@@ -0,0 +1,46 @@
+package testcase
+
+import (
+	"go/ast"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsBigNumber checks if selector expression refers to big.Int or big.Float
+func (g *TestCase) IsBigNumber(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "math/big", "Int") || g.IsImportedType(t, pointer, "math/big", "Float")
+}
+
+// BigNumberToValExpr creates a big.Int or big.Float using its constructor with a generated number,
+// as the default struct walk can't set the unexported fields. The created pointer is dereferenced,
+// so pointer parameters use the constructed pointer directly
+func (g *TestCase) BigNumberToValExpr(t *ast.SelectorExpr) *TypeExprToValExprRes {
+	var constructor ast.Expr
+	if t.Sel.Name == "Int" {
+		// big.NewInt(x)
+		constructor = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   t.X,
+				Sel: &ast.Ident{Name: "NewInt"},
+			},
+			Args: []ast.Expr{g.BasicExprToValExpr("int64")},
+		}
+	} else {
+		// new(big.Float).SetFloat64(x)
+		constructor = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.CallExpr{
+					Fun:  &ast.Ident{Name: "new"},
+					Args: []ast.Expr{t},
+				},
+				Sel: &ast.Ident{Name: "SetFloat64"},
+			},
+			Args: []ast.Expr{g.BasicExprToValExpr("float64")},
+		}
+	}
+	return &TypeExprToValExprRes{
+		Expr:         &ast.StarExpr{X: constructor},
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
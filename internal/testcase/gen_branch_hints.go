@@ -0,0 +1,175 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+// intBitSizes bit sizes of the integer types, used to verify hints next to a constant don't overflow
+var intBitSizes = map[string]int{
+	"int":     64,
+	"int8":    8,
+	"int16":   16,
+	"int32":   32,
+	"int64":   64,
+	"rune":    32,
+	"uint":    64,
+	"uint8":   8,
+	"uint16":  16,
+	"uint32":  32,
+	"uint64":  64,
+	"byte":    8,
+	"uintptr": 64,
+}
+
+// BranchHints collects the constants the parameters of basic types are compared against
+// in the body of the function under test, together with the values next to integer constants
+func (g *TestCase) BranchHints() map[string][]ast.Expr {
+	res := make(map[string][]ast.Expr)
+	if g.FuncDecl.Body == nil {
+		return res
+	}
+	paramTypes := make(map[string]string)
+	for _, field := range g.FuncDecl.Type.Params.List {
+		identifier, ok := g.IsBasicExpr(field.Type)
+		if !ok {
+			continue
+		}
+		for _, name := range field.Names {
+			paramTypes[name.Name] = identifier
+		}
+	}
+	seen := make(map[string]map[string]bool)
+	ast.Inspect(g.FuncDecl.Body, func(n ast.Node) bool {
+		binaryExpr, ok := n.(*ast.BinaryExpr)
+		if !ok || !isComparison(binaryExpr.Op) {
+			return true
+		}
+		param, constant, ok := paramComparedToConstant(binaryExpr)
+		if !ok {
+			return true
+		}
+		identifier, ok := paramTypes[param]
+		if !ok {
+			return true
+		}
+		for _, value := range hintValues(identifier, constant) {
+			if seen[param] == nil {
+				seen[param] = make(map[string]bool)
+			}
+			if seen[param][value.Value] {
+				continue
+			}
+			seen[param][value.Value] = true
+			res[param] = append(res[param], g.hintToValExpr(identifier, value))
+		}
+		return true
+	})
+	return res
+}
+
+// BranchHint retrieves a value for given parameter from the collected branch hints,
+// false is returned if no hint should be used
+func (g *TestCase) BranchHint(hints map[string][]ast.Expr, param string) (ast.Expr, bool) {
+	i := g.Opts.ValTestCase.BranchHintIndex(g.Opts.BranchHintBias, len(hints[param]))
+	if i == -1 {
+		return nil, false
+	}
+	return hints[param][i], true
+}
+
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	default:
+		return false
+	}
+}
+
+// paramComparedToConstant retrieves the identifier and the constant of a comparison between
+// an identifier and a literal, e.g. x > 10 or "admin" == s
+func paramComparedToConstant(binaryExpr *ast.BinaryExpr) (string, *ast.BasicLit, bool) {
+	if ident, ok := binaryExpr.X.(*ast.Ident); ok {
+		if constant, ok := constantLit(binaryExpr.Y); ok {
+			return ident.Name, constant, true
+		}
+	}
+	if ident, ok := binaryExpr.Y.(*ast.Ident); ok {
+		if constant, ok := constantLit(binaryExpr.X); ok {
+			return ident.Name, constant, true
+		}
+	}
+	return "", nil, false
+}
+
+// constantLit retrieves a literal, negative numbers are merged into a single literal
+func constantLit(e ast.Expr) (*ast.BasicLit, bool) {
+	switch t := e.(type) {
+	case *ast.BasicLit:
+		return t, true
+	case *ast.UnaryExpr:
+		basicLit, ok := t.X.(*ast.BasicLit)
+		if !ok || t.Op != token.SUB || (basicLit.Kind != token.INT && basicLit.Kind != token.FLOAT) {
+			return nil, false
+		}
+		return &ast.BasicLit{Kind: basicLit.Kind, Value: "-" + basicLit.Value}, true
+	default:
+		return nil, false
+	}
+}
+
+// hintValues creates the values to try for a parameter of given type compared to a constant,
+// for integers the values next to the constant are used as well to cover both sides of the boundary
+func hintValues(identifier string, constant *ast.BasicLit) []*ast.BasicLit {
+	switch identifier {
+	case "string":
+		if constant.Kind != token.STRING {
+			return nil
+		}
+		return []*ast.BasicLit{constant}
+	case "float32", "float64":
+		if constant.Kind != token.INT && constant.Kind != token.FLOAT {
+			return nil
+		}
+		return []*ast.BasicLit{constant}
+	}
+	bitSize, ok := intBitSizes[identifier]
+	if !ok || constant.Kind != token.INT {
+		return nil
+	}
+	val, err := strconv.ParseInt(constant.Value, 0, 64)
+	if err != nil {
+		return []*ast.BasicLit{constant}
+	}
+	res := []*ast.BasicLit{}
+	for _, v := range []int64{val - 1, val, val + 1} {
+		if !fitsIntType(v, identifier, bitSize) {
+			continue
+		}
+		res = append(res, &ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(v, 10)})
+	}
+	return res
+}
+
+// fitsIntType checks if a value can be represented by the integer type with given name
+func fitsIntType(v int64, identifier string, bitSize int) bool {
+	if identifier[0] == 'u' || identifier == "byte" {
+		return v >= 0 && (bitSize == 64 || v < 1<<bitSize)
+	}
+	return bitSize == 64 || (v >= -1<<(bitSize-1) && v < 1<<(bitSize-1))
+}
+
+// hintToValExpr converts a hint to a value expression of given basic type
+func (g *TestCase) hintToValExpr(identifier string, value *ast.BasicLit) ast.Expr {
+	switch identifier {
+	case "int", "float64", "string":
+		return value
+	default:
+		return &ast.CallExpr{
+			Fun:  &ast.Ident{Name: identifier},
+			Args: []ast.Expr{value},
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// FindChanFactory finds a constructor returning a channel of given type, e.g. func NewEvents() <-chan Event,
+// so channels handed out by the package are used instead of creating them. A constructor returning a bidirectional
+// channel satisfies directional channel types as well. Channels created by a factory are owned by the package,
+// so they're never closed by the test case
+func (g *TestCase) FindChanFactory(t *ast.ChanType, pointer *importer.PkgResolverPointer) *PointerConstructor {
+	if !g.Opts.ChanFactories {
+		return nil
+	}
+	return g.findFactory(pointer, !g.PackageInfo.IsRoot(pointer), func(funcType *ast.FuncType) (bool, bool) {
+		if funcType.Results == nil || len(funcType.Results.List) != 1 || len(funcType.Results.List[0].Names) > 1 {
+			return false, false
+		}
+		result, ok := funcType.Results.List[0].Type.(*ast.ChanType)
+		if !ok || types.ExprString(result.Value) != types.ExprString(t.Value) {
+			return false, false
+		}
+		return result.Dir == t.Dir || result.Dir == ast.SEND|ast.RECV, false
+	})
+}
+
+// FindNamedChanFactory finds a constructor returning a value of the defined channel type declared by given type spec
+func (g *TestCase) FindNamedChanFactory(typeSpec *ast.TypeSpec, pointer *importer.PkgResolverPointer) *PointerConstructor {
+	if !g.Opts.ChanFactories || typeSpec.TypeParams != nil {
+		return nil
+	}
+	return g.findConstructor(typeSpec.Name.Name, pointer, false, !g.PackageInfo.IsRoot(pointer))
+}
@@ -4,8 +4,6 @@ import (
 	"encoding/json"
 	"go/ast"
 	"unicode"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // NewRecursionInputWithExpr helper function for creating new recursion inputs
@@ -102,13 +100,13 @@ func (g *TestCase) CheckIfCanGenExpr(input *RecursionInput) bool { // nolint: fu
 			if g.IsBasicLit(t.Name) {
 				return true
 			}
-			if g.IsError(t.Name) {
+			if g.IsError(t.Name) || g.IsAny(t.Name) {
 				return true
 			}
 			// t.Name != basic val this is from another file in the same package
 			found, expr, newPointer := g.PackageInfo.FindInCurrent(input.pkgPointer, t.Name)
 			if !found {
-				log.Warningf("identifier not present in this file not found in other file: %s, dir: %s", t.Name, input.pkgPointer.Dir)
+				g.logger().Warningf("identifier not present in this file not found in other file: %s, dir: %s", t.Name, input.pkgPointer.Dir)
 				return false
 			}
 			return g.CheckIfCanGenExpr(&RecursionInput{
@@ -151,7 +149,7 @@ func (g *TestCase) CheckIfCanGenExpr(input *RecursionInput) bool { // nolint: fu
 				return isOK
 			}
 		default:
-			log.Warningf("unimplemented object declaration type")
+			g.logger().Warningf("unimplemented object declaration type")
 			return false
 		}
 	case *ast.FuncType:
@@ -172,13 +170,16 @@ func (g *TestCase) CheckIfCanGenExpr(input *RecursionInput) bool { // nolint: fu
 		return g.CheckIfCanGenExpr(NewRecursionInputWithExpr(t.Elt, input)) &&
 			g.CheckIfCanGenExpr(NewRecursionInputWithExpr(t.Len, input))
 	case *ast.SelectorExpr:
+		if g.IsReflectValue(t, input.pkgPointer) || g.IsBigNumber(t, input.pkgPointer) {
+			return true
+		}
 		if selectorIdent, ok := t.X.(*ast.Ident); ok {
 			found, expr, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
 			if newPointer == nil {
-				log.Warning("new pointer nil")
+				g.logger().Warning("new pointer nil")
 			}
 			if !found {
-				log.Warningf("identifier not found in imports: %s, sel: %s", selectorIdent.Name, t.Sel.Name)
+				g.logger().Warningf("identifier not found in imports: %s, sel: %s", selectorIdent.Name, t.Sel.Name)
 				return false
 			}
 			return g.CheckIfCanGenExpr(&RecursionInput{
@@ -188,7 +189,7 @@ func (g *TestCase) CheckIfCanGenExpr(input *RecursionInput) bool { // nolint: fu
 				varName:    input.varName,
 			})
 		}
-		log.Warningf("unexpected selector expr")
+		g.logger().Warningf("unexpected selector expr")
 		return false
 	case *ast.StructType:
 		isStructOK := true
@@ -213,7 +214,7 @@ func (g *TestCase) CheckIfCanGenExpr(input *RecursionInput) bool { // nolint: fu
 	case *ast.ParenExpr:
 		return g.CheckIfCanGenExpr(NewRecursionInputWithExpr(t.X, input))
 	default:
-		log.Warningf("Implement interface recurse type: %T", t)
+		g.logger().Warningf("Implement interface recurse type: %T", t)
 		return false
 	}
 }
@@ -0,0 +1,38 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/wimspaargaren/final-unit/internal/decorator"
+)
+
+// CustomValStmts creates the statements assigning a decorator value to given ident. If the decorator
+// returns a cleanup func, it's registered using t.Cleanup so resources don't leak after the test
+func (g *TestCase) CustomValStmts(ident *ast.Ident, val *decorator.CustomVal) []ast.Stmt {
+	if !val.Cleanup {
+		return []ast.Stmt{assignStmt(ident, val.Call)}
+	}
+	cleanupIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "cleanup"})
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ident, cleanupIdent},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{val.Call},
+		},
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   &ast.Ident{Name: "s"},
+							Sel: &ast.Ident{Name: "T"},
+						},
+					},
+					Sel: &ast.Ident{Name: "Cleanup"},
+				},
+				Args: []ast.Expr{cleanupIdent},
+			},
+		},
+	}
+}
@@ -0,0 +1,128 @@
+package testcase
+
+import (
+	"go/ast"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// ResultToPrintStmt converts a value asserted after calling the function to print statements, printing composite
+// values as a whole if they're asserted using go-cmp
+func (g *TestCase) ResultToPrintStmt(input *PrintRecursionInput) *PrintResult {
+	if g.Opts.CmpAssertions && g.IsCmpComparable(input.e, input.pkgPointer, true, map[*ast.TypeSpec]bool{}) {
+		return g.CmpToPrintStmt(input)
+	}
+	return g.TypeExpressionToPrintStmt(input)
+}
+
+// IsCmpComparable checks if a value of given type is a composite value i.e. a struct, slice, array, map or
+// pointer to a struct, of which the Go syntax representation is a valid literal, so it can be asserted as a whole.
+// Pointers nested in the value are printed as addresses, values of other packages may have unexported fields
+func (g *TestCase) IsCmpComparable(e ast.Expr, pointer *importer.PkgResolverPointer, top bool, visited map[*ast.TypeSpec]bool) bool { // nolint: gocyclo
+	switch t := e.(type) {
+	case *ast.Ident:
+		if t.Obj == nil {
+			if g.IsBasicLit(t.Name) {
+				return !top
+			}
+			if g.IsError(t.Name) || g.IsAny(t.Name) {
+				return false
+			}
+			found, expr, newPointer := g.PackageInfo.FindInCurrent(pointer, t.Name)
+			if !found {
+				return false
+			}
+			return g.IsCmpComparable(expr, newPointer, top, visited)
+		}
+		typeSpec, ok := t.Obj.Decl.(*ast.TypeSpec)
+		if !ok || typeSpec.TypeParams != nil {
+			return false
+		}
+		if _, _, ok := g.Comparer(typeSpec, NewPrintRecursionInput(t, "", pointer)); ok {
+			return false
+		}
+		if g.Opts.StringerAssertions && g.IsStringer(typeSpec, pointer, top) {
+			return false
+		}
+		// Recursive types are finite values, the type is checked once
+		if visited[typeSpec] {
+			return true
+		}
+		visited[typeSpec] = true
+		return g.IsCmpComparable(typeSpec.Type, pointer, top, visited)
+	case *ast.StructType:
+		isRoot := g.PackageInfo.IsRoot(pointer)
+		for _, field := range t.Fields.List {
+			for _, name := range field.Names {
+				if g.SkipsFieldAssertion(name.Name) || (!isRoot && !name.IsExported()) {
+					return false
+				}
+			}
+			if !g.IsCmpComparable(field.Type, pointer, false, visited) {
+				return false
+			}
+		}
+		return true
+	case *ast.ArrayType:
+		return g.IsCmpComparable(t.Elt, pointer, false, visited)
+	case *ast.MapType:
+		return g.IsCmpComparable(t.Key, pointer, false, visited) && g.IsCmpComparable(t.Value, pointer, false, visited)
+	case *ast.StarExpr:
+		if !top {
+			return false
+		}
+		ident, ok := t.X.(*ast.Ident)
+		return ok && g.IsCmpComparable(ident, pointer, false, visited) && g.isStructIdent(ident, pointer)
+	default:
+		return false
+	}
+}
+
+// isStructIdent checks if identifier refers to a struct type of the package of given pointer
+func (g *TestCase) isStructIdent(ident *ast.Ident, pointer *importer.PkgResolverPointer) bool {
+	if ident.Obj == nil {
+		found, expr, _ := g.PackageInfo.FindInCurrent(pointer, ident.Name)
+		if !found {
+			return false
+		}
+		var ok bool
+		ident, ok = expr.(*ast.Ident)
+		if !ok || ident.Obj == nil {
+			return false
+		}
+	}
+	typeSpec, ok := ident.Obj.Decl.(*ast.TypeSpec)
+	if !ok {
+		return false
+	}
+	_, ok = typeSpec.Type.(*ast.StructType)
+	return ok
+}
+
+// CmpToPrintStmt creates a print statement printing the Go syntax representation of a composite value,
+// which is used as expected value of a go-cmp comparison
+func (g *TestCase) CmpToPrintStmt(input *PrintRecursionInput) *PrintResult {
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "pkg": "%s", "val": %#v}`),
+		BasicLitString("cmp"),
+		BasicLitString(input.varName),
+		BasicLitString(g.Pointer.Pkg),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "fmt"},
+				Sel: &ast.Ident{Name: "Sprintf"},
+			},
+			Args: []ast.Expr{
+				BasicLitString("%#v"),
+				&ast.Ident{Name: input.varName},
+			},
+		},
+	}))
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
@@ -0,0 +1,86 @@
+package testcase
+
+import (
+	"go/ast"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// Comparer retrieves the comparison expression template used for asserting values of the type
+// declared by given type spec. Registered comparers take precedence over Equal(other T) bool methods
+func (g *TestCase) Comparer(typeSpec *ast.TypeSpec, input *PrintRecursionInput) (string, string, bool) {
+	isRoot := g.PackageInfo.IsRoot(input.pkgPointer)
+	if !isRoot && !typeSpec.Name.IsExported() {
+		return "", "", false
+	}
+	typeName := typeSpec.Name.Name
+	if !isRoot {
+		typeName = input.pkgPointer.Pkg + "." + typeName
+	}
+	if comparer, ok := g.Opts.Comparers[typeName]; ok {
+		return typeName, comparer, true
+	}
+	for _, method := range g.PackageInfo.MethodsForType(input.pkgPointer, typeSpec.Name.Name) {
+		if method.Name.Name == "Equal" && isEqualFunc(method.Type, typeSpec.Name.Name) {
+			return typeName, runtime.DefaultComparer, true
+		}
+	}
+	return "", "", false
+}
+
+// HasGoSyntax checks if the Go syntax representation of values of the type declared by given type spec is a valid
+// literal, so it can be used as expected value for a comparer. Values containing pointers or unexported fields of
+// other packages are printed using the print statements of their type instead
+func (g *TestCase) HasGoSyntax(typeSpec *ast.TypeSpec, pointer *importer.PkgResolverPointer) bool {
+	if typeSpec.TypeParams != nil {
+		return false
+	}
+	return g.IsCmpComparable(typeSpec.Type, pointer, false, map[*ast.TypeSpec]bool{typeSpec: true})
+}
+
+// isEqualFunc checks if function type matches Equal(other T) bool
+func isEqualFunc(funcType *ast.FuncType, typeName string) bool {
+	if len(funcType.Params.List) != 1 || len(funcType.Params.List[0].Names) > 1 {
+		return false
+	}
+	param, ok := funcType.Params.List[0].Type.(*ast.Ident)
+	if !ok || param.Name != typeName {
+		return false
+	}
+	if funcType.Results == nil || len(funcType.Results.List) != 1 || len(funcType.Results.List[0].Names) > 1 {
+		return false
+	}
+	result, ok := funcType.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "bool"
+}
+
+// ComparerToPrintStmt creates a print statement printing the Go syntax representation of a value,
+// which is used as expected value for the comparer of its type
+func (g *TestCase) ComparerToPrintStmt(typeName, comparer string, input *PrintRecursionInput) *PrintResult {
+	g.RunTimeInfo.Comparers[typeName] = comparer
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "type_name": "%s", "pkg": "%s", "val": %#v}`),
+		BasicLitString("comparer"),
+		BasicLitString(input.varName),
+		BasicLitString(typeName),
+		BasicLitString(g.Pointer.Pkg),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "fmt"},
+				Sel: &ast.Ident{Name: "Sprintf"},
+			},
+			Args: []ast.Expr{
+				BasicLitString("%#v"),
+				&ast.Ident{Name: input.varName},
+			},
+		},
+	}))
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
@@ -0,0 +1,276 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// PointerConstructor constructor returning a pointer to a type, e.g. func NewService() *Service
+type PointerConstructor struct {
+	FuncDecl *ast.FuncDecl
+	Pointer  *importer.PkgResolverPointer
+	// ReturnsPointer indicates the constructor returns a pointer, which is dereferenced if a value is needed
+	ReturnsPointer bool
+	// ReturnsError indicates the constructor is fallible, e.g. func NewClient() (*Client, error),
+	// the test case is skipped if the constructor rejects the generated arguments
+	ReturnsError bool
+}
+
+// PointedTypeName retrieves the name of the type declaration a pointer refers to
+// together with the pointer to the package it's declared in
+func (g *TestCase) PointedTypeName(e ast.Expr, input *RecursionInput) (string, *importer.PkgResolverPointer, bool) {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if t.Obj != nil {
+			_, ok := t.Obj.Decl.(*ast.TypeSpec)
+			return t.Name, input.pkgPointer, ok
+		}
+		if g.IsBasicLit(t.Name) || g.IsError(t.Name) || g.IsAny(t.Name) {
+			return "", nil, false
+		}
+		found, _, newPointer := g.PackageInfo.FindInCurrent(input.pkgPointer, t.Name)
+		return t.Name, newPointer, found
+	case *ast.SelectorExpr:
+		selectorIdent, ok := t.X.(*ast.Ident)
+		if !ok {
+			return "", nil, false
+		}
+		found, _, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
+		return t.Sel.Name, newPointer, found
+	default:
+		return "", nil, false
+	}
+}
+
+// FindPointerConstructor finds a constructor returning a pointer to the type with given name,
+// only constructors which solely accept basic types are used, so creating their arguments can't cause cycles.
+// Constructors using functional options are created by FunctionalOptionsToValExpr instead
+func (g *TestCase) FindPointerConstructor(typeName string, pointer *importer.PkgResolverPointer) *PointerConstructor {
+	return g.findConstructor(typeName, pointer, true, !g.PackageInfo.IsRoot(pointer))
+}
+
+// FindUnexportedTypeConstructor finds an exported constructor of an unexported type returning either
+// the type or a pointer to it, so the value is created the way the package encapsulates it instead of
+// filling its unexported internals
+func (g *TestCase) FindUnexportedTypeConstructor(typeSpec *ast.TypeSpec, pointer *importer.PkgResolverPointer) *PointerConstructor {
+	if typeSpec.Name.IsExported() || typeSpec.TypeParams != nil {
+		return nil
+	}
+	return g.findConstructor(typeSpec.Name.Name, pointer, false, true)
+}
+
+// findConstructor finds a constructor of the type with given name with only basic parameters,
+// optionally only accepting constructors returning a pointer or exported constructors
+func (g *TestCase) findConstructor(typeName string, pointer *importer.PkgResolverPointer, pointerOnly, exportedOnly bool) *PointerConstructor {
+	return g.findFactory(pointer, exportedOnly, func(funcType *ast.FuncType) (bool, bool) {
+		returnsType, returnsPointer := funcReturnsType(funcType, typeName)
+		return returnsType && (!pointerOnly || returnsPointer), returnsPointer
+	})
+}
+
+// findFactory finds a constructor with only basic parameters of which the results are accepted by given func,
+// which also reports if the constructor returns a pointer, optionally only accepting exported constructors.
+// The trailing error result of fallible constructors is stripped before passing their results to given func
+func (g *TestCase) findFactory(pointer *importer.PkgResolverPointer, exportedOnly bool, accepts func(funcType *ast.FuncType) (bool, bool)) *PointerConstructor {
+	pkg := g.PackageInfo.PkgForPointer(pointer)
+	if pkg == nil {
+		return nil
+	}
+	// Map iteration is random, sort files to keep generation deterministic
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || !strings.HasPrefix(funcDecl.Name.Name, "New") {
+				continue
+			}
+			if exportedOnly && !funcDecl.Name.IsExported() {
+				continue
+			}
+			funcType, returnsError := stripErrorResult(funcDecl.Type)
+			accepted, returnsPointer := accepts(funcType)
+			if !accepted {
+				continue
+			}
+			if !g.hasBasicParams(funcDecl.Type) {
+				continue
+			}
+			return &PointerConstructor{
+				FuncDecl: funcDecl,
+				Pointer: &importer.PkgResolverPointer{
+					Dir:  pointer.Dir,
+					Pkg:  pointer.Pkg,
+					File: fileName,
+				},
+				ReturnsPointer: returnsPointer,
+				ReturnsError:   returnsError,
+			}
+		}
+	}
+	return nil
+}
+
+// stripErrorResult strips the error result of a function type returning a single value and an error,
+// the function type is returned unchanged if it doesn't
+func stripErrorResult(funcType *ast.FuncType) (*ast.FuncType, bool) {
+	if funcType.Results == nil || len(funcType.Results.List) != 2 || len(funcType.Results.List[0].Names) > 1 {
+		return funcType, false
+	}
+	errType, ok := funcType.Results.List[1].Type.(*ast.Ident)
+	if !ok || errType.Name != "error" || len(funcType.Results.List[1].Names) > 1 {
+		return funcType, false
+	}
+	return &ast.FuncType{
+		Params:  funcType.Params,
+		Results: &ast.FieldList{List: funcType.Results.List[:1]},
+	}, true
+}
+
+// hasBasicParams checks if all parameters of a function type are of a basic type
+func (g *TestCase) hasBasicParams(funcType *ast.FuncType) bool {
+	for _, param := range funcType.Params.List {
+		if _, ok := g.IsBasicExpr(param.Type); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// PointerConstructorToValExpr creates a pointer value by calling its constructor
+func (g *TestCase) PointerConstructorToValExpr(constructor *PointerConstructor, input *RecursionInput) *TypeExprToValExprRes {
+	constructorInput := &RecursionInput{
+		pkgPointer: constructor.Pointer,
+		counter:    input.counter,
+		identList:  input.identList,
+	}
+	callExpr := &ast.CallExpr{
+		Fun: g.CorrectTypeExpr(&ast.Ident{Name: constructor.FuncDecl.Name.Name}, constructorInput),
+	}
+	for _, param := range constructor.FuncDecl.Type.Params.List {
+		identifier, _ := g.IsBasicExpr(param.Type)
+		amount := len(param.Names)
+		if amount == 0 {
+			amount = 1
+		}
+		for i := 0; i < amount; i++ {
+			callExpr.Args = append(callExpr.Args, g.BasicExprToValExpr(identifier))
+		}
+	}
+	if constructor.ReturnsError {
+		return g.FallibleConstructorToValExpr(constructor, callExpr)
+	}
+	return &TypeExprToValExprRes{
+		Expr:         callExpr,
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
+
+// FallibleConstructorToValExpr assigns the results of calling a fallible constructor, skipping the test case if it
+// rejects the generated arguments, e.g. client, err := NewClient(); if err != nil { s.T().Skipf(...) }.
+// Random arguments are often invalid, failing the test would abort capturing the values of the other test cases
+func (g *TestCase) FallibleConstructorToValExpr(constructor *PointerConstructor, callExpr *ast.CallExpr) *TypeExprToValExprRes {
+	name := utils.LowerCaseFirstLetter(strings.TrimPrefix(constructor.FuncDecl.Name.Name, "New"))
+	if name == "" {
+		name = "value"
+	}
+	valueIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: name})
+	errIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "err"})
+	return &TypeExprToValExprRes{
+		Expr: valueIdent,
+		Statements: []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{valueIdent, errIdent},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{callExpr},
+			},
+			skipOnErrorStmt(errIdent, constructor.FuncDecl.Name.Name),
+		},
+		Declarations: []ast.Decl{},
+	}
+}
+
+// skipOnErrorStmt creates a statement skipping the test case if the error of calling the function with given name
+// isn't nil, e.g. if err != nil { s.T().Skipf("NewClient rejected the generated arguments: %v", err) }
+func skipOnErrorStmt(errIdent *ast.Ident, funcName string) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  errIdent,
+			Op: token.NEQ,
+			Y:  &ast.Ident{Name: "nil"},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.CallExpr{
+							Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "s"}, Sel: &ast.Ident{Name: "T"}},
+						},
+						Sel: &ast.Ident{Name: "Skipf"},
+					},
+					Args: []ast.Expr{
+						&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(funcName + " rejected the generated arguments: %v")},
+						errIdent,
+					},
+				}},
+			},
+		},
+	}
+}
+
+// UnexportedTypeConstructorToValExpr creates a value of an unexported type by calling its constructor,
+// dereferencing the result if the constructor returns a pointer. The pointer is kept if the value is used
+// as pointer, as StarExprToValExpr uses the dereferenced pointer directly
+func (g *TestCase) UnexportedTypeConstructorToValExpr(constructor *PointerConstructor, input *RecursionInput) *TypeExprToValExprRes {
+	res := g.PointerConstructorToValExpr(constructor, input)
+	if constructor.ReturnsPointer {
+		res.Expr = &ast.StarExpr{X: res.Expr}
+	}
+	return res
+}
+
+// CanDereference checks if the value returned by a constructor of the type declared by given type spec can be
+// dereferenced, i.e. the constructor doesn't return a pointer or the value doesn't contain locks, which are copied
+func (g *TestCase) CanDereference(constructor *PointerConstructor, typeSpec *ast.TypeSpec) bool {
+	return !constructor.ReturnsPointer || !g.containsLock(typeSpec.Type, constructor.Pointer, map[*ast.TypeSpec]bool{})
+}
+
+// containsLock checks if a value of given type contains a value of a sync type, e.g. a struct with a sync.Mutex field
+func (g *TestCase) containsLock(e ast.Expr, pointer *importer.PkgResolverPointer, visited map[*ast.TypeSpec]bool) bool {
+	switch t := e.(type) {
+	case *ast.SelectorExpr:
+		return g.IsSyncType(t, pointer)
+	case *ast.Ident:
+		if t.Obj == nil {
+			return false
+		}
+		typeSpec, ok := t.Obj.Decl.(*ast.TypeSpec)
+		if !ok || visited[typeSpec] {
+			return false
+		}
+		visited[typeSpec] = true
+		return g.containsLock(typeSpec.Type, pointer, visited)
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if g.containsLock(field.Type, pointer, visited) {
+				return true
+			}
+		}
+		return false
+	case *ast.ArrayType:
+		// Elements of slices aren't copied
+		return t.Len != nil && g.containsLock(t.Elt, pointer, visited)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,40 @@
+package testcase
+
+import (
+	"go/ast"
+	"path/filepath"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsContext checks if selector expression refers to context.Context
+func (g *TestCase) IsContext(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "context", "Context")
+}
+
+// ContextToValExpr creates a background context, the key value pairs specified by ctx-value directives
+// of the function under test are injected using a chain of context.WithValue calls
+func (g *TestCase) ContextToValExpr(t *ast.SelectorExpr, input *RecursionInput) *TypeExprToValExprRes {
+	// The package may be imported under another name in the test file
+	pkg := &ast.Ident{Name: "context"}
+	if corrected, ok := g.CorrectTypeExpr(t, input).(*ast.SelectorExpr); ok {
+		if ident, ok := corrected.X.(*ast.Ident); ok {
+			pkg = ident
+		}
+	}
+	var expr ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: pkg, Sel: &ast.Ident{Name: "Background"}},
+	}
+	_, fileName := filepath.Split(g.Pointer.File)
+	for _, ctxValue := range g.Deco.GetCtxValues(fileName, g.FuncDecl.Name.Name) {
+		expr = &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: pkg, Sel: &ast.Ident{Name: "WithValue"}},
+			Args: []ast.Expr{expr, ctxValue.Key, ctxValue.Value},
+		}
+	}
+	return &TypeExprToValExprRes{
+		Expr:         expr,
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
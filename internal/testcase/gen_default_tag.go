@@ -0,0 +1,127 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// DefaultTag retrieves the value of the default tag of a struct field e.g. `default:"8080"`
+func (g *TestCase) DefaultTag(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup("default")
+}
+
+// UseDefaultTags decides if the fields of a struct with default tags are initialized to their default values,
+// so the defaults form one of the generated variants next to random values
+func (g *TestCase) UseDefaultTags(structExpr *ast.StructType) bool {
+	if !g.Opts.DefaultTags {
+		return false
+	}
+	for _, field := range structExpr.Fields.List {
+		if _, ok := g.DefaultTag(field); ok {
+			return g.Opts.ValTestCase.DefaultTag()
+		}
+	}
+	return false
+}
+
+// DefaultTagToValExpr creates the value declared by the default tag of a struct field, false is returned
+// if the field has no default tag or the default can't be parsed as a value of the field type
+func (g *TestCase) DefaultTagToValExpr(field *ast.Field) (ast.Expr, bool) {
+	value, ok := g.DefaultTag(field)
+	if !ok {
+		return nil, false
+	}
+	expr, ok := defaultValExpr(field.Type, value)
+	if !ok {
+		g.logger().Warningf("unable to use default tag value %q for field of type: %T", value, field.Type)
+	}
+	return expr, ok
+}
+
+// defaultValExpr parses the default value for a basic type or time.Duration
+func defaultValExpr(e ast.Expr, value string) (ast.Expr, bool) { // nolint: gocyclo
+	if sel, ok := e.(*ast.SelectorExpr); ok {
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Name != "time" || sel.Sel.Name != "Duration" {
+			return nil, false
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, false
+		}
+		return &ast.CallExpr{
+			Fun:  sel,
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.INT, Value: strconv.FormatInt(int64(d), 10)}},
+		}, true
+	}
+	ident, ok := e.(*ast.Ident)
+	if !ok || ident.Obj != nil {
+		return nil, false
+	}
+	switch ident.Name {
+	case "string":
+		return &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(value)}, true
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, false
+		}
+		return &ast.Ident{Name: strconv.FormatBool(b)}, true
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		i, err := strconv.ParseInt(value, 10, bitSize(ident.Name))
+		if err != nil {
+			return nil, false
+		}
+		return convertedBasicLit(ident.Name, "int", token.INT, strconv.FormatInt(i, 10)), true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		u, err := strconv.ParseUint(value, 10, bitSize(ident.Name))
+		if err != nil {
+			return nil, false
+		}
+		return convertedBasicLit(ident.Name, "", token.INT, strconv.FormatUint(u, 10)), true
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(value, bitSize(ident.Name))
+		if err != nil {
+			return nil, false
+		}
+		return convertedBasicLit(ident.Name, "float64", token.FLOAT, strconv.FormatFloat(f, 'f', -1, 64)), true
+	default:
+		return nil, false
+	}
+}
+
+// convertedBasicLit creates a basic literal converted to given type, unless it's the default type of the literal
+func convertedBasicLit(identifier, defaultType string, kind token.Token, value string) ast.Expr {
+	lit := &ast.BasicLit{Kind: kind, Value: value}
+	if identifier == defaultType {
+		return lit
+	}
+	return &ast.CallExpr{
+		Fun:  &ast.Ident{Name: identifier},
+		Args: []ast.Expr{lit},
+	}
+}
+
+// bitSize retrieves the size in bits of a numeric basic type
+func bitSize(identifier string) int {
+	switch identifier {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune", "float32":
+		return 32
+	default:
+		return 64
+	}
+}
@@ -20,10 +20,11 @@ type DuplMapChecker struct {
 	SelectorExprMap  map[ast.SelectorExpr]bool
 	EllipsisMap      map[ast.Ellipsis]bool
 	FuncLitMap       map[ast.FuncLit]bool
+	Logger           log.FieldLogger
 }
 
 // NewDuplMapChecker creates a new dupl map checker
-func NewDuplMapChecker() *DuplMapChecker {
+func NewDuplMapChecker(logger log.FieldLogger) *DuplMapChecker {
 	return &DuplMapChecker{
 		StructTypeMap:    make(map[ast.StructType]bool),
 		IdentMap:         make(map[ast.Ident]bool),
@@ -37,6 +38,7 @@ func NewDuplMapChecker() *DuplMapChecker {
 		SelectorExprMap:  make(map[ast.SelectorExpr]bool),
 		EllipsisMap:      make(map[ast.Ellipsis]bool),
 		FuncLitMap:       make(map[ast.FuncLit]bool),
+		Logger:           logger,
 	}
 }
 
@@ -96,7 +98,7 @@ func (d *DuplMapChecker) IsDuplExpr(e ast.Expr) bool { // nolint: funlen
 	case *ast.CompositeLit:
 		return d.IsDuplExpr(t.Type)
 	default:
-		log.Warningf("type: %T unknown for dupl map key check", t)
+		d.Logger.Warningf("type: %T unknown for dupl map key check", t)
 		return true
 	}
 }
@@ -0,0 +1,50 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// ErrorAsToPrintStmts converts a non nil error to print statements of the error types of the package under test
+// found in its chain using errors.As, so the type of a wrapped error is asserted using ErrorAs
+func (g *TestCase) ErrorAsToPrintStmts(varName string) []ast.Stmt {
+	res := []ast.Stmt{}
+	for _, errorType := range g.FindErrorTypes() {
+		var target ast.Expr = errorType.TypeSpec.Name
+		if errorType.PointerReceiver {
+			target = &ast.StarExpr{X: errorType.TypeSpec.Name}
+		}
+		target = g.CorrectTypeExpr(target, &RecursionInput{
+			e:          target,
+			pkgPointer: errorType.Pointer,
+		})
+		// if errors.As(err, new(*T)) { fmt.Printf(...) }
+		res = append(res, &ast.IfStmt{
+			Cond: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   &ast.Ident{Name: "errors"},
+					Sel: &ast.Ident{Name: "As"},
+				},
+				Args: []ast.Expr{
+					&ast.Ident{Name: varName},
+					&ast.CallExpr{
+						Fun:  &ast.Ident{Name: "new"},
+						Args: []ast.Expr{target},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					CreatePrintfStmt([]ast.Expr{
+						BasicLitString(`{ "type": "%s", "var_name": "%s", "type_name": "%s" } `),
+						BasicLitString("error_as"),
+						BasicLitString(varName),
+						BasicLitString(types.ExprString(target)),
+					}),
+					Println(),
+				},
+			},
+		})
+	}
+	return res
+}
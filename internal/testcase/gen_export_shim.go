@@ -0,0 +1,18 @@
+package testcase
+
+import (
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// ExportShimName retrieves the name of the exported wrapper of an unexported function
+func ExportShimName(funcName string) string {
+	return "Export" + utils.UpperCaseFirstLetter(funcName)
+}
+
+// UsesExportShim checks if the function under test is called through an exported wrapper,
+// which is only the case for unexported functions without a receiver. Generic functions can't be assigned to a
+// variable without instantiating them, so these are called directly
+func (g *TestCase) UsesExportShim() bool {
+	return g.Opts.ExportShims && g.FuncDecl.Recv == nil && len(g.TypeArgs) == 0 &&
+		!g.FuncDecl.Name.IsExported()
+}
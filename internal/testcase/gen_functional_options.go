@@ -0,0 +1,186 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// FunctionalOptionsConstructor constructor of a type using the functional options pattern
+// e.g. func New(opts ...Option) *T
+type FunctionalOptionsConstructor struct {
+	Constructor *ast.FuncDecl
+	// ReturnsPointer indicates if the constructor returns a pointer to the type
+	ReturnsPointer bool
+	Options        []*OptionFunc
+}
+
+// OptionFunc function creating an option for a functional options constructor
+// e.g. func WithX(x int) Option
+type OptionFunc struct {
+	FuncDecl *ast.FuncDecl
+	Pointer  *importer.PkgResolverPointer
+}
+
+// FindFunctionalOptionsConstructor finds a constructor using the functional options pattern
+// for the type declared by given type spec, returns nil if no such constructor is found
+func (g *TestCase) FindFunctionalOptionsConstructor(typeSpec *ast.TypeSpec, input *RecursionInput) *FunctionalOptionsConstructor {
+	isRoot := g.PackageInfo.IsRoot(input.pkgPointer)
+	if !isRoot && !typeSpec.Name.IsExported() {
+		return nil
+	}
+	pkg := g.PackageInfo.PkgForPointer(input.pkgPointer)
+	if pkg == nil {
+		return nil
+	}
+	// Map iteration is random, sort files to keep generation deterministic
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	var res *FunctionalOptionsConstructor
+	optionType := ""
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || !strings.HasPrefix(funcDecl.Name.Name, "New") {
+				continue
+			}
+			if !isRoot && !funcDecl.Name.IsExported() {
+				continue
+			}
+			returnsType, returnsPointer := funcReturnsType(funcDecl.Type, typeSpec.Name.Name)
+			if !returnsType {
+				continue
+			}
+			optionIdent, ok := variadicOptionParam(funcDecl.Type)
+			if !ok {
+				continue
+			}
+			res = &FunctionalOptionsConstructor{
+				Constructor:    funcDecl,
+				ReturnsPointer: returnsPointer,
+			}
+			optionType = optionIdent.Name
+			break
+		}
+		if res != nil {
+			break
+		}
+	}
+	if res == nil {
+		return nil
+	}
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || !strings.HasPrefix(funcDecl.Name.Name, "With") {
+				continue
+			}
+			if !isRoot && !funcDecl.Name.IsExported() {
+				continue
+			}
+			if returnsOption, _ := funcReturnsType(funcDecl.Type, optionType); !returnsOption {
+				continue
+			}
+			res.Options = append(res.Options, &OptionFunc{
+				FuncDecl: funcDecl,
+				Pointer: &importer.PkgResolverPointer{
+					Dir:  input.pkgPointer.Dir,
+					Pkg:  input.pkgPointer.Pkg,
+					File: fileName,
+				},
+			})
+		}
+	}
+	// Map iteration is random, sort options to keep generation deterministic
+	sort.Slice(res.Options, func(i, j int) bool {
+		return res.Options[i].FuncDecl.Name.Name < res.Options[j].FuncDecl.Name.Name
+	})
+	return res
+}
+
+// funcReturnsType checks if function type only returns the type with given name or a pointer to it
+func funcReturnsType(funcType *ast.FuncType, typeName string) (bool, bool) {
+	if funcType.Results == nil || len(funcType.Results.List) != 1 || len(funcType.Results.List[0].Names) > 1 {
+		return false, false
+	}
+	switch t := funcType.Results.List[0].Type.(type) {
+	case *ast.Ident:
+		return t.Name == typeName, false
+	case *ast.StarExpr:
+		ident, ok := t.X.(*ast.Ident)
+		return ok && ident.Name == typeName, true
+	default:
+		return false, false
+	}
+}
+
+// variadicOptionParam retrieves the option type if the function only accepts variadic options
+func variadicOptionParam(funcType *ast.FuncType) (*ast.Ident, bool) {
+	if len(funcType.Params.List) != 1 || len(funcType.Params.List[0].Names) > 1 {
+		return nil, false
+	}
+	ellipsis, ok := funcType.Params.List[0].Type.(*ast.Ellipsis)
+	if !ok {
+		return nil, false
+	}
+	ident, ok := ellipsis.Elt.(*ast.Ident)
+	return ident, ok
+}
+
+// FunctionalOptionsToValExpr creates a value by calling the functional options constructor
+// with a random selection of the discovered option functions
+func (g *TestCase) FunctionalOptionsToValExpr(constructor *FunctionalOptionsConstructor, input *RecursionInput) *TypeExprToValExprRes {
+	result := &TypeExprToValExprRes{}
+	callExpr := &ast.CallExpr{
+		Fun: g.CorrectTypeExpr(&ast.Ident{Name: constructor.Constructor.Name.Name}, input),
+	}
+	for _, option := range constructor.Options {
+		if !g.Opts.ValTestCase.OptionFunc() {
+			continue
+		}
+		optionInput := &RecursionInput{
+			pkgPointer: option.Pointer,
+			counter:    input.counter,
+			identList:  input.identList,
+		}
+		optionCall := &ast.CallExpr{
+			Fun: g.CorrectTypeExpr(&ast.Ident{Name: option.FuncDecl.Name.Name}, optionInput),
+		}
+		for _, param := range option.FuncDecl.Type.Params.List {
+			amount := len(param.Names)
+			if amount == 0 {
+				amount = 1
+			}
+			for i := 0; i < amount; i++ {
+				optionInput.e = param.Type
+				optionInput.varName = option.FuncDecl.Name.Name
+				recursionResult := g.TypeExprToValExpr(optionInput)
+				result.Merge(recursionResult)
+				optionCall.Args = append(optionCall.Args, recursionResult.Expr)
+			}
+		}
+		callExpr.Args = append(callExpr.Args, optionCall)
+	}
+
+	identTemp := g.Opts.IdentGen.Create(&ast.Ident{
+		Name: "options" + cases.Title(language.English).String(input.identList.Current().Name),
+	})
+	result.Statements = append(result.Statements, &ast.AssignStmt{
+		Lhs: []ast.Expr{identTemp},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{callExpr},
+	})
+	result.Expr = identTemp
+	if constructor.ReturnsPointer {
+		result.Expr = &ast.StarExpr{X: identTemp}
+	}
+	return result
+}
@@ -0,0 +1,244 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// genericInstance splits an instantiated generic type e.g. Stack[string] in its base type and type arguments
+func genericInstance(e ast.Expr) (ast.Expr, []ast.Expr, bool) {
+	switch t := e.(type) {
+	case *ast.IndexExpr:
+		return t.X, []ast.Expr{t.Index}, true
+	case *ast.IndexListExpr:
+		return t.X, t.Indices, true
+	default:
+		return nil, nil, false
+	}
+}
+
+// GenericInstanceToValExpr creates a value for an instantiated generic type e.g. Stack[string]
+// by substituting the type arguments for the type parameters of the generic type definition
+func (g *TestCase) GenericInstanceToValExpr(input *RecursionInput) *TypeExprToValExprRes {
+	base, args, _ := genericInstance(input.e)
+	if _, ok := base.(*ast.SelectorExpr); ok {
+		return g.SelectorExprToValExpr(input)
+	}
+	baseIdent, ok := base.(*ast.Ident)
+	if !ok {
+		g.logger().Warningf("unsupported generic type instantiation: %s", types.ExprString(input.e))
+		return EmptyResult()
+	}
+	typeSpec, pointer := g.genericTypeSpec(baseIdent, input)
+	if typeSpec == nil || typeSpec.TypeParams == nil || typeSpec.TypeParams.NumFields() != len(args) {
+		g.logger().Warningf("generic type definition not found for: %s", types.ExprString(input.e))
+		return EmptyResult()
+	}
+	input.identList.Add(baseIdent)
+	instanceType := g.instanceTypeExpr(typeSpec.Name, args, pointer, input)
+	return g.substitutedInstanceToValExpr(typeSpec, args, instanceType, pointer, input)
+}
+
+// ImportedGenericInstanceToValExpr creates a value for an instantiated generic type of an imported package
+// e.g. container.Stack[string]. The type arguments are resolved in the package of the generic type,
+// which is sufficient for predeclared types and types of the imported package itself
+func (g *TestCase) ImportedGenericInstanceToValExpr(input *RecursionInput) *TypeExprToValExprRes {
+	base, args, _ := genericInstance(input.e)
+	selector, ok := base.(*ast.SelectorExpr)
+	if !ok {
+		g.logger().Warningf("unsupported generic type instantiation: %s", types.ExprString(input.e))
+		return EmptyResult()
+	}
+	selectorIdent, ok := selector.X.(*ast.Ident)
+	if !ok {
+		g.logger().Warningf("unimplemented selector X: %T", selector.X)
+		return EmptyResult()
+	}
+	found, expr, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, selector.Sel.Name)
+	if !found {
+		g.logger().Warningf("identifier not found in imports: %s, expr: %v", selectorIdent.Name, selector.X)
+		return EmptyResult()
+	}
+	typeSpec := typeSpecForExpr(expr)
+	if typeSpec == nil || typeSpec.TypeParams == nil || typeSpec.TypeParams.NumFields() != len(args) {
+		g.logger().Warningf("generic type definition not found for: %s", types.ExprString(input.e))
+		return EmptyResult()
+	}
+	input.identList.Add(selector.Sel)
+	// The selector is kept as written in the file of the function under test
+	instanceType := g.CorrectTypeExpr(input.e, input)
+	return g.substitutedInstanceToValExpr(typeSpec, args, instanceType, newPointer, input)
+}
+
+// substitutedInstanceToValExpr creates a value for the definition of a generic type in which the
+// type parameters are substituted by the type arguments of the instantiation
+func (g *TestCase) substitutedInstanceToValExpr(typeSpec *ast.TypeSpec, args []ast.Expr, instanceType ast.Expr, pointer *importer.PkgResolverPointer, input *RecursionInput) *TypeExprToValExprRes {
+	// Substituted definitions are cached, so cycle detection of recursive generic types keeps working
+	key := pointer.Dir + "." + types.ExprString(input.e)
+	substituted, ok := input.counter.GenericInstances[key]
+	if !ok {
+		substituted = substituteTypeParams(typeSpec.Type, typeParamArgs(typeSpec.TypeParams, args))
+		input.counter.GenericInstances[key] = substituted
+	}
+
+	switch t := substituted.(type) {
+	case *ast.StructType:
+		result := g.StructExprToValExpr(&RecursionInput{
+			e:          t,
+			varName:    typeSpec.Name.Name,
+			pkgPointer: pointer,
+			counter:    input.counter,
+			identList:  input.identList,
+		})
+		// The composite literal of the struct is named after the generic type, so add the type arguments
+		if compositeLit, ok := result.Expr.(*ast.CompositeLit); ok {
+			compositeLit.Type = instanceType
+		}
+		if mem, ok := input.counter.StructMem[t].(*ast.CompositeLit); ok {
+			mem.Type = instanceType
+		}
+		return result
+	case *ast.InterfaceType:
+		// Methods of the substituted interface use the type arguments, so the implementation compiles as is
+		return g.InterfaceTypeToValExpr(&RecursionInput{
+			e:          t,
+			varName:    input.varName,
+			pkgPointer: pointer,
+			counter:    input.counter,
+			identList:  input.identList,
+		})
+	default:
+		recursionResult := g.TypeExprToValExpr(&RecursionInput{
+			e:          t,
+			varName:    input.varName,
+			pkgPointer: pointer,
+			counter:    input.counter,
+			identList:  input.identList,
+		})
+		result := &TypeExprToValExprRes{}
+		result.Merge(recursionResult)
+		result.Expr = &ast.CallExpr{
+			Fun:  instanceType,
+			Args: []ast.Expr{recursionResult.Expr},
+		}
+		return result
+	}
+}
+
+// genericTypeSpec resolves the type definition of a generic type declared in the current package
+func (g *TestCase) genericTypeSpec(ident *ast.Ident, input *RecursionInput) (*ast.TypeSpec, *importer.PkgResolverPointer) {
+	if ident.Obj != nil {
+		typeSpec, ok := ident.Obj.Decl.(*ast.TypeSpec)
+		if !ok {
+			return nil, input.pkgPointer
+		}
+		return typeSpec, input.pkgPointer
+	}
+	found, expr, newPointer := g.PackageInfo.FindInCurrent(input.pkgPointer, ident.Name)
+	if !found {
+		return nil, input.pkgPointer
+	}
+	typeSpec := typeSpecForExpr(expr)
+	if typeSpec == nil {
+		return nil, input.pkgPointer
+	}
+	return typeSpec, newPointer
+}
+
+// typeSpecForExpr retrieves the type definition of an identifier found by the package resolver
+func typeSpecForExpr(expr ast.Expr) *ast.TypeSpec {
+	foundIdent, ok := expr.(*ast.Ident)
+	if !ok || foundIdent.Obj == nil {
+		return nil
+	}
+	typeSpec, ok := foundIdent.Obj.Decl.(*ast.TypeSpec)
+	if !ok {
+		return nil
+	}
+	return typeSpec
+}
+
+// instanceTypeExpr creates the type expression of an instantiated generic type as used in the test case
+func (g *TestCase) instanceTypeExpr(name *ast.Ident, args []ast.Expr, pointer *importer.PkgResolverPointer, input *RecursionInput) ast.Expr {
+	base := g.CorrectTypeExpr(&ast.Ident{Name: name.Name}, &RecursionInput{pkgPointer: pointer})
+	correctedArgs := []ast.Expr{}
+	for _, arg := range args {
+		correctedArgs = append(correctedArgs, g.CorrectTypeExpr(arg, input))
+	}
+	if len(correctedArgs) == 1 {
+		return &ast.IndexExpr{X: base, Index: correctedArgs[0]}
+	}
+	return &ast.IndexListExpr{X: base, Indices: correctedArgs}
+}
+
+// typeParamArgs maps the names of the type parameters to the given type arguments
+func typeParamArgs(typeParams *ast.FieldList, args []ast.Expr) map[string]ast.Expr {
+	res := make(map[string]ast.Expr)
+	i := 0
+	for _, field := range typeParams.List {
+		for _, name := range field.Names {
+			res[name.Name] = args[i]
+			i++
+		}
+	}
+	return res
+}
+
+// substituteTypeParams creates a copy of a type expression in which the type parameters are replaced
+// by their type arguments
+func substituteTypeParams(e ast.Expr, args map[string]ast.Expr) ast.Expr { // nolint: gocyclo
+	switch t := e.(type) {
+	case *ast.Ident:
+		if arg, ok := args[t.Name]; ok {
+			return arg
+		}
+		return t
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: substituteTypeParams(t.X, args)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: substituteTypeParams(t.Elt, args)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: substituteTypeParams(t.Elt, args)}
+	case *ast.MapType:
+		return &ast.MapType{Key: substituteTypeParams(t.Key, args), Value: substituteTypeParams(t.Value, args)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: t.Dir, Value: substituteTypeParams(t.Value, args)}
+	case *ast.FuncType:
+		return &ast.FuncType{
+			Params:  substituteFieldList(t.Params, args),
+			Results: substituteFieldList(t.Results, args),
+		}
+	case *ast.StructType:
+		return &ast.StructType{Fields: substituteFieldList(t.Fields, args), Incomplete: t.Incomplete}
+	case *ast.InterfaceType:
+		return &ast.InterfaceType{Methods: substituteFieldList(t.Methods, args), Incomplete: t.Incomplete}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: t.X, Index: substituteTypeParams(t.Index, args)}
+	case *ast.IndexListExpr:
+		indices := []ast.Expr{}
+		for _, index := range t.Indices {
+			indices = append(indices, substituteTypeParams(index, args))
+		}
+		return &ast.IndexListExpr{X: t.X, Indices: indices}
+	default:
+		return e
+	}
+}
+
+// substituteFieldList substitutes the type parameters of the types in a field list
+func substituteFieldList(fieldList *ast.FieldList, args map[string]ast.Expr) *ast.FieldList {
+	if fieldList == nil {
+		return nil
+	}
+	res := &ast.FieldList{Opening: fieldList.Opening, Closing: fieldList.Closing}
+	for _, field := range fieldList.List {
+		res.List = append(res.List, &ast.Field{
+			Names: field.Names,
+			Type:  substituteTypeParams(field.Type, args),
+			Tag:   field.Tag,
+		})
+	}
+	return res
+}
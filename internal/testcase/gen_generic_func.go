@@ -0,0 +1,245 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// anyTypeArgs type arguments used for type parameters constrained by any or comparable, including a struct,
+// so functions are also tested with composite type arguments
+func anyTypeArgs() []ast.Expr {
+	return append(identExprs([]string{"int", "string", "bool", "float64"}), &ast.StructType{
+		Fields: &ast.FieldList{List: []*ast.Field{
+			{Names: []*ast.Ident{{Name: "ID"}}, Type: &ast.Ident{Name: "int"}},
+			{Names: []*ast.Ident{{Name: "Name"}}, Type: &ast.Ident{Name: "string"}},
+		}},
+	})
+}
+
+// knownConstraints type arguments used for type parameters constrained by the constraints of the standard library
+// and golang.org/x/exp/constraints, which can't be resolved by the package resolver
+var knownConstraints = map[string][]string{
+	"cmp.Ordered":          {"int", "string", "float64"},
+	"constraints.Ordered":  {"int", "string", "float64"},
+	"constraints.Integer":  {"int", "int64", "uint"},
+	"constraints.Signed":   {"int", "int64", "int8"},
+	"constraints.Unsigned": {"uint", "uint64", "uint8"},
+	"constraints.Float":    {"float64", "float32"},
+}
+
+// FuncInstance instantiation of a function under test
+type FuncInstance struct {
+	// FuncDecl declaration of the function of which the type parameters are substituted
+	FuncDecl *ast.FuncDecl
+	// TypeArgs type arguments of the instantiation, empty for non generic functions
+	TypeArgs []ast.Expr
+}
+
+// FuncInstances retrieves up to amount distinct instantiations of a generic function, so the function
+// is tested across multiple sets of type arguments. Non generic functions have a single instance.
+// Nil is returned if the type arguments of a constraint can't be determined, e.g. if it has methods
+func FuncInstances(funcDecl *ast.FuncDecl, pkgInfo *importer.PackageInfo, pointer *importer.PkgResolverPointer, amount int) []*FuncInstance {
+	if funcDecl.Type.TypeParams == nil || len(funcDecl.Type.TypeParams.List) == 0 {
+		return []*FuncInstance{{FuncDecl: funcDecl}}
+	}
+	if amount < 1 {
+		amount = 1
+	}
+	res := []*FuncInstance{}
+	for _, args := range funcTypeArgs(funcDecl, pkgInfo, pointer, amount) {
+		res = append(res, &FuncInstance{
+			FuncDecl: InstantiateFuncDecl(funcDecl, args),
+			TypeArgs: args,
+		})
+	}
+	return res
+}
+
+// funcTypeArgs retrieves up to amount distinct sets of type arguments for the type parameters of a generic
+// function, picked from the terms of the constraint of every type parameter. Terms referring to other type parameters,
+// e.g. the core type of S ~[]E, are substituted by the type arguments picked for these type parameters
+func funcTypeArgs(funcDecl *ast.FuncDecl, pkgInfo *importer.PackageInfo, pointer *importer.PkgResolverPointer, amount int) [][]ast.Expr {
+	candidates := [][]ast.Expr{}
+	for _, field := range funcDecl.Type.TypeParams.List {
+		fieldCandidates := constraintTypeArgs(field.Type, pkgInfo, pointer)
+		if len(fieldCandidates) == 0 {
+			return nil
+		}
+		for range field.Names {
+			candidates = append(candidates, fieldCandidates)
+		}
+	}
+	maxCandidates := 0
+	for _, c := range candidates {
+		if len(c) > maxCandidates {
+			maxCandidates = len(c)
+		}
+	}
+	res := [][]ast.Expr{}
+	seen := make(map[string]bool)
+	// Type parameters are shifted against each other, so instantiations differ in all type arguments if possible
+	for i := 0; len(res) < amount && i < amount*maxCandidates; i++ {
+		args := []ast.Expr{}
+		key := ""
+		for j, c := range candidates {
+			args = append(args, c[(i+j)%len(c)])
+		}
+		args, ok := substituteTypeArgs(funcDecl.Type.TypeParams, args)
+		if !ok {
+			continue
+		}
+		for _, arg := range args {
+			key += types.ExprString(arg) + ","
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		res = append(res, args)
+	}
+	return res
+}
+
+// substituteTypeArgs substitutes the type parameters referred to by type arguments by the type arguments of these
+// type parameters, false is returned if the type parameters refer to each other
+func substituteTypeArgs(typeParams *ast.FieldList, args []ast.Expr) ([]ast.Expr, bool) {
+	// Every substitution resolves at least one level of references, unless the references are cyclic
+	for range args {
+		typeArgs := typeParamArgs(typeParams, args)
+		substituted := []ast.Expr{}
+		for _, arg := range args {
+			substituted = append(substituted, substituteTypeParams(arg, typeArgs))
+		}
+		args = substituted
+	}
+	typeArgs := typeParamArgs(typeParams, args)
+	for _, arg := range args {
+		refers := false
+		ast.Inspect(arg, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && typeArgs[ident.Name] != nil {
+				refers = true
+			}
+			return !refers
+		})
+		if refers {
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+// constraintTypeArgs retrieves the type arguments satisfying a constraint of a type parameter
+func constraintTypeArgs(constraint ast.Expr, pkgInfo *importer.PackageInfo, pointer *importer.PkgResolverPointer) []ast.Expr {
+	switch t := constraint.(type) {
+	case *ast.Ident:
+		if t.Name == "any" || t.Name == "comparable" {
+			return anyTypeArgs()
+		}
+		if t.Obj != nil {
+			typeSpec, ok := t.Obj.Decl.(*ast.TypeSpec)
+			if !ok {
+				return nil
+			}
+			return constraintTypeArgs(typeSpec.Type, pkgInfo, pointer)
+		}
+		found, expr, newPointer := pkgInfo.FindInCurrent(pointer, t.Name)
+		if !found {
+			return nil
+		}
+		interfaceType, ok := expr.(*ast.InterfaceType)
+		if !ok {
+			return nil
+		}
+		return constraintTypeArgs(interfaceType, pkgInfo, newPointer)
+	case *ast.SelectorExpr:
+		if known, ok := knownConstraints[types.ExprString(t)]; ok {
+			return identExprs(known)
+		}
+		return nil
+	case *ast.InterfaceType:
+		// Only interfaces consisting of a single union of types are supported, methods can't be implemented by basic types
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return anyTypeArgs()
+		}
+		if len(t.Methods.List) != 1 || len(t.Methods.List[0].Names) != 0 {
+			return nil
+		}
+		return constraintTypeArgs(t.Methods.List[0].Type, pkgInfo, pointer)
+	case *ast.BinaryExpr:
+		if t.Op != token.OR {
+			return nil
+		}
+		return unionTerms(t)
+	case *ast.UnaryExpr, *ast.ArrayType, *ast.MapType:
+		return unionTerms(t)
+	default:
+		return nil
+	}
+}
+
+// unionTerms retrieves the types of the terms of a union e.g. ~int | float64 or ~[]E, nil is returned
+// if a term isn't a type identifier, slice or map
+func unionTerms(e ast.Expr) []ast.Expr {
+	switch t := e.(type) {
+	case *ast.BinaryExpr:
+		if t.Op != token.OR {
+			return nil
+		}
+		x, y := unionTerms(t.X), unionTerms(t.Y)
+		if x == nil || y == nil {
+			return nil
+		}
+		return append(x, y...)
+	case *ast.UnaryExpr:
+		if t.Op != token.TILDE {
+			return nil
+		}
+		return unionTerms(t.X)
+	case *ast.Ident, *ast.ArrayType, *ast.MapType:
+		return []ast.Expr{t}
+	default:
+		return nil
+	}
+}
+
+// identExprs converts type names to identifiers
+func identExprs(names []string) []ast.Expr {
+	res := []ast.Expr{}
+	for _, name := range names {
+		res = append(res, &ast.Ident{Name: name})
+	}
+	return res
+}
+
+// InstantiateFuncDecl creates a copy of a generic function declaration of which the type parameters in the signature
+// are substituted by given type arguments, so values of the parameters can be generated
+func InstantiateFuncDecl(funcDecl *ast.FuncDecl, args []ast.Expr) *ast.FuncDecl {
+	typeArgs := typeParamArgs(funcDecl.Type.TypeParams, args)
+	return &ast.FuncDecl{
+		Doc:  funcDecl.Doc,
+		Recv: funcDecl.Recv,
+		Name: funcDecl.Name,
+		Type: &ast.FuncType{
+			Func:    funcDecl.Type.Func,
+			Params:  substituteFieldList(funcDecl.Type.Params, typeArgs),
+			Results: substituteFieldList(funcDecl.Type.Results, typeArgs),
+		},
+		Body: funcDecl.Body,
+	}
+}
+
+// instantiatedFunc adds the type arguments of the instantiation of a generic function under test to the called
+// function, e.g. Map[int, string], as type arguments which only occur in the results can't be inferred
+func (g *TestCase) instantiatedFunc(fun ast.Expr) ast.Expr {
+	switch len(g.TypeArgs) {
+	case 0:
+		return fun
+	case 1:
+		return &ast.IndexExpr{X: fun, Index: g.TypeArgs[0]}
+	default:
+		return &ast.IndexListExpr{X: fun, Indices: g.TypeArgs}
+	}
+}
@@ -0,0 +1,232 @@
+package testcase
+
+import (
+	"go/ast"
+	"sort"
+
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// FindGomock checks if a mock generated by mockgen exists in the root package or its test files for the interface
+// with given name, i.e. a type Mock<name> with an EXPECT method and a constructor NewMock<name>
+func (g *TestCase) FindGomock(name string) bool {
+	files := g.PackageInfo.GetRootPkg()
+	if files == nil {
+		return false
+	}
+	paths := []string{}
+	all := make(map[string]*ast.File)
+	for path, f := range files {
+		paths = append(paths, path)
+		all[path] = f
+	}
+	for path, f := range g.PackageInfo.RootTestFiles() {
+		paths = append(paths, path)
+		all[path] = f
+	}
+	sort.Strings(paths)
+	hasConstructor, hasExpect := false, false
+	for _, path := range paths {
+		for _, decl := range all[path].Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if funcDecl.Recv == nil && funcDecl.Name.Name == "NewMock"+name {
+				hasConstructor = true
+				continue
+			}
+			if funcDecl.Recv != nil && funcDecl.Name.Name == "EXPECT" && receiverTypeName(funcDecl) == "Mock"+name {
+				hasExpect = true
+			}
+		}
+	}
+	return hasConstructor && hasExpect
+}
+
+// GomockToValExpr creates a mock of an interface using its mockgen constructor, instead of a synthetic implementation.
+// Every method declared by the interface expects any arguments and returns generated values e.g.
+// mockStore.EXPECT().Get(gomock.Any()).Return("foo", nil).AnyTimes()
+func (g *TestCase) GomockToValExpr(name string, t *ast.InterfaceType, input *RecursionInput) *TypeExprToValExprRes {
+	result := &TypeExprToValExprRes{
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+	// A single controller is shared by all mocks of the test case
+	if g.gomockCtrl == nil {
+		g.gomockCtrl = g.Opts.IdentGen.Create(&ast.Ident{Name: "ctrl"})
+		result.Statements = append(result.Statements, assignStmt(g.gomockCtrl, &ast.CallExpr{
+			Fun: gomockSelector("NewController"),
+			Args: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   &ast.Ident{Name: "s"},
+						Sel: &ast.Ident{Name: "T"},
+					},
+				},
+			},
+		}))
+	}
+	mockIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "mock" + utils.UpperCaseFirstLetter(name)})
+	result.Statements = append(result.Statements, assignStmt(mockIdent, &ast.CallExpr{
+		Fun:  &ast.Ident{Name: "NewMock" + name},
+		Args: []ast.Expr{g.gomockCtrl},
+	}))
+	g.gomockExpectStmts(mockIdent, t, input, make(map[string]bool), result)
+	result.Expr = mockIdent
+	return result
+}
+
+// gomockExpectStmts adds the expectations of the methods of an interface to the result, including the methods of
+// embedded interfaces. Methods which are already expected are skipped, since an interface may be embedded
+// multiple times in the transitive embedding closure
+func (g *TestCase) gomockExpectStmts(mockIdent *ast.Ident, t *ast.InterfaceType, input *RecursionInput, expected map[string]bool, result *TypeExprToValExprRes) {
+	if t.Methods == nil {
+		return
+	}
+	for _, method := range t.Methods.List {
+		switch methodType := method.Type.(type) {
+		case *ast.FuncType:
+			if len(method.Names) == 0 || expected[method.Names[0].Name] {
+				continue
+			}
+			expected[method.Names[0].Name] = true
+			g.gomockExpectStmt(mockIdent, method.Names[0], methodType, input, result)
+		case *ast.Ident:
+			// Embedded interfaces of the same package are resolved from their declaration
+			var expr ast.Expr
+			pointer := input.pkgPointer
+			if methodType.Obj != nil {
+				if typeSpec, ok := methodType.Obj.Decl.(*ast.TypeSpec); ok {
+					expr = typeSpec.Type
+				}
+			} else {
+				_, expr, pointer = g.PackageInfo.FindInCurrent(input.pkgPointer, methodType.Name)
+			}
+			embedded, ok := expr.(*ast.InterfaceType)
+			if !ok {
+				g.logger().Warningf("unable to resolve embedded interface: %s", methodType.Name)
+				continue
+			}
+			g.gomockExpectStmts(mockIdent, embedded, &RecursionInput{
+				e:          embedded,
+				varName:    input.varName,
+				pkgPointer: pointer,
+				counter:    input.counter,
+				identList:  input.identList,
+			}, expected, result)
+		case *ast.SelectorExpr:
+			// The methods of embedded interfaces of other packages are qualified relative to that package
+			selectorIdent, ok := methodType.X.(*ast.Ident)
+			if !ok {
+				g.logger().Warningf("unexpected embedded interface selector: %T", methodType.X)
+				continue
+			}
+			found, expr, pointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, methodType.Sel.Name)
+			embedded, ok := expr.(*ast.InterfaceType)
+			if !found || !ok {
+				g.logger().Warningf("unable to resolve embedded interface: %s.%s", selectorIdent.Name, methodType.Sel.Name)
+				continue
+			}
+			g.gomockExpectStmts(mockIdent, embedded, &RecursionInput{
+				e:          embedded,
+				varName:    input.varName,
+				pkgPointer: pointer,
+				counter:    input.counter,
+				identList:  input.identList,
+			}, expected, result)
+		default:
+			g.logger().Warningf("interface specified non functype type: %T", method.Type)
+		}
+	}
+}
+
+// gomockExpectStmt adds the expectation of a single method to the result, expecting any arguments
+// and returning generated values
+func (g *TestCase) gomockExpectStmt(mockIdent, name *ast.Ident, funcType *ast.FuncType, input *RecursionInput, result *TypeExprToValExprRes) {
+	args := []ast.Expr{}
+	for i := 0; i < fieldCount(funcType.Params); i++ {
+		args = append(args, &ast.CallExpr{Fun: gomockSelector("Any")})
+	}
+	// e.g. mockStore.EXPECT().Get(gomock.Any())
+	call := ast.Expr(&ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   mockIdent,
+					Sel: &ast.Ident{Name: "EXPECT"},
+				},
+			},
+			Sel: name,
+		},
+		Args: args,
+	})
+	if funcType.Results != nil && len(funcType.Results.List) > 0 {
+		returnValues := []ast.Expr{}
+		for _, field := range funcType.Results.List {
+			for i := 0; i < namesCount(field); i++ {
+				recursionResult := g.TypeExprToValExpr(&RecursionInput{
+					e:          field.Type,
+					varName:    name.Name,
+					pkgPointer: input.pkgPointer,
+					counter:    input.counter,
+					identList:  input.identList,
+				})
+				result.Merge(recursionResult)
+				returnValues = append(returnValues, recursionResult.Expr)
+			}
+		}
+		call = &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: call, Sel: &ast.Ident{Name: "Return"}},
+			Args: returnValues,
+		}
+	}
+	result.Statements = append(result.Statements, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: call, Sel: &ast.Ident{Name: "AnyTimes"}},
+		},
+	})
+}
+
+// gomockSelector creates a selector of the gomock package e.g. gomock.Any
+func gomockSelector(name string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   &ast.Ident{Name: "gomock"},
+		Sel: &ast.Ident{Name: name},
+	}
+}
+
+// fieldCount counts the fields of a field list, where a field with multiple names counts once per name
+func fieldCount(fieldList *ast.FieldList) int {
+	if fieldList == nil {
+		return 0
+	}
+	res := 0
+	for _, field := range fieldList.List {
+		res += namesCount(field)
+	}
+	return res
+}
+
+// namesCount counts the names of a field, an unnamed field counts as one
+func namesCount(field *ast.Field) int {
+	if len(field.Names) == 0 {
+		return 1
+	}
+	return len(field.Names)
+}
+
+// receiverTypeName retrieves the name of the receiver type of a method e.g. MockStore for (m *MockStore)
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if len(funcDecl.Recv.List) != 1 {
+		return ""
+	}
+	recvType := funcDecl.Recv.List[0].Type
+	if starExpr, ok := recvType.(*ast.StarExpr); ok {
+		recvType = starExpr.X
+	}
+	if ident, ok := recvType.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
@@ -0,0 +1,94 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// SpawnsGoroutines checks if the body of the function under test contains a go statement
+func (g *TestCase) SpawnsGoroutines() bool {
+	if g.FuncDecl.Body == nil {
+		return false
+	}
+	spawns := false
+	ast.Inspect(g.FuncDecl.Body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.GoStmt); ok {
+			spawns = true
+		}
+		return !spawns
+	})
+	return spawns
+}
+
+// HasLeakCheck reports if the test case verifies that no goroutines are leaked
+func (g *TestCase) HasLeakCheck() bool {
+	return g.LeakCheckIdent != ""
+}
+
+// IsConcurrencyRelevant checks if the function under test may race when called concurrently,
+// i.e. it spawns goroutines or accesses package level variables
+func (g *TestCase) IsConcurrencyRelevant() bool {
+	return g.SpawnsGoroutines() || g.AccessesPackageVars()
+}
+
+// AccessesPackageVars checks if the body of the function under test refers to a variable declared
+// at package level in the package of the function
+func (g *TestCase) AccessesPackageVars() bool {
+	if g.FuncDecl.Body == nil {
+		return false
+	}
+	pkg := g.PackageInfo.PkgForPointer(g.Pointer)
+	if pkg == nil {
+		return false
+	}
+	specs := make(map[string]*ast.ValueSpec)
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for _, name := range valueSpec.Names {
+					specs[name.Name] = valueSpec
+				}
+			}
+		}
+	}
+	accesses := false
+	ast.Inspect(g.FuncDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			// Only the operand can refer to a variable, the selector is a field or method
+			ast.Inspect(node.X, func(n ast.Node) bool {
+				if ident, ok := n.(*ast.Ident); ok && isPackageVar(ident, specs) {
+					accesses = true
+				}
+				return !accesses
+			})
+			return false
+		case *ast.Ident:
+			if isPackageVar(node, specs) {
+				accesses = true
+			}
+		}
+		return !accesses
+	})
+	return accesses
+}
+
+// HasRaceCheck reports if the test case calls the function concurrently to detect data races
+func (g *TestCase) HasRaceCheck() bool {
+	return g.RaceCheckIdent != ""
+}
+
+// isPackageVar checks if an identifier refers to one of given package level variables, identifiers
+// declared in another file of the package aren't resolved by the parser
+func isPackageVar(ident *ast.Ident, specs map[string]*ast.ValueSpec) bool {
+	spec, ok := specs[ident.Name]
+	if !ok {
+		return false
+	}
+	return ident.Obj == nil || ident.Obj.Decl == spec
+}
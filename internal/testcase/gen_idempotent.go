@@ -0,0 +1,62 @@
+package testcase
+
+import (
+	"go/ast"
+	"path/filepath"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// IdempotentStmts creates statements calling the function under test a second time on its own results,
+// if it's marked by an idempotent directive, and asserting the second call returns the results of the first call
+func (g *TestCase) IdempotentStmts(recvIdents []*ast.Ident, identsPrint []ast.Expr) []runtime.Stmt {
+	_, fileName := filepath.Split(g.Pointer.File)
+	if !g.Deco.IsIdempotent(fileName, g.FuncDecl.Name.Name) {
+		return []runtime.Stmt{}
+	}
+	callExpr := &ast.CallExpr{
+		Fun: &ast.Ident{Name: g.FuncDecl.Name.Name},
+	}
+	if len(recvIdents) > 0 {
+		callExpr.Fun = &ast.SelectorExpr{X: recvIdents[0], Sel: &ast.Ident{Name: g.FuncDecl.Name.Name}}
+	}
+	firstIdents := []*ast.Ident{}
+	for _, e := range identsPrint {
+		ident, ok := e.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			g.logger().Warningf("idempotent directive specified for func %s, of which not all results are verifiable", g.FuncDecl.Name.Name)
+			return []runtime.Stmt{}
+		}
+		firstIdents = append(firstIdents, ident)
+		callExpr.Args = append(callExpr.Args, ident)
+	}
+
+	// A single result is compared directly against the second call
+	if len(firstIdents) == 1 {
+		return []runtime.Stmt{&runtime.AssertStmt{
+			AssertStmtType: runtime.AssertStmtTypeEqual,
+			Expected:       firstIdents[0].Name,
+			Value:          MustPrettyPrintElement(callExpr),
+		}}
+	}
+
+	// Multiple results of the second call are assigned first
+	lhs := []string{}
+	asserts := []runtime.Stmt{}
+	for _, ident := range firstIdents {
+		againIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "again"})
+		lhs = append(lhs, againIdent.Name)
+		asserts = append(asserts, &runtime.AssertStmt{
+			AssertStmtType: runtime.AssertStmtTypeEqual,
+			Expected:       ident.Name,
+			Value:          againIdent.Name,
+		})
+	}
+	res := []runtime.Stmt{&runtime.AssignStmt{
+		AssignStmtType: runtime.AssignStmtTypeDefine,
+		LeftHand:       strings.Join(lhs, ", "),
+		RightHand:      MustPrettyPrintElement(callExpr),
+	}}
+	return append(res, asserts...)
+}
@@ -0,0 +1,113 @@
+package testcase
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"path/filepath"
+
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// InvariantStmts creates assert statements for the invariant directives of the function under test.
+// Identifiers in the invariants referring to the receiver, parameters or results are substituted
+// with the identifiers chosen by the generator, unnamed results are referred to as result,
+// or result0, result1, etc. if the function has multiple results
+func (g *TestCase) InvariantStmts(recvIdents, paramIdents []*ast.Ident, identsPrint []ast.Expr) []runtime.Stmt {
+	_, fileName := filepath.Split(g.Pointer.File)
+	invariants := g.Deco.GetInvariants(fileName, g.FuncDecl.Name.Name)
+	if len(invariants) == 0 {
+		return []runtime.Stmt{}
+	}
+	substitutions := g.invariantSubstitutions(recvIdents, paramIdents, identsPrint)
+	res := []runtime.Stmt{}
+	for _, invariant := range invariants {
+		// Parse the invariant for every test case, since substitution modifies the expression
+		expr, err := parser.ParseExpr(invariant)
+		if err != nil {
+			g.logger().WithError(err).Errorf("unable to parse invariant: %s", invariant)
+			continue
+		}
+		if !substituteIdents(expr, substitutions) {
+			g.logger().Warningf("invariant of func %s refers to a result which is not available: %s", g.FuncDecl.Name.Name, invariant)
+			continue
+		}
+		res = append(res, &runtime.AssertStmt{
+			AssertStmtType: runtime.AssertStmtTypeTrue,
+			Expected:       MustPrettyPrintElement(expr),
+		})
+	}
+	return res
+}
+
+// invariantSubstitutions maps the names used in invariants to the identifiers of the test case
+func (g *TestCase) invariantSubstitutions(recvIdents, paramIdents []*ast.Ident, identsPrint []ast.Expr) map[string]string {
+	res := make(map[string]string)
+	if g.FuncDecl.Recv != nil && len(g.FuncDecl.Recv.List) == 1 && len(recvIdents) == 1 {
+		for _, name := range g.FuncDecl.Recv.List[0].Names {
+			res[name.Name] = recvIdents[0].Name
+		}
+	}
+	i := 0
+	for _, field := range g.FuncDecl.Type.Params.List {
+		for _, name := range field.Names {
+			if i < len(paramIdents) {
+				res[name.Name] = paramIdents[i].Name
+			}
+			i++
+		}
+	}
+	if g.FuncDecl.Type.Results == nil {
+		return res
+	}
+	names := []string{}
+	for _, field := range g.FuncDecl.Type.Results.List {
+		if len(field.Names) == 0 {
+			names = append(names, "")
+		}
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+	}
+	for i, name := range names {
+		if name == "" {
+			name = "result"
+			if len(names) > 1 {
+				name = fmt.Sprintf("result%d", i)
+			}
+		}
+		if i < len(identsPrint) {
+			if ident, ok := identsPrint[i].(*ast.Ident); ok {
+				res[name] = ident.Name
+			}
+		}
+	}
+	return res
+}
+
+// substituteIdents replaces the identifiers in an expression by their substitution,
+// returns false if the expression refers to a result which isn't assigned
+func substituteIdents(expr ast.Expr, substitutions map[string]string) bool {
+	selectors := make(map[*ast.Ident]bool)
+	ok := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.SelectorExpr:
+			selectors[t.Sel] = true
+		case *ast.Ident:
+			if selectors[t] {
+				return true
+			}
+			substitution, found := substitutions[t.Name]
+			if !found {
+				return true
+			}
+			if substitution == "_" {
+				ok = false
+			}
+			t.Name = substitution
+		}
+		return true
+	})
+	return ok
+}
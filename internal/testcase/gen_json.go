@@ -0,0 +1,57 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsJSONRawMessage checks if selector expression refers to json.RawMessage
+func (g *TestCase) IsJSONRawMessage(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "encoding/json", "RawMessage")
+}
+
+// IsJSONNumber checks if selector expression refers to json.Number
+func (g *TestCase) IsJSONNumber(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "encoding/json", "Number")
+}
+
+// JSONNumberToValExpr creates a json.Number containing a generated numeric string,
+// as random strings would make parsing the number always fail
+func (g *TestCase) JSONNumberToValExpr(t *ast.SelectorExpr, input *RecursionInput) *TypeExprToValExprRes {
+	return &TypeExprToValExprRes{
+		Expr: &ast.CallExpr{
+			Fun: g.CorrectTypeExpr(t, input),
+			Args: []ast.Expr{&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: strconv.Quote(g.Opts.ValTestCase.JSONNumber()),
+			}},
+		},
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
+
+// JSONRawMessageToValExpr creates a json.RawMessage containing a generated valid JSON document,
+// as random bytes would make unmarshalling the message always fail
+func (g *TestCase) JSONRawMessageToValExpr(t *ast.SelectorExpr, input *RecursionInput) *TypeExprToValExprRes {
+	doc := g.Opts.ValTestCase.JSON()
+	value := strconv.Quote(doc)
+	if !strings.Contains(doc, "`") {
+		value = "`" + doc + "`"
+	}
+	return &TypeExprToValExprRes{
+		Expr: &ast.CallExpr{
+			Fun: g.CorrectTypeExpr(t, input),
+			Args: []ast.Expr{&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: value,
+			}},
+		},
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
@@ -0,0 +1,13 @@
+package testcase
+
+// MaxRecursion retrieves the amount of times a struct of the package may be created per value, being
+// the depth of a max-depth directive for the type if specified, otherwise the global max recursion
+func (g *TestCase) MaxRecursion(input *RecursionInput) int {
+	if g.Deco == nil || !g.PackageInfo.IsRoot(input.pkgPointer) {
+		return g.Opts.MaxRecursion
+	}
+	if depth, ok := g.Deco.GetMaxDepth(input.varName); ok {
+		return depth
+	}
+	return g.Opts.MaxRecursion
+}
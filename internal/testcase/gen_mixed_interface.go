@@ -0,0 +1,274 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// MixedInterfaceImplementers finds the exported types of the package under test implementing the interface
+// element type of a slice, so the slice can be filled with a mix of implementers. Nil is returned
+// if the element isn't an interface with methods
+func (g *TestCase) MixedInterfaceImplementers(elt ast.Expr, input *RecursionInput) []*SealedImplementer {
+	interfaceType, pointer, ok := g.resolveInterface(elt, input)
+	if !ok {
+		return nil
+	}
+	methods, ok := interfaceMethodTypes(interfaceType, map[*ast.InterfaceType]bool{})
+	if !ok || len(methods) == 0 {
+		return nil
+	}
+	// Unexported methods of interfaces of other packages can't be implemented by the package under test
+	if !g.PackageInfo.IsRoot(pointer) {
+		for name := range methods {
+			if !ast.IsExported(name) {
+				return nil
+			}
+		}
+	}
+	pkg := g.PackageInfo.PkgForPointer(g.Pointer)
+	if pkg == nil {
+		return nil
+	}
+	// Map iteration is random, sort files to keep generation deterministic
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	res := []*SealedImplementer{}
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() || typeSpec.TypeParams != nil || typeSpec.Assign.IsValid() {
+					continue
+				}
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					continue
+				}
+				declared := g.PackageInfo.MethodsForType(g.Pointer, typeSpec.Name.Name)
+				pointerReceiver, ok := g.implementsMethods(declared, methods, pointer)
+				if !ok {
+					continue
+				}
+				res = append(res, &SealedImplementer{
+					TypeSpec: typeSpec,
+					Pointer: &importer.PkgResolverPointer{
+						Dir:  g.Pointer.Dir,
+						Pkg:  g.Pointer.Pkg,
+						File: fileName,
+					},
+					PointerReceiver: pointerReceiver,
+				})
+			}
+		}
+	}
+	return res
+}
+
+// MixedInterfaceElementToValExpr creates an element of a slice of interfaces, being a value of one of the
+// given implementers or the value generated for the interface itself
+func (g *TestCase) MixedInterfaceElementToValExpr(implementers []*SealedImplementer, input *RecursionInput) *TypeExprToValExprRes {
+	index := g.Opts.ValTestCase.ImplementerIndex(len(implementers))
+	if index < 0 {
+		return g.TypeExprToValExpr(input)
+	}
+	return g.SealedImplementerToValExpr(implementers[index], input)
+}
+
+// resolveInterface resolves the interface type of given expression and the pointer to the package declaring it
+func (g *TestCase) resolveInterface(e ast.Expr, input *RecursionInput) (*ast.InterfaceType, *importer.PkgResolverPointer, bool) {
+	switch t := e.(type) {
+	case *ast.InterfaceType:
+		return t, input.pkgPointer, true
+	case *ast.Ident:
+		if t.Obj != nil {
+			typeSpec, ok := t.Obj.Decl.(*ast.TypeSpec)
+			if !ok {
+				return nil, nil, false
+			}
+			interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			return interfaceType, input.pkgPointer, ok
+		}
+		found, expr, pointer := g.PackageInfo.FindInCurrent(input.pkgPointer, t.Name)
+		if !found {
+			return nil, nil, false
+		}
+		interfaceType, ok := expr.(*ast.InterfaceType)
+		return interfaceType, pointer, ok
+	case *ast.SelectorExpr:
+		selectorIdent, ok := t.X.(*ast.Ident)
+		if !ok {
+			return nil, nil, false
+		}
+		found, expr, pointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
+		if !found {
+			return nil, nil, false
+		}
+		interfaceType, ok := expr.(*ast.InterfaceType)
+		return interfaceType, pointer, ok
+	default:
+		return nil, nil, false
+	}
+}
+
+// interfaceMethodTypes retrieves the signatures of the methods of an interface including the methods of interfaces
+// embedded from the same package, false is returned if an embedded interface can't be resolved
+func interfaceMethodTypes(t *ast.InterfaceType, visited map[*ast.InterfaceType]bool) (map[string]*ast.FuncType, bool) {
+	res := make(map[string]*ast.FuncType)
+	if t.Methods == nil || visited[t] {
+		return res, true
+	}
+	visited[t] = true
+	for _, method := range t.Methods.List {
+		switch methodType := method.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range method.Names {
+				res[name.Name] = methodType
+			}
+		case *ast.Ident:
+			if methodType.Obj == nil {
+				return nil, false
+			}
+			typeSpec, ok := methodType.Obj.Decl.(*ast.TypeSpec)
+			if !ok {
+				return nil, false
+			}
+			embedded, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				return nil, false
+			}
+			embeddedMethods, ok := interfaceMethodTypes(embedded, visited)
+			if !ok {
+				return nil, false
+			}
+			for name, funcType := range embeddedMethods {
+				res[name] = funcType
+			}
+		default:
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// implementsMethods checks if the declared methods implement the given interface methods of the interface declared
+// at given pointer, the first result indicates if methods are declared on the pointer, so a pointer has to be used
+func (g *TestCase) implementsMethods(declared []*ast.FuncDecl, methods map[string]*ast.FuncType, interfacePointer *importer.PkgResolverPointer) (bool, bool) {
+	pointerReceiver := false
+	matched := 0
+	for _, method := range declared {
+		funcType, ok := methods[method.Name.Name]
+		if !ok {
+			continue
+		}
+		if g.qualifiedTypeString(method.Type, g.declPointer(method)) != g.qualifiedTypeString(funcType, interfacePointer) {
+			return false, false
+		}
+		matched++
+		if _, ok := method.Recv.List[0].Type.(*ast.StarExpr); ok {
+			pointerReceiver = true
+		}
+	}
+	return pointerReceiver, matched == len(methods)
+}
+
+// declPointer retrieves the pointer to the file of the package under test containing given declaration,
+// which is needed to resolve the imports used by the declaration
+func (g *TestCase) declPointer(decl ast.Decl) *importer.PkgResolverPointer {
+	pkg := g.PackageInfo.PkgForPointer(g.Pointer)
+	if pkg == nil {
+		return g.Pointer
+	}
+	for fileName, f := range pkg.Files {
+		if f.Pos() <= decl.Pos() && decl.End() <= f.End() {
+			return &importer.PkgResolverPointer{Dir: g.Pointer.Dir, Pkg: g.Pointer.Pkg, File: fileName}
+		}
+	}
+	return g.Pointer
+}
+
+// qualifiedTypeString prints a type expression written in the file of given pointer, of which every declared type
+// is qualified by the directory of its package. Type expressions written in different packages, e.g. Record and
+// store.Record, are equal if they denote the same type
+func (g *TestCase) qualifiedTypeString(e ast.Expr, pointer *importer.PkgResolverPointer) string { // nolint: gocyclo
+	switch t := e.(type) {
+	case *ast.Ident:
+		if types.Universe.Lookup(t.Name) != nil {
+			return t.Name
+		}
+		dir, err := filepath.Abs(pointer.Dir)
+		if err != nil {
+			dir = pointer.Dir
+		}
+		return dir + "." + t.Name
+	case *ast.SelectorExpr:
+		selectorIdent, ok := t.X.(*ast.Ident)
+		if !ok {
+			return types.ExprString(t)
+		}
+		dir, ok := g.PackageInfo.ImportDir(pointer, selectorIdent.Name)
+		if !ok {
+			return types.ExprString(t)
+		}
+		return dir + "." + t.Sel.Name
+	case *ast.ParenExpr:
+		return g.qualifiedTypeString(t.X, pointer)
+	case *ast.StarExpr:
+		return "*" + g.qualifiedTypeString(t.X, pointer)
+	case *ast.Ellipsis:
+		return "..." + g.qualifiedTypeString(t.Elt, pointer)
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "[]" + g.qualifiedTypeString(t.Elt, pointer)
+		}
+		return "[" + types.ExprString(t.Len) + "]" + g.qualifiedTypeString(t.Elt, pointer)
+	case *ast.MapType:
+		return "map[" + g.qualifiedTypeString(t.Key, pointer) + "]" + g.qualifiedTypeString(t.Value, pointer)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.qualifiedTypeString(t.Value, pointer)
+		case ast.RECV:
+			return "<-chan " + g.qualifiedTypeString(t.Value, pointer)
+		default:
+			return "chan " + g.qualifiedTypeString(t.Value, pointer)
+		}
+	case *ast.FuncType:
+		return "func(" + g.qualifiedTypeStrings(fieldTypes(t.Params), pointer) + ") (" +
+			g.qualifiedTypeStrings(fieldTypes(t.Results), pointer) + ")"
+	case *ast.IndexExpr:
+		return g.qualifiedTypeString(t.X, pointer) + "[" + g.qualifiedTypeString(t.Index, pointer) + "]"
+	case *ast.IndexListExpr:
+		return g.qualifiedTypeString(t.X, pointer) + "[" + g.qualifiedTypeStrings(t.Indices, pointer) + "]"
+	default:
+		return types.ExprString(e)
+	}
+}
+
+func (g *TestCase) qualifiedTypeStrings(exprs []ast.Expr, pointer *importer.PkgResolverPointer) string {
+	res := []string{}
+	for _, e := range exprs {
+		res = append(res, g.qualifiedTypeString(e, pointer))
+	}
+	return strings.Join(res, ", ")
+}
+
+// InterfaceParamImplementers finds the implementers of the package under test for an interface parameter if
+// interface implementers are enabled. Implementers of which the methods are declared on the pointer receiver
+// only implement the interface by address, they're marked to be passed as a pointer
+func (g *TestCase) InterfaceParamImplementers(e ast.Expr, input *RecursionInput) []*SealedImplementer {
+	if !g.Opts.InterfaceImplementers {
+		return nil
+	}
+	return g.MixedInterfaceImplementers(e, input)
+}
@@ -0,0 +1,44 @@
+package testcase
+
+import (
+	"go/ast"
+)
+
+// NamedMapToValExpr creates a composite literal of a defined map type, e.g. Router{"/": ...},
+// instead of converting a literal of the underlying map type
+func (g *TestCase) NamedMapToValExpr(objectDeclType *ast.TypeSpec, t *ast.MapType, input *RecursionInput) *TypeExprToValExprRes {
+	recursionResult := g.TypeExprToValExpr(&RecursionInput{
+		e:          t,
+		varName:    input.varName,
+		pkgPointer: input.pkgPointer,
+		counter:    input.counter,
+		identList:  input.identList,
+	})
+	compositeLit, ok := recursionResult.Expr.(*ast.CompositeLit)
+	if !ok {
+		return g.ConvertedTypeSpecToValExpr(objectDeclType.Name, objectDeclType, input)
+	}
+	compositeLit.Type = g.CorrectTypeExpr(objectDeclType.Name, input)
+	return recursionResult
+}
+
+// IsMakeableNamedChan checks if a value of a defined channel type can be created using make directly,
+// directional and signal channels are created from a bidirectional channel of the underlying type
+func (g *TestCase) IsMakeableNamedChan(t *ast.ChanType) bool {
+	return t.Dir == ast.SEND|ast.RECV && !(g.Opts.SignalChannels && IsSignalChan(t))
+}
+
+// NamedChanToValExpr creates a channel of a defined channel type using make, e.g. make(Signal)
+func (g *TestCase) NamedChanToValExpr(objectDeclType *ast.TypeSpec, t *ast.ChanType, input *RecursionInput) *TypeExprToValExprRes {
+	newIdent := g.Opts.IdentGen.Create(input.identList.Current())
+	makeExpr := &ast.CallExpr{
+		Fun:  &ast.Ident{Name: "make"},
+		Args: []ast.Expr{g.CorrectTypeExpr(objectDeclType.Name, input)},
+	}
+	return &TypeExprToValExprRes{
+		Expr:         newIdent,
+		Statements:   []ast.Stmt{assignStmt(newIdent, makeExpr)},
+		Declarations: []ast.Decl{},
+		ChanIdents:   []*ast.Ident{{Name: newIdent.Name}},
+	}
+}
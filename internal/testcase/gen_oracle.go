@@ -0,0 +1,67 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+
+	"github.com/wimspaargaren/final-unit/internal/runtime"
+)
+
+// OracleStmts creates statements comparing the results of the function under test against the results
+// of the function specified by its oracle directive. The oracle is called with the receiver, if any,
+// followed by the parameters of the test case. The first result contains the statements calling the oracle,
+// which are executed before the function under test, so the oracle receives the arguments before they
+// may be modified by the function under test
+func (g *TestCase) OracleStmts(recvIdents, paramIdents []*ast.Ident, identsPrint []ast.Expr) ([]string, []runtime.Stmt) {
+	_, fileName := filepath.Split(g.Pointer.File)
+	oracle := g.Deco.GetOracle(fileName, g.FuncDecl.Name.Name)
+	if oracle == "" {
+		return []string{}, []runtime.Stmt{}
+	}
+	if len(identsPrint) == 0 {
+		g.logger().Warningf("oracle directive specified for func %s, which has no verifiable results", g.FuncDecl.Name.Name)
+		return []string{}, []runtime.Stmt{}
+	}
+	fun, err := parser.ParseExpr(oracle)
+	if err != nil {
+		g.logger().WithError(err).Errorf("unable to parse oracle: %s", oracle)
+		return []string{}, []runtime.Stmt{}
+	}
+	callExpr := &ast.CallExpr{
+		Fun: fun,
+	}
+	for _, ident := range recvIdents {
+		callExpr.Args = append(callExpr.Args, ident)
+	}
+	for _, ident := range paramIdents {
+		callExpr.Args = append(callExpr.Args, ident)
+	}
+	SpreadVariadic(callExpr, g.FuncDecl.Type)
+
+	// The results of the oracle are assigned first, skipping the results which aren't verifiable
+	assignStmt := &ast.AssignStmt{
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{callExpr},
+	}
+	asserts := []runtime.Stmt{}
+	for _, e := range identsPrint {
+		ident, ok := e.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			assignStmt.Lhs = append(assignStmt.Lhs, &ast.Ident{Name: "_"})
+			continue
+		}
+		expectedIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "expected"})
+		assignStmt.Lhs = append(assignStmt.Lhs, expectedIdent)
+		asserts = append(asserts, &runtime.AssertStmt{
+			AssertStmtType: runtime.AssertStmtTypeEqual,
+			Expected:       expectedIdent.Name,
+			Value:          ident.Name,
+		})
+	}
+	if len(asserts) == 0 {
+		return []string{}, []runtime.Stmt{}
+	}
+	return []string{MustPrettyPrintElement(assignStmt)}, asserts
+}
@@ -0,0 +1,81 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"math"
+	"strconv"
+)
+
+// OverflowBounds collects the min and max values of the integer parameters which are an operand of an addition
+// or multiplication in the body of the function under test, so overflows of the arithmetic are exercised
+func (g *TestCase) OverflowBounds() map[string][]ast.Expr {
+	res := make(map[string][]ast.Expr)
+	if g.FuncDecl.Body == nil {
+		return res
+	}
+	paramTypes := make(map[string]string)
+	for _, field := range g.FuncDecl.Type.Params.List {
+		identifier, ok := g.IsBasicExpr(field.Type)
+		if _, isInt := intBitSizes[identifier]; !ok || !isInt {
+			continue
+		}
+		for _, name := range field.Names {
+			paramTypes[name.Name] = identifier
+		}
+	}
+	addBounds := func(operands ...ast.Expr) {
+		for _, operand := range operands {
+			ident, ok := operand.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			identifier, ok := paramTypes[ident.Name]
+			if !ok || len(res[ident.Name]) != 0 {
+				continue
+			}
+			for _, value := range intBounds(identifier) {
+				res[ident.Name] = append(res[ident.Name], g.hintToValExpr(identifier, value))
+			}
+		}
+	}
+	ast.Inspect(g.FuncDecl.Body, func(n ast.Node) bool {
+		switch t := n.(type) {
+		case *ast.BinaryExpr:
+			if t.Op == token.ADD || t.Op == token.MUL {
+				addBounds(t.X, t.Y)
+			}
+		case *ast.AssignStmt:
+			if t.Tok == token.ADD_ASSIGN || t.Tok == token.MUL_ASSIGN {
+				addBounds(append(t.Lhs, t.Rhs...)...)
+			}
+		}
+		return true
+	})
+	return res
+}
+
+// OverflowBound retrieves a bound for given parameter from the collected overflow bounds,
+// false is returned if no bound should be used
+func (g *TestCase) OverflowBound(bounds map[string][]ast.Expr, param string) (ast.Expr, bool) {
+	i := g.Opts.ValTestCase.OverflowIndex(g.Opts.OverflowBias, len(bounds[param]))
+	if i == -1 {
+		return nil, false
+	}
+	return bounds[param][i], true
+}
+
+// intBounds creates the min and max value of the integer type with given name,
+// the min value of unsigned integers can't overflow by adding or multiplying, so only the max value is used
+func intBounds(identifier string) []*ast.BasicLit {
+	bitSize := intBitSizes[identifier]
+	if identifier[0] == 'u' || identifier == "byte" {
+		return []*ast.BasicLit{
+			{Kind: token.INT, Value: strconv.FormatUint(math.MaxUint64>>(64-bitSize), 10)},
+		}
+	}
+	return []*ast.BasicLit{
+		{Kind: token.INT, Value: strconv.FormatInt(math.MinInt64>>(64-bitSize), 10)},
+		{Kind: token.INT, Value: strconv.FormatInt(math.MaxInt64>>(64-bitSize), 10)},
+	}
+}
@@ -0,0 +1,30 @@
+package testcase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ReportsPanic checks if the test case is emitted as a skipped failing test documenting the panic observed at runtime,
+// instead of asserting the function panics
+func (g *TestCase) ReportsPanic() bool {
+	return g.Opts.PanicReports && g.RunTimeInfo.Panics
+}
+
+// PanicReport creates the lines of the comment documenting the panic observed at runtime,
+// containing the panic value followed by the frames from the panicking function up to the test
+func (g *TestCase) PanicReport() []string {
+	res := []string{"FIXME: panics: " + g.panicMessage()}
+	return append(res, g.RunTimeInfo.PanicFrames...)
+}
+
+// PanicSkipStmt creates the statement skipping the test case, which fails by panicking once the skip is removed
+func (g *TestCase) PanicSkipStmt() string {
+	return fmt.Sprintf("s.T().Skip(%s)", strconv.Quote("FIXME: panics: "+g.panicMessage()))
+}
+
+// panicMessage retrieves the value the function panicked with on a single line
+func (g *TestCase) panicMessage() string {
+	return strings.Join(strings.Fields(g.RunTimeInfo.PanicMessage), " ")
+}
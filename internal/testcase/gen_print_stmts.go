@@ -6,7 +6,6 @@ import (
 	"go/token"
 	"unicode"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/wimspaargaren/final-unit/internal/importer"
 	"github.com/wimspaargaren/final-unit/internal/utils"
 )
@@ -24,6 +23,8 @@ type PrintRecursionInput struct {
 // PrintResult result of recursion
 type PrintResult struct {
 	Stmts []ast.Stmt
+	// ArgStmts statements creating the arguments of invoked returned funcs, executed before calling the function
+	ArgStmts []ast.Stmt
 }
 
 // ResultsToPrintStmts converts a results list to print statements
@@ -36,6 +37,7 @@ func (g *TestCase) ResultsToPrintStmts(results *ast.FieldList, funcName string,
 	for _, p := range results.List {
 		idents, temp := g.FieldToPrintStmt(p, funcName, pointer)
 		res.Stmts = append(res.Stmts, temp.Stmts...)
+		res.ArgStmts = append(res.ArgStmts, temp.ArgStmts...)
 		identsRes = append(identsRes, idents...)
 	}
 	resultUsage := []ast.Stmt{}
@@ -57,7 +59,7 @@ func (g *TestCase) ResultsToPrintStmts(results *ast.FieldList, funcName string,
 				})
 			}
 		} else {
-			log.Warningf("unexpected ident expression fouund")
+			g.logger().Warningf("unexpected ident expression fouund")
 		}
 	}
 	return identsRes, res, resultUsage
@@ -72,9 +74,14 @@ func (g *TestCase) FieldToPrintStmt(field *ast.Field, funcName string, pointer *
 		for _, n := range field.Names {
 			newIdent := g.Opts.IdentGen.Create(n)
 
-			res := g.TypeExpressionToPrintStmt(NewPrintRecursionInput(field.Type, newIdent.Name, pointer))
-			printResult.Stmts = append(printResult.Stmts, res.Stmts...)
+			res := g.ResultToPrintStmt(NewPrintRecursionInput(field.Type, newIdent.Name, pointer))
 			if len(res.Stmts) == 0 {
+				res = g.ReturnedFuncPrintStmts(field.Type, newIdent, pointer)
+			}
+			printResult.Stmts = append(printResult.Stmts, res.Stmts...)
+			printResult.ArgStmts = append(printResult.ArgStmts, res.ArgStmts...)
+			// Without print statements the result isn't asserted, unless assertions are written manually
+			if len(res.Stmts) == 0 && !g.Opts.Scaffold {
 				expressions = append(expressions, &ast.Ident{
 					Name: "_",
 				})
@@ -87,8 +94,11 @@ func (g *TestCase) FieldToPrintStmt(field *ast.Field, funcName string, pointer *
 	// Normal returns
 	newIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "out"})
 
-	res := g.TypeExpressionToPrintStmt(NewPrintRecursionInput(field.Type, newIdent.Name, pointer))
+	res := g.ResultToPrintStmt(NewPrintRecursionInput(field.Type, newIdent.Name, pointer))
 	if len(res.Stmts) == 0 {
+		res = g.ReturnedFuncPrintStmts(field.Type, newIdent, pointer)
+	}
+	if len(res.Stmts) == 0 && !g.Opts.Scaffold {
 		return []ast.Expr{&ast.Ident{
 			Name: "_",
 		}}, res
@@ -105,10 +115,14 @@ func (g *TestCase) PreIdentToPrintStmt(t *ast.Ident, input *PrintRecursionInput)
 		if g.IsError(t.Name) {
 			return g.ErrExprToPrintStmt(input)
 		}
+		// Ignore empty interfaces, like other interface types
+		if g.IsAny(t.Name) {
+			return &PrintResult{}
+		}
 		// t.Name != basic val this is from another file in the same package
 		found, expr, newPointer := g.PackageInfo.FindInCurrent(input.pkgPointer, t.Name)
 		if !found {
-			log.Warningf("identifier not present in this file not found in other file: %s", t.Name)
+			g.logger().Warningf("identifier not present in this file not found in other file: %s", t.Name)
 		} else {
 			return g.TypeExpressionToPrintStmt(&PrintRecursionInput{
 				e:          expr,
@@ -124,6 +138,13 @@ func (g *TestCase) PreIdentToPrintStmt(t *ast.Ident, input *PrintRecursionInput)
 	switch objectDeclType := t.Obj.Decl.(type) {
 	// Object type
 	case *ast.TypeSpec:
+		if typeName, comparer, ok := g.Comparer(objectDeclType, input); ok && g.HasGoSyntax(objectDeclType, input.pkgPointer) {
+			return g.ComparerToPrintStmt(typeName, comparer, input)
+		}
+		// Only values stored in a variable are addressable, e.g. map elements are not
+		if g.Opts.StringerAssertions && g.IsStringer(objectDeclType, input.pkgPointer, token.IsIdentifier(input.varName)) {
+			return g.StringerToPrintStmt(input)
+		}
 		switch oType := objectDeclType.Type.(type) {
 		case *ast.StructType:
 			return g.StructExprToPrintStmt(&PrintRecursionInput{
@@ -157,11 +178,11 @@ func (g *TestCase) PreIdentToPrintStmt(t *ast.Ident, input *PrintRecursionInput)
 				varName:    input.varName,
 			})
 		default:
-			log.Warningf("Unsupported validate type: %T", oType)
+			g.logger().Warningf("Unsupported validate type: %T", oType)
 			return &PrintResult{}
 		}
 	default:
-		log.Warningf("unimplemented object declaration type")
+		g.logger().Warningf("unimplemented object declaration type")
 		return &PrintResult{}
 	}
 }
@@ -187,7 +208,7 @@ func (g *TestCase) TypeExpressionToPrintStmt(input *PrintRecursionInput) *PrintR
 		*ast.FuncType:
 		return &PrintResult{}
 	default:
-		log.Warningf("Unsupported print stmt: %T", t)
+		g.logger().Warningf("Unsupported print stmt: %T", t)
 		return &PrintResult{}
 	}
 }
@@ -217,18 +238,23 @@ func (g *TestCase) SelectorExprToPrintStmt(input *PrintRecursionInput) *PrintRes
 	t, ok := input.e.(*ast.SelectorExpr)
 	// Sanity check
 	if !ok {
-		log.Warningf("SelectorExprToPrintStmt is not  used correctly: %T", input.e)
+		g.logger().Warningf("SelectorExprToPrintStmt is not  used correctly: %T", input.e)
 		return &PrintResult{}
 	}
 
+	// The internals of time.Time differ per call, e.g. its monotonic clock reading, so it's printed separately
+	if g.IsImportedType(t, input.pkgPointer, "time", "Time") {
+		return g.TimeToPrintStmt(t, input)
+	}
+
 	// Resolve imports
 	if selectorIdent, ok := t.X.(*ast.Ident); ok {
 		found, expr, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
 		if newPointer == nil {
-			log.Warning("new pointer nil")
+			g.logger().Warning("new pointer nil")
 		}
 		if !found {
-			log.Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, t.Sel.Name)
+			g.logger().Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, t.Sel.Name)
 			return &PrintResult{}
 		}
 		res := g.TypeExpressionToPrintStmt(&PrintRecursionInput{
@@ -242,7 +268,7 @@ func (g *TestCase) SelectorExprToPrintStmt(input *PrintRecursionInput) *PrintRes
 
 		return res
 	}
-	log.Warningf("unimplemented selector X: %T", t.Sel)
+	g.logger().Warningf("unimplemented selector X: %T", t.Sel)
 	return &PrintResult{}
 }
 
@@ -265,14 +291,14 @@ func (g *TestCase) ErrExprToPrintStmt(input *PrintRecursionInput) *PrintResult {
 			},
 		},
 		Else: &ast.BlockStmt{
-			List: []ast.Stmt{
+			List: append([]ast.Stmt{
 				CreatePrintfStmt([]ast.Expr{
 					BasicLitString(`{ "type": "%s", "var_name": "%s", "val": "notnil" } `),
 					BasicLitString("error"),
 					BasicLitString(input.varName),
 				}),
 				Println(),
-			},
+			}, g.ErrorAsToPrintStmts(input.varName)...),
 		},
 	}
 	return &PrintResult{
@@ -349,7 +375,7 @@ func (g *TestCase) StructExprToPrintStmt(input *PrintRecursionInput) *PrintResul
 	t, ok := input.e.(*ast.StructType)
 	// Sanity check
 	if !ok {
-		log.Warningf("StructExprToPrintStmt is not  used correctly: %T", input.e)
+		g.logger().Warningf("StructExprToPrintStmt is not  used correctly: %T", input.e)
 		return &PrintResult{}
 	}
 	// Store current struct in memory
@@ -406,6 +432,9 @@ func (g *TestCase) StructExprToPrintStmt(input *PrintRecursionInput) *PrintResul
 					continue
 				}
 			}
+			if g.SkipsFieldAssertion(n.Name) {
+				continue
+			}
 			prefix := CreatePrintfStmt([]ast.Expr{
 				BasicLitString(`{ "type": "%s", "var_name": "%s", "child": `),
 				BasicLitString("struct"),
@@ -431,6 +460,9 @@ func (g *TestCase) StructExprToPrintStmt(input *PrintRecursionInput) *PrintResul
 					continue
 				}
 			}
+			if g.SkipsFieldAssertion(n.Name) {
+				continue
+			}
 			prefix := CreatePrintfStmt([]ast.Expr{
 				BasicLitString(`{ "type": "%s", "var_name": "%s", "child": `),
 				BasicLitString("struct"),
@@ -509,6 +541,13 @@ func (g *TestCase) MapExprToPrintStmt(t *ast.MapType, input *PrintRecursionInput
 
 // ArrayExprToPrintStmt converts an array type to print statements
 func (g *TestCase) ArrayExprToPrintStmt(t *ast.ArrayType, input *PrintRecursionInput) *PrintResult {
+	// Slices of functions marked unordered are sorted before printing, so map iteration order doesn't matter
+	if t.Len == nil && g.IsComparableSlice(t) && g.IsUnordered() {
+		return g.UnorderedSliceToPrintStmt(t, input)
+	}
+	if g.Opts.WholeSliceAssertions && g.IsComparableSlice(t) {
+		return g.WholeSliceToPrintStmt(t, input)
+	}
 	res := []ast.Stmt{}
 
 	indexIdent := &ast.Ident{
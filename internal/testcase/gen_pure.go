@@ -0,0 +1,21 @@
+package testcase
+
+import (
+	"go/ast"
+	"path/filepath"
+)
+
+// IsPure checks if the function under test is marked pure by a pure directive
+func (g *TestCase) IsPure() bool {
+	_, fileName := filepath.Split(g.Pointer.File)
+	return g.Deco.IsPure(fileName, g.FuncDecl.Name.Name)
+}
+
+// PurePrintStmts creates print statements for the parameters referring to shared memory if the function is pure,
+// which are executed before calling the function, so the captured values are asserted to be unchanged after the call
+func (g *TestCase) PurePrintStmts(paramIdents []*ast.Ident) []ast.Stmt {
+	if !g.IsPure() {
+		return []ast.Stmt{}
+	}
+	return g.argPrintStmts(g.FuncDecl.Type.Params, paramIdents)
+}
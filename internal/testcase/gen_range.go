@@ -0,0 +1,88 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"math"
+
+	"github.com/wimspaargaren/final-unit/internal/decorator"
+)
+
+// RangeValExpr creates a value within the bounds of a range directive for a parameter of a basic numeric type,
+// false is returned if the type isn't numeric or no value of the type lies within the bounds
+func (g *TestCase) RangeValExpr(e ast.Expr, r *decorator.Range) (ast.Expr, bool) {
+	ident, ok := e.(*ast.Ident)
+	if !ok || ident.Obj != nil {
+		return nil, false
+	}
+	switch ident.Name {
+	case "float64":
+		return &ast.BasicLit{
+			Kind:  token.FLOAT,
+			Value: g.Opts.ValTestCase.FloatRange(r.Min, r.Max),
+		}, true
+	case "float32":
+		// The bounds are intersected with the limits of float32, so the constant doesn't overflow
+		min, max := math.Max(r.Min, -math.MaxFloat32), math.Min(r.Max, math.MaxFloat32)
+		if min > max {
+			g.logger().Warningf("range directive contains no %s value between %v and %v", ident.Name, r.Min, r.Max)
+			return nil, false
+		}
+		return &ast.CallExpr{
+			Fun: &ast.Ident{Name: ident.Name},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.FLOAT,
+					Value: g.Opts.ValTestCase.FloatRange(min, max),
+				},
+			},
+		}, true
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return g.intRangeValExpr(ident.Name, r.Min, r.Max)
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return g.intRangeValExpr(ident.Name, r.Min, r.Max)
+	default:
+		g.logger().Warningf("range directive is not supported for type: %s", ident.Name)
+		return nil, false
+	}
+}
+
+// intRangeValExpr creates an integer value of given type within the bounds, which are intersected with the limits
+// of the type, so the constant doesn't overflow e.g. an int8 with range 0 1000
+func (g *TestCase) intRangeValExpr(identifier string, min, max float64) (ast.Expr, bool) {
+	typeMin, typeMax := intLimits(identifier)
+	lower, upper := math.Ceil(min), math.Floor(max)
+	if lower > upper || upper < float64(typeMin) || lower > float64(typeMax) {
+		g.logger().Warningf("range directive contains no %s value between %v and %v", identifier, min, max)
+		return nil, false
+	}
+	// Bounds beyond the limits are replaced by the limits themselves, float64 can't represent every int64
+	from, to := typeMin, typeMax
+	if lower > float64(typeMin) {
+		from = int64(lower)
+	}
+	if upper < float64(typeMax) {
+		to = int64(upper)
+	}
+	value := g.Opts.ValTestCase.IntRange(int(from), int(to))
+	if identifier == "int" {
+		return &ast.BasicLit{
+			Kind:  token.INT,
+			Value: value,
+		}, true
+	}
+	return g.numericBasicType(identifier, value), true
+}
+
+// intLimits retrieves the min and max value of the integer type with given name. Values are generated as int,
+// so the max value of unsigned integers of 64 bits is limited to the max value of int64
+func intLimits(identifier string) (int64, int64) {
+	bitSize := intBitSizes[identifier]
+	if identifier[0] == 'u' || identifier == "byte" {
+		if bitSize == 64 {
+			return 0, math.MaxInt64
+		}
+		return 0, 1<<bitSize - 1
+	}
+	return math.MinInt64 >> (64 - bitSize), math.MaxInt64 >> (64 - bitSize)
+}
@@ -0,0 +1,89 @@
+package testcase
+
+import (
+	"go/ast"
+)
+
+// IsReachableMethod checks if a method of a synthetic interface implementation could be called
+// when executing the function under test
+func (g *TestCase) IsReachableMethod(method *ast.Field) bool {
+	reachable := g.ReachableMethods()
+	for _, name := range method.Names {
+		if reachable[name.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// ReachableMethods retrieves the names of the methods statically reachable from the function under test.
+// Starting from its body, the bodies of the functions and methods of the package called by name are scanned
+// as well, so methods called by a helper receiving the interface are considered reachable
+func (g *TestCase) ReachableMethods() map[string]bool {
+	if g.reachableMethods != nil {
+		return g.reachableMethods
+	}
+	g.reachableMethods = make(map[string]bool)
+	funcs, methods := g.packageFuncDecls()
+	visited := map[*ast.FuncDecl]bool{g.FuncDecl: true}
+	queue := []*ast.FuncDecl{g.FuncDecl}
+	for len(queue) > 0 {
+		funcDecl := queue[0]
+		queue = queue[1:]
+		if funcDecl.Body == nil {
+			continue
+		}
+		callees := []*ast.FuncDecl{}
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			callExpr, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			switch fun := callExpr.Fun.(type) {
+			case *ast.Ident:
+				if callee, ok := funcs[fun.Name]; ok {
+					callees = append(callees, callee)
+				}
+			case *ast.SelectorExpr:
+				g.reachableMethods[fun.Sel.Name] = true
+				callees = append(callees, methods[fun.Sel.Name]...)
+			}
+			return true
+		})
+		for _, callee := range callees {
+			if !visited[callee] {
+				visited[callee] = true
+				queue = append(queue, callee)
+			}
+		}
+	}
+	return g.reachableMethods
+}
+
+// packageFuncDecls retrieves the functions and methods declared in the package of the function under test
+// by name, multiple types of the package may declare a method with the same name
+func (g *TestCase) packageFuncDecls() (map[string]*ast.FuncDecl, map[string][]*ast.FuncDecl) {
+	funcs := make(map[string]*ast.FuncDecl)
+	methods := make(map[string][]*ast.FuncDecl)
+	if g.PackageInfo == nil || g.Pointer == nil {
+		return funcs, methods
+	}
+	pkg := g.PackageInfo.PkgForPointer(g.Pointer)
+	if pkg == nil {
+		return funcs, methods
+	}
+	for _, f := range pkg.Files {
+		for _, d := range f.Decls {
+			funcDecl, ok := d.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			if funcDecl.Recv == nil {
+				funcs[funcDecl.Name.Name] = funcDecl
+				continue
+			}
+			methods[funcDecl.Name.Name] = append(methods[funcDecl.Name.Name], funcDecl)
+		}
+	}
+	return funcs, methods
+}
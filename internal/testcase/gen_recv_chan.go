@@ -0,0 +1,56 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// RecvChanToValExpr creates a value for a receive only channel, which is a buffered channel filled with
+// generated values of the element type and closed afterwards, so receiving or ranging over it doesn't block
+func (g *TestCase) RecvChanToValExpr(t *ast.ChanType, input *RecursionInput) *TypeExprToValExprRes {
+	newIdent := g.Opts.IdentGen.Create(input.identList.Current())
+	chanLen, counter := g.spendBudget(input, g.Opts.ValTestCase.SliceLen(g.Opts.LenBoundaryBias), 1)
+	result := &TypeExprToValExprRes{}
+	sendStmts := []ast.Stmt{}
+	for i := 0; i < chanLen; i++ {
+		recursionResult := g.TypeExprToValExpr(&RecursionInput{
+			e:          t.Value,
+			varName:    input.varName,
+			pkgPointer: input.pkgPointer,
+			counter:    counter,
+			identList:  input.identList,
+		})
+		if IsEmptyExpr(recursionResult.Expr) {
+			g.logger().Warningf("unable to generate element of chan type: %s, skipping test case", types.ExprString(t))
+			g.Invalid = true
+			return EmptyResult()
+		}
+		result.Merge(recursionResult)
+		sendStmts = append(sendStmts, &ast.SendStmt{
+			Chan:  newIdent,
+			Value: recursionResult.Expr,
+		})
+	}
+	makeArgs := []ast.Expr{&ast.ChanType{
+		Dir:   ast.SEND | ast.RECV,
+		Value: g.CorrectTypeExpr(t.Value, input),
+	}}
+	if chanLen > 0 {
+		makeArgs = append(makeArgs, &ast.BasicLit{Kind: token.INT, Value: strconv.Itoa(chanLen)})
+	}
+	result.Statements = append(result.Statements, assignStmt(newIdent, &ast.CallExpr{
+		Fun:  &ast.Ident{Name: "make"},
+		Args: makeArgs,
+	}))
+	result.Statements = append(result.Statements, sendStmts...)
+	result.Statements = append(result.Statements, &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun:  &ast.Ident{Name: "close"},
+			Args: []ast.Expr{newIdent},
+		},
+	})
+	result.Expr = newIdent
+	return result
+}
@@ -0,0 +1,28 @@
+package testcase
+
+import (
+	"go/ast"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsReflectValue checks if selector expression refers to reflect.Value
+func (g *TestCase) IsReflectValue(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "reflect", "Value")
+}
+
+// ReflectValueToValExpr wraps a generated value of a random basic type in reflect.ValueOf,
+// as reflect.Value can't be constructed using its unexported fields
+func (g *TestCase) ReflectValueToValExpr(t *ast.SelectorExpr) *TypeExprToValExprRes {
+	return &TypeExprToValExprRes{
+		Expr: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   t.X,
+				Sel: &ast.Ident{Name: "ValueOf"},
+			},
+			Args: []ast.Expr{g.BasicExprToValExpr(g.Opts.ValTestCase.Type())},
+		},
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
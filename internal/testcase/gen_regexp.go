@@ -0,0 +1,41 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsRegexp checks if selector expression refers to regexp.Regexp
+func (g *TestCase) IsRegexp(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "regexp", "Regexp")
+}
+
+// RegexpToValExpr creates a regexp by compiling a generated valid pattern,
+// e.g. regexp.MustCompile("^[a-z]+"), as filling its fields results in an invalid regexp
+func (g *TestCase) RegexpToValExpr(t *ast.SelectorExpr, input *RecursionInput, isPointer bool) *TypeExprToValExprRes {
+	// The package may be imported under another name in the test file
+	pkg := &ast.Ident{Name: "regexp"}
+	if corrected, ok := g.CorrectTypeExpr(t, input).(*ast.SelectorExpr); ok {
+		if ident, ok := corrected.X.(*ast.Ident); ok {
+			pkg = ident
+		}
+	}
+	var expr ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: pkg, Sel: &ast.Ident{Name: "MustCompile"}},
+		Args: []ast.Expr{&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: strconv.Quote(g.Opts.ValTestCase.RegexpPattern()),
+		}},
+	}
+	if !isPointer {
+		expr = &ast.StarExpr{X: expr}
+	}
+	return &TypeExprToValExprRes{
+		Expr:         expr,
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
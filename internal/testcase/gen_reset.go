@@ -0,0 +1,26 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/parser"
+	"path/filepath"
+)
+
+// ResetStmts creates calls of the functions specified by the reset directives of the function under test,
+// which reset the package state before the values of the test case are created, so results don't depend
+// on the test cases executed before
+func (g *TestCase) ResetStmts() []ast.Stmt {
+	_, fileName := filepath.Split(g.Pointer.File)
+	res := []ast.Stmt{}
+	for _, reset := range g.Deco.GetResets(fileName, g.FuncDecl.Name.Name) {
+		fun, err := parser.ParseExpr(reset)
+		if err != nil {
+			g.logger().WithError(err).Errorf("unable to parse reset func: %s", reset)
+			continue
+		}
+		res = append(res, &ast.ExprStmt{
+			X: &ast.CallExpr{Fun: fun},
+		})
+	}
+	return res
+}
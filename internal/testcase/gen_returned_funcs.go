@@ -0,0 +1,83 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// ReturnedFuncPrintStmts creates print statements invoking a returned func the configured amount of times,
+// such that the result of every successive call is asserted. Only funcs with parameters and a single result
+// of a basic type are invoked, since the call is repeated in the assert statement. The arguments are created
+// before calling the function under test, and the calls are guarded against panics of the returned func
+func (g *TestCase) ReturnedFuncPrintStmts(e ast.Expr, ident *ast.Ident, pointer *importer.PkgResolverPointer) *PrintResult {
+	funcType, ok := e.(*ast.FuncType)
+	if g.Opts.ReturnedFuncCalls <= 0 || !ok {
+		return &PrintResult{}
+	}
+	resultTypes := fieldTypes(funcType.Results)
+	if len(resultTypes) != 1 || !g.isBasicType(resultTypes[0]) {
+		return &PrintResult{}
+	}
+	res := &PrintResult{}
+	call := &ast.CallExpr{Fun: ident}
+	for _, paramType := range fieldTypes(funcType.Params) {
+		if !g.isBasicType(paramType) {
+			return &PrintResult{}
+		}
+		argIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: ident.Name + "Arg"})
+		call.Args = append(call.Args, argIdent)
+		// Arguments remain used if the func is nil or panics, since no assertions are created in that case
+		res.ArgStmts = append(res.ArgStmts,
+			assignStmt(argIdent, g.BasicExprToValExpr(paramType.(*ast.Ident).Name)),
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.Ident{Name: "_"}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{argIdent},
+			},
+		)
+	}
+	callName := MustPrettyPrintElement(call)
+	guarded := []ast.Stmt{recoverStmt()}
+	for i := 0; i < g.Opts.ReturnedFuncCalls; i++ {
+		guarded = append(guarded, g.TypeExpressionToPrintStmt(NewPrintRecursionInput(resultTypes[0], callName, pointer)).Stmts...)
+	}
+	// func() { defer func() { _ = recover() }(); ... }()
+	res.Stmts = []ast.Stmt{
+		&ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.FuncLit{
+					Type: &ast.FuncType{Params: &ast.FieldList{}},
+					Body: &ast.BlockStmt{List: guarded},
+				},
+			},
+		},
+	}
+	return res
+}
+
+func (g *TestCase) isBasicType(e ast.Expr) bool {
+	ident, ok := e.(*ast.Ident)
+	return ok && ident.Obj == nil && g.IsBasicLit(ident.Name)
+}
+
+// recoverStmt creates a deferred recover ignoring panics
+func recoverStmt() ast.Stmt {
+	return &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{Params: &ast.FieldList{}},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{&ast.Ident{Name: "_"}},
+							Tok: token.ASSIGN,
+							Rhs: []ast.Expr{&ast.CallExpr{Fun: &ast.Ident{Name: "recover"}}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,11 @@
+package testcase
+
+import "strings"
+
+// ScaffoldTODO creates the placeholder emitted instead of the assertions if only the scaffolding is generated
+func (g *TestCase) ScaffoldTODO() string {
+	if len(g.ResultIdents) == 0 {
+		return "TODO: add assertions"
+	}
+	return "TODO: assert on " + strings.Join(g.ResultIdents, ", ")
+}
@@ -0,0 +1,157 @@
+package testcase
+
+import (
+	"go/ast"
+	"sort"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// SealedImplementer exported type implementing a sealed interface in the package declaring the interface
+type SealedImplementer struct {
+	TypeSpec *ast.TypeSpec
+	Pointer  *importer.PkgResolverPointer
+	// PointerReceiver indicates if methods are declared on the pointer, so a pointer has to be used
+	PointerReceiver bool
+}
+
+// IsSealedInterface checks if expression is an interface of an imported package with unexported methods,
+// which can only be implemented in its defining package
+func (g *TestCase) IsSealedInterface(e ast.Expr, pointer *importer.PkgResolverPointer) bool {
+	interfaceType, ok := e.(*ast.InterfaceType)
+	if !ok || g.PackageInfo.IsRoot(pointer) {
+		return false
+	}
+	for _, name := range interfaceMethodNames(interfaceType, map[*ast.InterfaceType]bool{}) {
+		if !ast.IsExported(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindSealedImplementer finds an exported type in the package declaring given sealed interface, which declares
+// all methods of the interface. Methods are matched by name, as the package is known to compile
+func (g *TestCase) FindSealedImplementer(interfaceType *ast.InterfaceType, pointer *importer.PkgResolverPointer) *SealedImplementer {
+	pkg := g.PackageInfo.PkgForPointer(pointer)
+	if pkg == nil {
+		return nil
+	}
+	methodNames := interfaceMethodNames(interfaceType, map[*ast.InterfaceType]bool{})
+	// Map iteration is random, sort files to keep generation deterministic
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() || typeSpec.TypeParams != nil {
+					continue
+				}
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					continue
+				}
+				declared, pointerReceiver := declaredMethodNames(g.PackageInfo.MethodsForType(pointer, typeSpec.Name.Name))
+				if !containsAll(declared, methodNames) {
+					continue
+				}
+				return &SealedImplementer{
+					TypeSpec: typeSpec,
+					Pointer: &importer.PkgResolverPointer{
+						Dir:  pointer.Dir,
+						Pkg:  pointer.Pkg,
+						File: fileName,
+					},
+					PointerReceiver: pointerReceiver,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// SealedInterfaceToValExpr creates a value for a sealed interface declared in the package of given pointer
+// using one of its implementers, false is returned if the expression is no sealed interface or no implementer is found
+func (g *TestCase) SealedInterfaceToValExpr(e ast.Expr, name string, pointer *importer.PkgResolverPointer, input *RecursionInput) (*TypeExprToValExprRes, bool) {
+	if !g.IsSealedInterface(e, pointer) {
+		return nil, false
+	}
+	implementer := g.FindSealedImplementer(e.(*ast.InterfaceType), pointer)
+	if implementer == nil {
+		g.logger().Infof("using nil for sealed interface %s, no exported implementer found in package %s", name, pointer.Pkg)
+		return nil, false
+	}
+	return g.SealedImplementerToValExpr(implementer, input), true
+}
+
+// SealedImplementerToValExpr creates a value of the implementer of a sealed interface
+func (g *TestCase) SealedImplementerToValExpr(implementer *SealedImplementer, input *RecursionInput) *TypeExprToValExprRes {
+	var e ast.Expr = implementer.TypeSpec.Name
+	if implementer.PointerReceiver {
+		e = &ast.StarExpr{X: implementer.TypeSpec.Name}
+	}
+	return g.TypeExprToValExpr(&RecursionInput{
+		e:          e,
+		varName:    input.varName,
+		pkgPointer: implementer.Pointer,
+		counter:    input.counter,
+		identList:  input.identList,
+	})
+}
+
+// interfaceMethodNames retrieves the names of the methods of an interface including the methods
+// of interfaces embedded from the same package
+func interfaceMethodNames(t *ast.InterfaceType, visited map[*ast.InterfaceType]bool) []string {
+	if t.Methods == nil || visited[t] {
+		return nil
+	}
+	visited[t] = true
+	res := []string{}
+	for _, method := range t.Methods.List {
+		switch methodType := method.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range method.Names {
+				res = append(res, name.Name)
+			}
+		case *ast.Ident:
+			if methodType.Obj == nil {
+				continue
+			}
+			if typeSpec, ok := methodType.Obj.Decl.(*ast.TypeSpec); ok {
+				if embedded, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					res = append(res, interfaceMethodNames(embedded, visited)...)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// declaredMethodNames retrieves the names of the given methods and whether any is declared on a pointer receiver
+func declaredMethodNames(methods []*ast.FuncDecl) (map[string]bool, bool) {
+	res := make(map[string]bool)
+	pointerReceiver := false
+	for _, method := range methods {
+		res[method.Name.Name] = true
+		if _, ok := method.Recv.List[0].Type.(*ast.StarExpr); ok {
+			pointerReceiver = true
+		}
+	}
+	return res, pointerReceiver
+}
+
+func containsAll(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if !set[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package testcase
+
+import (
+	"go/ast"
+)
+
+// SideEffectPrintStmts creates print statements for the receiver and the parameters referring to shared memory,
+// which are executed after calling the function, so the mutations of its arguments are asserted
+func (g *TestCase) SideEffectPrintStmts(recvIdents, paramIdents []*ast.Ident) []ast.Stmt {
+	if !g.Opts.SideEffectAssertions {
+		return []ast.Stmt{}
+	}
+	res := []ast.Stmt{}
+	res = append(res, g.argPrintStmts(g.FuncDecl.Recv, recvIdents)...)
+	// The parameters of pure functions are already asserted to be unchanged
+	if !g.IsPure() {
+		res = append(res, g.argPrintStmts(g.FuncDecl.Type.Params, paramIdents)...)
+	}
+	return res
+}
+
+// argPrintStmts creates print statements for the arguments of given field list referring to shared memory
+func (g *TestCase) argPrintStmts(fields *ast.FieldList, idents []*ast.Ident) []ast.Stmt {
+	argTypes := fieldTypes(fields)
+	// e.g. receivers specified by decorator values are a single value
+	if len(argTypes) != len(idents) {
+		g.logger().Debugf("unable to assert side effects on the arguments of func %s", g.FuncDecl.Name.Name)
+		return []ast.Stmt{}
+	}
+	res := []ast.Stmt{}
+	for i, argType := range argTypes {
+		if !IsAliasable(argType) {
+			continue
+		}
+		res = append(res, g.ResultToPrintStmt(NewPrintRecursionInput(argType, idents[i].Name, g.Pointer)).Stmts...)
+	}
+	return res
+}
+
+// fieldTypes retrieves the type of every name in given field list
+func fieldTypes(fields *ast.FieldList) []ast.Expr {
+	res := []ast.Expr{}
+	if fields == nil {
+		return res
+	}
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			res = append(res, field.Type)
+			continue
+		}
+		for range field.Names {
+			res = append(res, field.Type)
+		}
+	}
+	return res
+}
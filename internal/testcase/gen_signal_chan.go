@@ -0,0 +1,68 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// IsSignalChan checks if given chan type is a channel used for signalling which can be received from,
+// e.g. a done channel of type <-chan struct{}
+func IsSignalChan(t *ast.ChanType) bool {
+	if t.Dir == ast.SEND {
+		return false
+	}
+	structType, ok := t.Value.(*ast.StructType)
+	return ok && (structType.Fields == nil || len(structType.Fields.List) == 0)
+}
+
+// SignalChanToValExpr creates a signal channel on which receiving doesn't block, either by closing it
+// or by sending a signal on a buffered channel
+func (g *TestCase) SignalChanToValExpr(input *RecursionInput) *TypeExprToValExprRes {
+	newIdent := g.Opts.IdentGen.Create(input.identList.Current())
+	chanType := &ast.ChanType{
+		Dir:   ast.SEND | ast.RECV,
+		Value: emptyStructType(),
+	}
+	if g.Opts.ValTestCase.ClosedSignalChan() {
+		return &TypeExprToValExprRes{
+			Expr: newIdent,
+			Statements: []ast.Stmt{
+				assignStmt(newIdent, &ast.CallExpr{
+					Fun:  &ast.Ident{Name: "make"},
+					Args: []ast.Expr{chanType},
+				}),
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun:  &ast.Ident{Name: "close"},
+						Args: []ast.Expr{newIdent},
+					},
+				},
+			},
+			Declarations: []ast.Decl{},
+		}
+	}
+	return &TypeExprToValExprRes{
+		Expr: newIdent,
+		Statements: []ast.Stmt{
+			assignStmt(newIdent, &ast.CallExpr{
+				Fun:  &ast.Ident{Name: "make"},
+				Args: []ast.Expr{chanType, &ast.BasicLit{Kind: token.INT, Value: "1"}},
+			}),
+			&ast.SendStmt{
+				Chan:  newIdent,
+				Value: &ast.CompositeLit{Type: emptyStructType()},
+			},
+		},
+		Declarations: []ast.Decl{},
+	}
+}
+
+// emptyStructType creates the type struct{}, the braces are given a position so they're printed on a single line
+func emptyStructType() *ast.StructType {
+	return &ast.StructType{
+		Fields: &ast.FieldList{
+			Opening: 1,
+			Closing: 1,
+		},
+	}
+}
@@ -0,0 +1,12 @@
+package testcase
+
+// SkipsFieldAssertion checks if a struct field isn't asserted, because its name matches one of the
+// patterns of non deterministic fields e.g. .*ID$ or .*At$
+func (g *TestCase) SkipsFieldAssertion(name string) bool {
+	for _, re := range g.Opts.SkipAssertFields {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package testcase
+
+import (
+	"go/ast"
+)
+
+// IsComparableSlice checks if an array or slice type has elements of a basic type, so it can be asserted
+// as a whole using its Go syntax representation
+func (g *TestCase) IsComparableSlice(t *ast.ArrayType) bool {
+	ident, ok := t.Elt.(*ast.Ident)
+	return ok && ident.Obj == nil && g.IsBasicLit(ident.Name)
+}
+
+// WholeSliceToPrintStmt converts a slice of comparable elements to a print statement of its Go syntax
+// representation, which is asserted using a single Equal assertion instead of asserting every element
+func (g *TestCase) WholeSliceToPrintStmt(t *ast.ArrayType, input *PrintRecursionInput) *PrintResult {
+	elemType, _ := t.Elt.(*ast.Ident)
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "type_name": "%s", "pkg": "%s", "val": %#v}`),
+		BasicLitString("slice"),
+		BasicLitString(input.varName),
+		BasicLitString(elemType.Name),
+		BasicLitString(g.Pointer.Pkg),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "fmt"},
+				Sel: &ast.Ident{Name: "Sprintf"},
+			},
+			Args: []ast.Expr{
+				BasicLitString("%#v"),
+				&ast.Ident{Name: input.varName},
+			},
+		},
+	}))
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
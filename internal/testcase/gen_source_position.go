@@ -0,0 +1,20 @@
+package testcase
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// SourcePosition retrieves the position of the function under test in its source file e.g. sum.go:5,
+// an empty string is returned if source positions are disabled or the position is unknown
+func (g *TestCase) SourcePosition() string {
+	if !g.Opts.SourcePositions || g.PackageInfo == nil || g.PackageInfo.FileSet == nil || !g.FuncDecl.Pos().IsValid() {
+		return ""
+	}
+	position := g.PackageInfo.FileSet.Position(g.FuncDecl.Pos())
+	// Synthesized declarations, e.g. of promoted methods, are positioned in other files
+	if filepath.Clean(position.Filename) != filepath.Clean(g.Pointer.File) {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line)
+}
@@ -0,0 +1,60 @@
+package testcase
+
+import (
+	"go/ast"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsStringer checks if the type declared by given type spec implements fmt.Stringer. Methods with a pointer
+// receiver are only callable on addressable values, so they're only used if the value is stored in a variable
+func (g *TestCase) IsStringer(typeSpec *ast.TypeSpec, pointer *importer.PkgResolverPointer, addressable bool) bool {
+	if typeSpec.TypeParams != nil {
+		return false
+	}
+	for _, method := range g.PackageInfo.MethodsForType(pointer, typeSpec.Name.Name) {
+		if method.Name.Name != "String" || !isStringFunc(method.Type) {
+			continue
+		}
+		if _, ok := method.Recv.List[0].Type.(*ast.StarExpr); ok {
+			return addressable
+		}
+		return true
+	}
+	return false
+}
+
+// isStringFunc checks if function type matches String() string
+func isStringFunc(funcType *ast.FuncType) bool {
+	if len(funcType.Params.List) != 0 {
+		return false
+	}
+	if funcType.Results == nil || len(funcType.Results.List) != 1 || len(funcType.Results.List[0].Names) > 1 {
+		return false
+	}
+	result, ok := funcType.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "string"
+}
+
+// StringerToPrintStmt creates a print statement printing the result of the String method of a value,
+// which is asserted instead of the value itself
+func (g *TestCase) StringerToPrintStmt(input *PrintRecursionInput) *PrintResult {
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "val": %#v}`),
+		BasicLitString("stringer"),
+		BasicLitString(input.varName),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: input.varName},
+				Sel: &ast.Ident{Name: "String"},
+			},
+		},
+	}))
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
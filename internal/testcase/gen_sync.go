@@ -0,0 +1,66 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// syncTypes types of the sync package which are ready to use as zero value
+var syncTypes = []string{"Map", "Mutex", "Once", "Pool", "RWMutex", "WaitGroup"}
+
+// IsSyncType checks if selector expression refers to a type of the sync package which is ready to use as zero value
+func (g *TestCase) IsSyncType(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	for _, name := range syncTypes {
+		if g.IsImportedType(t, pointer, "sync", name) {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncTypeToValExpr creates the zero value of a sync type e.g. sync.Map{}, as the default struct walk would try
+// to set the unexported fields. Pointers are created directly e.g. &sync.Map{}, such that no lock value is copied.
+// If enabled, pointers to a sync.Map are populated using Store calls
+func (g *TestCase) SyncTypeToValExpr(t *ast.SelectorExpr, input *RecursionInput, isPointer bool) *TypeExprToValExprRes {
+	zero := &ast.CompositeLit{Type: t}
+	if !isPointer {
+		return &TypeExprToValExprRes{
+			Expr:         zero,
+			Statements:   []ast.Stmt{},
+			Declarations: []ast.Decl{},
+		}
+	}
+	pointerExpr := &ast.UnaryExpr{Op: token.AND, X: zero}
+	if t.Sel.Name != "Map" || !g.Opts.SyncMapEntries {
+		return &TypeExprToValExprRes{
+			Expr:         pointerExpr,
+			Statements:   []ast.Stmt{},
+			Declarations: []ast.Decl{},
+		}
+	}
+	syncIdent := g.Opts.IdentGen.Create(&ast.Ident{
+		Name: "sync" + cases.Title(language.English).String(input.identList.Previous().Name),
+	})
+	stmts := []ast.Stmt{assignStmt(syncIdent, pointerExpr)}
+	for i := 0; i < g.Opts.ValTestCase.MapLen(); i++ {
+		// e.g. syncM.Store("key", 42)
+		stmts = append(stmts, &ast.ExprStmt{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   syncIdent,
+					Sel: &ast.Ident{Name: "Store"},
+				},
+				Args: []ast.Expr{g.BasicExprToValExpr("string"), g.BasicExprToValExpr("int")},
+			},
+		})
+	}
+	return &TypeExprToValExprRes{
+		Expr:         syncIdent,
+		Statements:   stmts,
+		Declarations: []ast.Decl{},
+	}
+}
@@ -0,0 +1,103 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsOSFile checks if selector expression refers to os.File
+func (g *TestCase) IsOSFile(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "os", "File")
+}
+
+// TempFileToValExpr creates a temp file filled with generated content using os.CreateTemp, which is
+// closed and removed after the test using t.Cleanup. The offset of the file is reset to its start,
+// so the function under test reads the generated content
+func (g *TestCase) TempFileToValExpr(input *RecursionInput, isPointer bool) *TypeExprToValExprRes {
+	fileIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "file"})
+	errIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "err"})
+	osIdent := &ast.Ident{Name: "os"}
+	stmts := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{fileIdent, errIdent},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: osIdent, Sel: &ast.Ident{Name: "CreateTemp"}},
+				Args: []ast.Expr{
+					&ast.BasicLit{Kind: token.STRING, Value: `""`},
+					&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(input.varName + "-*")},
+				},
+			}},
+		},
+		requireNoErrorStmt(errIdent),
+		&ast.ExprStmt{X: suiteTCall("Cleanup", &ast.FuncLit{
+			Type: &ast.FuncType{Params: &ast.FieldList{}},
+			Body: &ast.BlockStmt{List: []ast.Stmt{
+				&ast.ExprStmt{X: &ast.CallExpr{Fun: &ast.SelectorExpr{X: fileIdent, Sel: &ast.Ident{Name: "Close"}}}},
+				&ast.ExprStmt{X: &ast.CallExpr{
+					Fun:  &ast.SelectorExpr{X: osIdent, Sel: &ast.Ident{Name: "Remove"}},
+					Args: []ast.Expr{&ast.CallExpr{Fun: &ast.SelectorExpr{X: fileIdent, Sel: &ast.Ident{Name: "Name"}}}},
+				}},
+			}},
+		})},
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.Ident{Name: "_"}, errIdent},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun:  &ast.SelectorExpr{X: fileIdent, Sel: &ast.Ident{Name: "WriteString"}},
+				Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: g.Opts.ValTestCase.String()}},
+			}},
+		},
+		requireNoErrorStmt(errIdent),
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{&ast.Ident{Name: "_"}, errIdent},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: fileIdent, Sel: &ast.Ident{Name: "Seek"}},
+				Args: []ast.Expr{
+					&ast.BasicLit{Kind: token.INT, Value: "0"},
+					&ast.SelectorExpr{X: &ast.Ident{Name: "io"}, Sel: &ast.Ident{Name: "SeekStart"}},
+				},
+			}},
+		},
+		requireNoErrorStmt(errIdent),
+	}
+	var expr ast.Expr = fileIdent
+	if !isPointer {
+		expr = &ast.StarExpr{X: fileIdent}
+	}
+	return &TypeExprToValExprRes{
+		Expr:         expr,
+		Statements:   stmts,
+		Declarations: []ast.Decl{},
+	}
+}
+
+// suiteTCall creates a call of a method of the testing.T of the suite, e.g. s.T().Cleanup(f)
+func suiteTCall(method string, args ...ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "s"}, Sel: &ast.Ident{Name: "T"}},
+			},
+			Sel: &ast.Ident{Name: method},
+		},
+		Args: args,
+	}
+}
+
+// requireNoErrorStmt creates a statement requiring given error to be nil, e.g. s.Require().NoError(err)
+func requireNoErrorStmt(errIdent *ast.Ident) ast.Stmt {
+	return &ast.ExprStmt{X: &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{X: &ast.Ident{Name: "s"}, Sel: &ast.Ident{Name: "Require"}},
+			},
+			Sel: &ast.Ident{Name: "NoError"},
+		},
+		Args: []ast.Expr{errIdent},
+	}}
+}
@@ -0,0 +1,52 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// IsTemplate checks if selector expression refers to template.Template of text/template or html/template
+func (g *TestCase) IsTemplate(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer) bool {
+	return g.IsImportedType(t, pointer, "text/template", "Template") ||
+		g.IsImportedType(t, pointer, "html/template", "Template")
+}
+
+// TemplateToValExpr creates a template by parsing a generated valid template text,
+// e.g. template.Must(template.New("tmpl").Parse("Hello {{.}}")), as filling its fields results in an invalid template
+func (g *TestCase) TemplateToValExpr(t *ast.SelectorExpr, input *RecursionInput, isPointer bool) *TypeExprToValExprRes {
+	// The package may be imported under another name in the test file
+	pkg := &ast.Ident{Name: "template"}
+	if corrected, ok := g.CorrectTypeExpr(t, input).(*ast.SelectorExpr); ok {
+		if ident, ok := corrected.X.(*ast.Ident); ok {
+			pkg = ident
+		}
+	}
+	newCall := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: pkg, Sel: &ast.Ident{Name: "New"}},
+		Args: []ast.Expr{&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: strconv.Quote(input.varName),
+		}},
+	}
+	var expr ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{X: pkg, Sel: &ast.Ident{Name: "Must"}},
+		Args: []ast.Expr{&ast.CallExpr{
+			Fun: &ast.SelectorExpr{X: newCall, Sel: &ast.Ident{Name: "Parse"}},
+			Args: []ast.Expr{&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: strconv.Quote(g.Opts.ValTestCase.TemplateText()),
+			}},
+		}},
+	}
+	if !isPointer {
+		expr = &ast.StarExpr{X: expr}
+	}
+	return &TypeExprToValExprRes{
+		Expr:         expr,
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+}
@@ -0,0 +1,191 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// HasTextUnmarshaler checks if the type declared by given type spec implements encoding.TextUnmarshaler
+// using a pointer receiver, so a value can be created using the validation of the type itself
+func (g *TestCase) HasTextUnmarshaler(typeSpec *ast.TypeSpec, input *RecursionInput) bool {
+	// Unexported types of other packages can't be declared in a test case
+	if !g.PackageInfo.IsRoot(input.pkgPointer) && !typeSpec.Name.IsExported() {
+		return false
+	}
+	pkg := g.PackageInfo.PkgForPointer(input.pkgPointer)
+	if pkg == nil {
+		return false
+	}
+	for _, f := range pkg.Files {
+		for _, decl := range f.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Name.Name != "UnmarshalText" || funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 {
+				continue
+			}
+			starExpr, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			recvIdent, ok := starExpr.X.(*ast.Ident)
+			if !ok || recvIdent.Name != typeSpec.Name.Name {
+				continue
+			}
+			return isUnmarshalTextFunc(funcDecl.Type)
+		}
+	}
+	return false
+}
+
+// isUnmarshalTextFunc checks if function type matches UnmarshalText(text []byte) error
+func isUnmarshalTextFunc(funcType *ast.FuncType) bool {
+	if len(funcType.Params.List) != 1 || len(funcType.Params.List[0].Names) > 1 {
+		return false
+	}
+	arrayType, ok := funcType.Params.List[0].Type.(*ast.ArrayType)
+	if !ok || arrayType.Len != nil {
+		return false
+	}
+	elt, ok := arrayType.Elt.(*ast.Ident)
+	if !ok || elt.Name != "byte" {
+		return false
+	}
+	if funcType.Results == nil || len(funcType.Results.List) != 1 || len(funcType.Results.List[0].Names) > 1 {
+		return false
+	}
+	result, ok := funcType.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "error"
+}
+
+// TextUnmarshalerToValExpr creates a value for a type implementing encoding.TextUnmarshaler
+// by unmarshalling a text into a zero value of the type. If the string is rejected by the type,
+// the value created by given fallback is used, the test case is skipped if there is no fallback
+func (g *TestCase) TextUnmarshalerToValExpr(typeSpec *ast.TypeSpec, input *RecursionInput, fallback *TypeExprToValExprRes) *TypeExprToValExprRes {
+	identTemp := g.Opts.IdentGen.Create(&ast.Ident{
+		Name: "text" + cases.Title(language.English).String(input.identList.Current().Name),
+	})
+	declStmt := &ast.DeclStmt{
+		Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{
+				&ast.ValueSpec{
+					Names: []*ast.Ident{identTemp},
+					Type:  g.CorrectTypeExpr(typeSpec.Name, input),
+				},
+			},
+		},
+	}
+	errIdent := &ast.Ident{Name: "err"}
+	var unmarshalStmt *ast.IfStmt
+	if IsEmptyExpr(fallback.Expr) {
+		unmarshalStmt = skipOnErrorStmt(errIdent, typeSpec.Name.Name+".UnmarshalText").(*ast.IfStmt)
+		fallback = EmptyResult()
+	} else {
+		unmarshalStmt = &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  errIdent,
+				Op: token.NEQ,
+				Y:  &ast.Ident{Name: "nil"},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{identTemp},
+						Tok: token.ASSIGN,
+						Rhs: []ast.Expr{fallback.Expr},
+					},
+				},
+			},
+		}
+	}
+	unmarshalStmt.Init = &ast.AssignStmt{
+		Lhs: []ast.Expr{errIdent},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   identTemp,
+					Sel: &ast.Ident{Name: "UnmarshalText"},
+				},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.ArrayType{
+							Elt: &ast.Ident{Name: "byte"},
+						},
+						Args: []ast.Expr{
+							g.unmarshalText(typeSpec, input),
+						},
+					},
+				},
+			},
+		},
+	}
+	res := &TypeExprToValExprRes{
+		Expr:         identTemp,
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+	// The statements of the fallback precede the unmarshalling, so its value can be assigned on error
+	res.Merge(fallback)
+	res.Statements = append(res.Statements, declStmt, unmarshalStmt)
+	return res
+}
+
+// unmarshalText retrieves the text which is unmarshalled into a value of the type declared by given type spec.
+// Texts of the corpus and string constants of the type are valid more often than generated strings
+func (g *TestCase) unmarshalText(typeSpec *ast.TypeSpec, input *RecursionInput) ast.Expr {
+	typeName := typeSpec.Name.Name
+	if !g.PackageInfo.IsRoot(input.pkgPointer) {
+		typeName = input.pkgPointer.Pkg + "." + typeName
+	}
+	texts := append(append([]ast.Expr{}, g.Opts.Corpus.Get(typeName)...), g.typedStringConsts(typeSpec, input)...)
+	if index := g.Opts.ValTestCase.CorpusIndex(len(texts)); index != -1 {
+		return texts[index]
+	}
+	return &ast.BasicLit{
+		Kind:  token.STRING,
+		Value: g.Opts.ValTestCase.String(),
+	}
+}
+
+// typedStringConsts retrieves the string literals of the constants declared with the type of given type spec,
+// e.g. const Red Color = "red"
+func (g *TestCase) typedStringConsts(typeSpec *ast.TypeSpec, input *RecursionInput) []ast.Expr {
+	pkg := g.PackageInfo.PkgForPointer(input.pkgPointer)
+	if pkg == nil {
+		return nil
+	}
+	// Files are iterated in order, so the same constants are used for the same seed
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	res := []ast.Expr{}
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				if ident, ok := valueSpec.Type.(*ast.Ident); !ok || ident.Name != typeSpec.Name.Name {
+					continue
+				}
+				for _, value := range valueSpec.Values {
+					if basicLit, ok := value.(*ast.BasicLit); ok && basicLit.Kind == token.STRING {
+						res = append(res, &ast.BasicLit{Kind: token.STRING, Value: basicLit.Value})
+					}
+				}
+			}
+		}
+	}
+	return res
+}
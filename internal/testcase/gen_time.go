@@ -0,0 +1,66 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// TimeToPrintStmt converts a time.Time to print statements, the instant is printed as seconds and nanoseconds,
+// as nanoseconds since the epoch are undefined for the zero time and times outside the years 1678 to 2262.
+// It's printed together with whether it's the zero time or close to the current time, e.g. when it's set using time.Now
+func (g *TestCase) TimeToPrintStmt(t *ast.SelectorExpr, input *PrintRecursionInput) *PrintResult {
+	since := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   t.X,
+			Sel: &ast.Ident{Name: "Since"},
+		},
+		Args: []ast.Expr{&ast.Ident{Name: input.varName}},
+	}
+	second := &ast.SelectorExpr{
+		X:   t.X,
+		Sel: &ast.Ident{Name: "Second"},
+	}
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "val": "%d.%09d", "zero": %t, "now": %t}`),
+		BasicLitString("time"),
+		BasicLitString(input.varName),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: input.varName},
+				Sel: &ast.Ident{Name: "Unix"},
+			},
+		},
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: input.varName},
+				Sel: &ast.Ident{Name: "Nanosecond"},
+			},
+		},
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: input.varName},
+				Sel: &ast.Ident{Name: "IsZero"},
+			},
+		},
+		&ast.BinaryExpr{
+			X: &ast.BinaryExpr{
+				X:  since,
+				Op: token.LSS,
+				Y:  second,
+			},
+			Op: token.LAND,
+			Y: &ast.BinaryExpr{
+				X:  since,
+				Op: token.GTR,
+				Y:  &ast.UnaryExpr{Op: token.SUB, X: second},
+			},
+		},
+	}))
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
@@ -2,8 +2,6 @@ package testcase
 
 import (
 	"go/ast"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // CorrectTypeExpr corrects type expressions for imports
@@ -15,7 +13,7 @@ func (g *TestCase) CorrectTypeExpr(e ast.Expr, input *RecursionInput) ast.Expr {
 			Elt: g.CorrectTypeExpr(t.Elt, input),
 		}
 	case *ast.Ident:
-		if g.IsBasicLit(t.Name) || g.IsError(t.Name) {
+		if g.IsBasicLit(t.Name) || g.IsError(t.Name) || g.IsAny(t.Name) {
 			return t
 		}
 		if !g.PackageInfo.IsRoot(input.pkgPointer) {
@@ -42,9 +40,27 @@ func (g *TestCase) CorrectTypeExpr(e ast.Expr, input *RecursionInput) ast.Expr {
 			Key:   g.CorrectTypeExpr(t.Key, input),
 		}
 	case *ast.SelectorExpr:
+		// Self qualified selectors are corrected as identifiers of the current package
+		if selectorIdent, ok := t.X.(*ast.Ident); ok && g.PackageInfo.IsSelfQualified(input.pkgPointer, selectorIdent.Name) {
+			return g.CorrectTypeExpr(t.Sel, input)
+		}
 		return e
 	case *ast.BasicLit:
 		return e
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{
+			X:     g.CorrectTypeExpr(t.X, input),
+			Index: g.CorrectTypeExpr(t.Index, input),
+		}
+	case *ast.IndexListExpr:
+		indices := []ast.Expr{}
+		for _, index := range t.Indices {
+			indices = append(indices, g.CorrectTypeExpr(index, input))
+		}
+		return &ast.IndexListExpr{
+			X:       g.CorrectTypeExpr(t.X, input),
+			Indices: indices,
+		}
 	case *ast.CompositeLit:
 		return &ast.CompositeLit{
 			Type:       g.CorrectTypeExpr(t.Type, input),
@@ -134,7 +150,7 @@ func (g *TestCase) CorrectTypeExpr(e ast.Expr, input *RecursionInput) ast.Expr {
 			Elt:      g.CorrectTypeExpr(t.Elt, input),
 		}
 	default:
-		log.Warningf("unable to correct type:  %T", t)
+		g.logger().Warningf("unable to correct type:  %T", t)
 	}
 	return e
 }
@@ -0,0 +1,22 @@
+package testcase
+
+import (
+	"go/ast"
+)
+
+// TypedNil creates a typed nil pointer of given interface implementation e.g. (*testReader)(nil) with a probability
+// of the typed nil bias. The interface holding it isn't nil, which exercises the nil handling of the function.
+// The choice is recorded in the typed nils of the test case. No typed nils are used if pointers are required to be non nil
+func (g *TestCase) TypedNil(implIdent *ast.Ident) (ast.Expr, bool) {
+	if g.Opts.NonNilPointers || !g.Opts.ValTestCase.TypedNil(g.Opts.TypedNilBias) {
+		return nil, false
+	}
+	g.TypedNils = append(g.TypedNils, implIdent.Name)
+	g.logger().Debugf("using typed nil %s as interface value in func %s", implIdent.Name, g.FuncDecl.Name.Name)
+	return &ast.CallExpr{
+		Fun: &ast.ParenExpr{
+			X: &ast.StarExpr{X: implIdent},
+		},
+		Args: []ast.Expr{&ast.Ident{Name: "nil"}},
+	}, true
+}
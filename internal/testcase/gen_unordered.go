@@ -0,0 +1,118 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"path/filepath"
+
+	"github.com/wimspaargaren/final-unit/internal/utils"
+)
+
+// IsUnordered checks if the order of the slices returned by the function under test is marked irrelevant
+// by an unordered directive
+func (g *TestCase) IsUnordered() bool {
+	_, fileName := filepath.Split(g.Pointer.File)
+	return g.Deco.IsUnordered(fileName, g.FuncDecl.Name.Name)
+}
+
+// UnorderedSliceToPrintStmt converts a slice of comparable elements to a print statement of the Go syntax
+// representation of a sorted copy, so the captured output doesn't depend on map iteration order and is asserted
+// using ElementsMatch
+func (g *TestCase) UnorderedSliceToPrintStmt(t *ast.ArrayType, input *PrintRecursionInput) *PrintResult {
+	elemType, _ := t.Elt.(*ast.Ident)
+	sortedIdent := &ast.Ident{
+		Name: utils.LowerCaseFirstLetter(g.Opts.VarTestCase.Generate()),
+	}
+	sortedIndex := func(name string) ast.Expr {
+		return &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "fmt"},
+				Sel: &ast.Ident{Name: "Sprint"},
+			},
+			Args: []ast.Expr{
+				&ast.IndexExpr{X: sortedIdent, Index: &ast.Ident{Name: name}},
+			},
+		}
+	}
+	// sorted := append([]T(nil), value...)
+	copyStmt := &ast.AssignStmt{
+		Lhs: []ast.Expr{sortedIdent},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.Ident{Name: "append"},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun:  &ast.ArrayType{Elt: elemType},
+						Args: []ast.Expr{&ast.Ident{Name: "nil"}},
+					},
+					&ast.Ident{Name: input.varName},
+				},
+				Ellipsis: 1,
+			},
+		},
+	}
+	// sort.Slice(sorted, func(i, j int) bool { return fmt.Sprint(sorted[i]) < fmt.Sprint(sorted[j]) })
+	sortStmt := &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "sort"},
+				Sel: &ast.Ident{Name: "Slice"},
+			},
+			Args: []ast.Expr{
+				sortedIdent,
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{{Name: "i"}, {Name: "j"}},
+									Type:  &ast.Ident{Name: "int"},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{{Type: &ast.Ident{Name: "bool"}}},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.BinaryExpr{X: sortedIndex("i"), Op: token.LSS, Y: sortedIndex("j")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	printStmt := CreatePrintfStmt([]ast.Expr{
+		BasicLitString(`{ "type": "%s", "var_name": "%s", "type_name": "%s", "pkg": "%s", "val": %#v}`),
+		BasicLitString("unordered"),
+		BasicLitString(input.varName),
+		BasicLitString(elemType.Name),
+		BasicLitString(g.Pointer.Pkg),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "fmt"},
+				Sel: &ast.Ident{Name: "Sprintf"},
+			},
+			Args: []ast.Expr{
+				BasicLitString("%#v"),
+				sortedIdent,
+			},
+		},
+	})
+	res := []ast.Stmt{}
+	res = append(res, input.prefix...)
+	res = append(res, &ast.BlockStmt{
+		List: []ast.Stmt{copyStmt, sortStmt, printStmt},
+	})
+	res = append(res, input.suffix...)
+	res = append(res, Println())
+	return &PrintResult{
+		Stmts: res,
+	}
+}
@@ -2,8 +2,11 @@ package testcase
 
 import (
 	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
 
-	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/importer"
 )
 
 // IsBasicLit reports if an idenetifier is a basic literal
@@ -39,6 +42,11 @@ func (g *TestCase) IsError(identifier string) bool {
 	return identifier == "error"
 }
 
+// IsAny checks if identifier is the predeclared any alias of the empty interface
+func (g *TestCase) IsAny(identifier string) bool {
+	return identifier == "any"
+}
+
 // IsBasicExpr checks if expr is basic expr
 func (g *TestCase) IsBasicExpr(x ast.Expr) (string, bool) {
 	if t, ok := x.(*ast.Ident); ok {
@@ -51,6 +59,23 @@ func (g *TestCase) IsBasicExpr(x ast.Expr) (string, bool) {
 	return "", false
 }
 
+// IsImportedType checks if selector expression refers to the type with given name of the package with given import path
+func (g *TestCase) IsImportedType(t *ast.SelectorExpr, pointer *importer.PkgResolverPointer, path, name string) bool {
+	selectorIdent, ok := t.X.(*ast.Ident)
+	if !ok || t.Sel.Name != name {
+		return false
+	}
+	f := g.PackageInfo.FileForPointer(pointer)
+	if f == nil {
+		return false
+	}
+	importSpec, err := g.PackageInfo.GetImportSpecForIdentifierAndFile(selectorIdent.Name, f)
+	if err != nil {
+		return false
+	}
+	return strings.Trim(importSpec.Path.Value, `"`) == path
+}
+
 // GetUnnamedStructIdent retrieves an identifier for an unnamed struct field
 func (g *TestCase) GetUnnamedStructIdent(fieldType ast.Expr, input *RecursionInput) *ast.Ident {
 	switch t := fieldType.(type) {
@@ -61,7 +86,28 @@ func (g *TestCase) GetUnnamedStructIdent(fieldType ast.Expr, input *RecursionInp
 	case *ast.SelectorExpr:
 		return t.Sel
 	default:
-		log.Warningf("unable to get unnamed struct field")
+		g.logger().Warningf("unable to get unnamed struct field")
 		return &ast.Ident{}
 	}
 }
+
+// HasJSONOmitEmpty checks if a struct field is tagged with the json omitempty option
+func (g *TestCase) HasJSONOmitEmpty(field *ast.Field) bool {
+	if field.Tag == nil {
+		return false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return false
+	}
+	jsonTag, ok := reflect.StructTag(tag).Lookup("json")
+	if !ok {
+		return false
+	}
+	for _, option := range strings.Split(jsonTag, ",")[1:] {
+		if option == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package testcase
+
+// valueBudget remaining amount of collection elements a value may consist of
+type valueBudget struct {
+	set       bool
+	remaining int
+}
+
+// spendBudget limits the length of a collection to the remaining value budget, the remaining budget
+// is divided over the values created per element, e.g. both key and value of a map entry,
+// so the total amount of elements of nested collections stays bounded. The cycle info of the
+// element values is returned
+func (g *TestCase) spendBudget(input *RecursionInput, length, valuesPerElement int) (int, CycleInfo) {
+	counter := input.counter
+	if g.Opts.ValueBudget <= 0 {
+		return length, counter
+	}
+	budget := counter.budget
+	if !budget.set {
+		budget = valueBudget{set: true, remaining: g.Opts.ValueBudget}
+	}
+	if length > budget.remaining {
+		length = budget.remaining
+	}
+	if length == 0 {
+		return 0, counter
+	}
+	counter.budget = valueBudget{
+		set:       true,
+		remaining: (budget.remaining - length) / (length * valuesPerElement),
+	}
+	return length, counter
+}
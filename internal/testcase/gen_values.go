@@ -3,8 +3,6 @@ package testcase
 import (
 	"go/ast"
 	"go/token"
-
-	log "github.com/sirupsen/logrus"
 )
 
 // InterfaceGenDecl creates interface gen decl
@@ -47,6 +45,11 @@ func (g *TestCase) ErrExprToValExpr() *TypeExprToValExprRes {
 			},
 		}
 	}
+	return errFuncLit(errReturn)
+}
+
+// errFuncLit creates a function literal returning given error, which is called directly
+func errFuncLit(errReturn ast.Expr) *TypeExprToValExprRes {
 	// Create function returning either nil or an error
 	e := &ast.CallExpr{
 		Fun: &ast.FuncLit{
@@ -81,6 +84,12 @@ func (g *TestCase) ErrExprToValExpr() *TypeExprToValExprRes {
 
 // BasicExprToValExpr converts a basic identifier to an expression
 func (g *TestCase) BasicExprToValExpr(identifier string) ast.Expr { // nolint: gocyclo
+	// Values of the corpus are more realistic than random values
+	if corpusVals := g.Opts.Corpus.Get(identifier); len(corpusVals) > 0 {
+		if index := g.Opts.ValTestCase.CorpusIndex(len(corpusVals)); index != -1 {
+			return corpusVals[index]
+		}
+	}
 	switch identifier {
 	case "int":
 		return &ast.BasicLit{
@@ -142,7 +151,7 @@ func (g *TestCase) BasicExprToValExpr(identifier string) ast.Expr { // nolint: g
 	case "complex128":
 		return g.numericBasicType(identifier, g.Opts.ValTestCase.Complex128())
 	default:
-		log.Warningf("basic lit not implemented yet: %s", identifier)
+		g.logger().Warningf("basic lit not implemented yet: %s", identifier)
 	}
 	return &ast.BasicLit{}
 }
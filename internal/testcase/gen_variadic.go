@@ -0,0 +1,72 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// spreadPos position of the ellipsis of a spread call, any valid position makes the printer add it e.g. f(xs...)
+const spreadPos token.Pos = 1
+
+// IsVariadic checks if the last parameter of given function type is variadic e.g. func(items ...Item)
+func IsVariadic(funcType *ast.FuncType) bool {
+	if funcType.Params == nil || len(funcType.Params.List) == 0 {
+		return false
+	}
+	_, ok := funcType.Params.List[len(funcType.Params.List)-1].Type.(*ast.Ellipsis)
+	return ok
+}
+
+// SpreadVariadic spreads the argument of the variadic parameter in the call of given function, e.g. f(items...),
+// as the values of variadic parameters are generated as a slice
+func SpreadVariadic(callExpr *ast.CallExpr, funcType *ast.FuncType) {
+	if IsVariadic(funcType) && len(callExpr.Args) > 0 {
+		callExpr.Ellipsis = spreadPos
+	}
+}
+
+// VariadicToValExpr converts a variadic parameter type e.g. ...Item to a slice value e.g. []Item{Item("foo")},
+// which is spread in the call of the function, so the function receives multiple elements of the correct type
+func (g *TestCase) VariadicToValExpr(t *ast.Ellipsis, input *RecursionInput) *TypeExprToValExprRes {
+	return g.TypeExprToValExpr(&RecursionInput{
+		e:          &ast.ArrayType{Elt: t.Elt},
+		counter:    input.counter,
+		pkgPointer: input.pkgPointer,
+		varName:    input.varName,
+		identList:  input.identList,
+	})
+}
+
+// variadicElemType retrieves the element type of a variadic parameter type, other types are returned as is
+func variadicElemType(e ast.Expr) ast.Expr {
+	if t, ok := e.(*ast.Ellipsis); ok {
+		return t.Elt
+	}
+	return e
+}
+
+// variadicElemIdent retrieves the identifier a single value forced for a parameter is assigned to,
+// for a variadic parameter this is a separate identifier of the only element of the spread slice
+func (g *TestCase) variadicElemIdent(e ast.Expr, ident *ast.Ident) *ast.Ident {
+	if _, ok := e.(*ast.Ellipsis); !ok {
+		return ident
+	}
+	return g.Opts.IdentGen.Create(&ast.Ident{Name: ident.Name + "Elem"})
+}
+
+// variadicSliceStmts creates the statement assigning the slice containing the single element of a variadic parameter
+// e.g. items := []Item{itemsElem}, no statements are created for other parameters
+func (g *TestCase) variadicSliceStmts(e ast.Expr, ident, elemIdent *ast.Ident, pointer *importer.PkgResolverPointer) []ast.Stmt {
+	t, ok := e.(*ast.Ellipsis)
+	if !ok {
+		return []ast.Stmt{}
+	}
+	return []ast.Stmt{
+		assignStmt(ident, &ast.CompositeLit{
+			Type: g.CorrectTypeExpr(&ast.ArrayType{Elt: t.Elt}, &RecursionInput{pkgPointer: pointer}),
+			Elts: []ast.Expr{elemIdent},
+		}),
+	}
+}
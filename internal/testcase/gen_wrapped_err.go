@@ -0,0 +1,113 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/wimspaargaren/final-unit/internal/importer"
+)
+
+// WrappedErrExprToValExpr converts error expression to a function returning either nil or an error
+// created with fmt.Errorf wrapping an inner error, which is an error type of the package under test if available
+func (g *TestCase) WrappedErrExprToValExpr(input *RecursionInput) *TypeExprToValExprRes {
+	if !g.Opts.ValTestCase.Error() {
+		return errFuncLit(&ast.Ident{Name: "nil"})
+	}
+	res := &TypeExprToValExprRes{
+		Statements:   []ast.Stmt{},
+		Declarations: []ast.Decl{},
+	}
+	var inner ast.Expr
+	errorTypes := g.FindErrorTypes()
+	if index := g.Opts.ValTestCase.CustomErrorIndex(len(errorTypes)); index >= 0 {
+		innerRes := g.SealedImplementerToValExpr(errorTypes[index], input)
+		res.Merge(innerRes)
+		inner = innerRes.Expr
+	} else {
+		inner = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   &ast.Ident{Name: "errors"},
+				Sel: &ast.Ident{Name: "New"},
+			},
+			Args: []ast.Expr{g.BasicExprToValExpr("string")},
+		}
+	}
+	errFunc := errFuncLit(&ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   &ast.Ident{Name: "fmt"},
+			Sel: &ast.Ident{Name: "Errorf"},
+		},
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"wrapped: %w"`,
+			},
+			inner,
+		},
+	})
+	res.Expr = errFunc.Expr
+	return res
+}
+
+// FindErrorTypes finds the exported types of the package of the function under test implementing the error interface,
+// the PointerReceiver of the result indicates if the Error method is declared on the pointer
+func (g *TestCase) FindErrorTypes() []*SealedImplementer {
+	pointer := g.Pointer
+	pkg := g.PackageInfo.PkgForPointer(pointer)
+	if pkg == nil {
+		return nil
+	}
+	// Map iteration is random, sort files to keep generation deterministic
+	fileNames := []string{}
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+	res := []*SealedImplementer{}
+	for _, fileName := range fileNames {
+		for _, decl := range pkg.Files[fileName].Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() || typeSpec.TypeParams != nil || typeSpec.Assign.IsValid() {
+					continue
+				}
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					continue
+				}
+				method := errorMethod(g.PackageInfo.MethodsForType(pointer, typeSpec.Name.Name))
+				if method == nil {
+					continue
+				}
+				_, pointerReceiver := method.Recv.List[0].Type.(*ast.StarExpr)
+				res = append(res, &SealedImplementer{
+					TypeSpec: typeSpec,
+					Pointer: &importer.PkgResolverPointer{
+						Dir:  pointer.Dir,
+						Pkg:  pointer.Pkg,
+						File: fileName,
+					},
+					PointerReceiver: pointerReceiver,
+				})
+			}
+		}
+	}
+	return res
+}
+
+// errorMethod finds the method implementing the error interface i.e. Error() string
+func errorMethod(methods []*ast.FuncDecl) *ast.FuncDecl {
+	for _, method := range methods {
+		if method.Name.Name != "Error" || method.Type.Params.NumFields() != 0 || method.Type.Results.NumFields() != 1 {
+			continue
+		}
+		if ident, ok := method.Type.Results.List[0].Type.(*ast.Ident); ok && ident.Name == "string" {
+			return method
+		}
+	}
+	return nil
+}
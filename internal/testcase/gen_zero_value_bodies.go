@@ -0,0 +1,99 @@
+package testcase
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// ShouldUseZeroValueBody checks if a method of a synthetic interface implementation should return zero values,
+// which is the case in zero value bodies mode if its return types are expensive to construct and the
+// function under test does not call the method directly, or in reachable methods mode if the method
+// isn't reachable from the function under test
+func (g *TestCase) ShouldUseZeroValueBody(funcType *ast.FuncType, method *ast.Field) bool {
+	if funcType.Results == nil {
+		return false
+	}
+	if g.Opts.ReachableMethodsOnly {
+		return !g.IsReachableMethod(method)
+	}
+	if !g.Opts.ZeroValueBodies {
+		return false
+	}
+	for _, name := range method.Names {
+		if g.CalledMethods()[name.Name] {
+			return false
+		}
+	}
+	for _, res := range funcType.Results.List {
+		if !g.isCheapExpr(res.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCheapExpr checks if a value of given type expression is cheap to construct
+func (g *TestCase) isCheapExpr(e ast.Expr) bool {
+	if _, ok := g.IsBasicExpr(e); ok {
+		return true
+	}
+	ident, ok := e.(*ast.Ident)
+	return ok && g.IsError(ident.Name)
+}
+
+// CalledMethods retrieves the names of the methods called in the body of the function under test
+func (g *TestCase) CalledMethods() map[string]bool {
+	res := make(map[string]bool)
+	if g.FuncDecl.Body == nil {
+		return res
+	}
+	ast.Inspect(g.FuncDecl.Body, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if selectorExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+			res[selectorExpr.Sel.Name] = true
+		}
+		return true
+	})
+	return res
+}
+
+// FuncReturnListToZeroValueStatements converts a return list of function to statements returning zero values
+func (g *TestCase) FuncReturnListToZeroValueStatements(input *RecursionInput) *TypeExprToValExprRes {
+	t, ok := input.e.(*ast.FuncType)
+	// Sanity check
+	if !ok {
+		return EmptyResult()
+	}
+	result := &TypeExprToValExprRes{}
+	retVars := []ast.Expr{}
+	if t.Results != nil {
+		for _, res := range t.Results.List {
+			amount := len(res.Names)
+			if amount == 0 {
+				amount = 1
+			}
+			for i := 0; i < amount; i++ {
+				newIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "o"})
+				result.Statements = append(result.Statements, &ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{newIdent},
+								Type:  g.CorrectTypeExpr(res.Type, input),
+							},
+						},
+					},
+				})
+				retVars = append(retVars, newIdent)
+			}
+		}
+	}
+	result.Statements = append(result.Statements, &ast.ReturnStmt{
+		Results: retVars,
+	})
+	return result
+}
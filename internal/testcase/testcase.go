@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"path/filepath"
+	"regexp"
 	"unicode"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/corpus"
 	"github.com/wimspaargaren/final-unit/internal/decorator"
 	"github.com/wimspaargaren/final-unit/internal/ident"
 	"github.com/wimspaargaren/final-unit/internal/identlist"
 	"github.com/wimspaargaren/final-unit/internal/importer"
 	"github.com/wimspaargaren/final-unit/internal/runtime"
+	"github.com/wimspaargaren/final-unit/pkg/seed"
 	"github.com/wimspaargaren/final-unit/pkg/values"
 	"github.com/wimspaargaren/final-unit/pkg/variables"
 	"golang.org/x/text/cases"
@@ -26,6 +30,102 @@ type Options struct {
 	VarTestCase  variables.IGen
 	IdentGen     ident.IGen
 	MaxRecursion int
+	// TextUnmarshaler creates values for types implementing encoding.TextUnmarshaler
+	// by unmarshalling a generated string instead of filling their fields
+	TextUnmarshaler bool
+	// ZeroValueBodies generates method bodies of synthetic interface implementations returning zero values
+	// for methods with expensive return types, which are not called by the function under test
+	ZeroValueBodies bool
+	// ReachableMethodsOnly generates method bodies of synthetic interface implementations returning zero values
+	// for all methods, which aren't statically reachable from the function under test
+	ReachableMethodsOnly bool
+	// SeedOffsets seeds the value and variable generators of every created test case
+	// with its own seed offset, so a single case can be reproduced using RegenerateCase
+	SeedOffsets bool
+	// LogAssertions logs expected and actual values instead of asserting them
+	LogAssertions bool
+	// AssertFuncs qualified functions of a custom assertion package used per assertion type
+	// instead of testify, assertions which aren't mapped keep using testify. Unused if assertions are logged
+	AssertFuncs map[runtime.AssertStmtType]string
+	// GoroutineLeaks verifies that functions spawning goroutines don't leak them
+	GoroutineLeaks bool
+	// ConcurrentInvocations amount of concurrent calls of concurrency relevant functions, so data races are
+	// detected by the race detector, if 0 functions aren't called concurrently
+	ConcurrentInvocations int
+	// Comparers comparison expression templates used for asserting values per type name,
+	// e.g. {{.Actual}}.Same({{.Expected}})
+	Comparers map[string]string
+	// LenBoundaryBias probability of using one of the boundary lengths 0, 1 or max for slices
+	LenBoundaryBias float64
+	// BranchHintBias probability of using a constant a parameter is compared against in the function body,
+	// or a value next to it, instead of a random value
+	BranchHintBias float64
+	// OverflowBias probability of using the min or max value of an integer parameter used in an addition or multiplication
+	OverflowBias float64
+	// Logger logger used for generation diagnostics, the global logrus logger is used if nil
+	Logger log.FieldLogger
+	// SignalChannels creates channels of type chan struct{} which are closed or contain a signal,
+	// so functions receiving from them don't block
+	SignalChannels bool
+	// Scaffold emits the test cases without assertions, leaving a placeholder to assert the results manually
+	Scaffold bool
+	// ChanFactories creates channels by calling a constructor of the package returning a channel of the type
+	ChanFactories bool
+	// TempFiles creates values of os.File using temp files with generated content, which are removed after the test
+	TempFiles bool
+	// ExportShims calls unexported functions through exported wrappers declared in finalunit_export_test.go
+	ExportShims bool
+	// AliasBias probability of aliasing a pointer, slice or map parameter to a preceding parameter
+	// of the same type, instead of generating an independent value
+	AliasBias float64
+	// SourcePositions adds a comment with the source position of the function under test to the test case
+	SourcePositions bool
+	// ValueBudget max amount of elements of a generated collection including its nested collections,
+	// unbounded if zero
+	ValueBudget int
+	// SyncMapEntries populates pointers to a sync.Map using Store calls, instead of passing an empty map
+	SyncMapEntries bool
+	// ReturnedFuncCalls amount of times a returned func is invoked, asserting the result of every call
+	ReturnedFuncCalls int
+	// TypedNilBias probability of using a typed nil pointer of the generated implementation as interface value
+	TypedNilBias float64
+	// SkipResultAssertions omits the assertions on the values returned by the function
+	SkipResultAssertions bool
+	// SkipAssertFields patterns of the names of struct fields which aren't asserted
+	SkipAssertFields []*regexp.Regexp
+	// SideEffectAssertions asserts the receiver and the arguments referring to shared memory after calling the function,
+	// capturing their mutations
+	SideEffectAssertions bool
+	// Corpus user supplied values which are used for basic types next to random values,
+	// and as texts of types implementing encoding.TextUnmarshaler
+	Corpus *corpus.Corpus
+	// PanicReports emits test cases which panicked at runtime as skipped failing tests, documenting the panic value
+	// and stack, instead of asserting the function panics
+	PanicReports bool
+	// Gomock uses the mocks generated by mockgen for interfaces of the package, instead of synthetic implementations
+	Gomock bool
+	// DefaultTags initializes struct fields tagged with default to their declared default value as one of the variants
+	DefaultTags bool
+	// WholeSliceAssertions asserts slices of comparable elements using a single Equal assertion
+	WholeSliceAssertions bool
+	// WrappedErrors creates error values wrapping a generated inner error, which can be of an error type of the package
+	WrappedErrors bool
+	// MixedInterfaceSlices fills slices of interfaces with a mix of the implementers of the package and
+	// the generated implementation
+	MixedInterfaceSlices bool
+	// InterfaceImplementers passes a mix of the implementers of the package and the generated implementation
+	// for interface parameters
+	InterfaceImplementers bool
+	// NonNilPointers generates all pointers as the address of a value, terminating recursive chains
+	// with a pointer to an empty struct instead of nil
+	NonNilPointers bool
+	// CmpAssertions asserts composite values as a whole using go-cmp, ignoring the order of slices
+	CmpAssertions bool
+	// StringerAssertions asserts values of types implementing fmt.Stringer by comparing the result of String
+	StringerAssertions bool
+	// Allocs asserts the average amount of allocations of calling a function doesn't exceed the amount measured
+	// at runtime, using testing.AllocsPerRun
+	Allocs bool
 }
 
 // TestCase contains all information for generating a test case
@@ -36,6 +136,13 @@ type TestCase struct {
 	PackageInfo *importer.PackageInfo
 	Opts        Options
 	Deco        *decorator.Deco
+	ExpectError *decorator.ExpectError
+	// TypeArgs type arguments of the instantiation of a generic function under test,
+	// FuncDecl is the declaration of which the type parameters are substituted
+	TypeArgs []ast.Expr
+	// SeedOffset seed used for the generators when creating this test case,
+	// only set if seed offsets are enabled or the case was regenerated
+	SeedOffset int64
 	Dynamic
 	RunTimeInfo *runtime.Info
 
@@ -44,10 +151,45 @@ type TestCase struct {
 	Stmts      []string
 	FuncStmt   string
 	ChanIdents []string
+	// LeakCheckIdent identifier holding the amount of goroutines before calling the function,
+	// only set if the test case verifies that no goroutines are leaked
+	LeakCheckIdent string
+	// RaceCheckIdent identifier of the wait group of the concurrent calls of the function,
+	// only set if the test case calls the function concurrently to detect data races
+	RaceCheckIdent string
+	// RaceIndexIdent identifier of the loop index of the concurrent calls of the function
+	RaceIndexIdent string
+	// AllocsIdent identifier holding the average amount of allocations of calling the function,
+	// only set if the allocations of the function are asserted
+	AllocsIdent string
+	// AllocsStmt statement measuring the allocations of the function, executed after the function is called and asserted
+	AllocsStmt string
+	// AllocsPrintStmts print statements of the measured allocations, only using them if the maximum is specified by a directive
+	AllocsPrintStmts []string
+	// Aliases parameters which are aliased to a preceding parameter of the same type,
+	// mapping the name of the parameter to the name of the parameter it aliases
+	Aliases map[string]string
+	// TypedNils names of the interface implementations of which a typed nil pointer is used as interface value
+	TypedNils []string
+	// reachableMethods cached names of the methods reachable from the function under test
+	reachableMethods map[string]bool
+	// gomockCtrl identifier of the gomock controller shared by the mocks of the test case
+	gomockCtrl *ast.Ident
+	// Invalid indicates a value of the test case couldn't be generated, e.g. an array of which the element type
+	// can't be resolved, in which case the test case is skipped instead of emitting code which doesn't compile
+	Invalid bool
 	// Properties used for creating assert stmts in test cases
 	ResultStmts      []string
 	ResultUsageStmts []string
 	FuncPrintStmt    string
+	// ResultIdents names of the variables the results of the function are assigned to
+	ResultIdents []string
+	// PureStmts print statements of the parameters executed before calling a pure function,
+	// the captured values are asserted after the call
+	PureStmts []string
+	// OracleCallStmts statements assigning the results of the oracle of the function, executed before calling
+	// the function, so the oracle isn't affected by modifications of the arguments by the function
+	OracleCallStmts []string
 }
 
 // HasPrintStmts check if any print statements are generated for current test case
@@ -67,19 +209,52 @@ func New(f *ast.FuncDecl,
 	opts Options,
 	decorator *decorator.Deco,
 ) *TestCase {
+	suitePrinter := runtime.TestifySuitePrinter{
+		Receiver: "s",
+		Logger:   opts.Logger,
+	}
+	var printer runtime.StmtPrinter = &suitePrinter
+	switch {
+	// Logged assertions never fail, so custom assertion functions aren't used
+	case opts.LogAssertions:
+		printer = &runtime.TestifyLogPrinter{
+			TestifySuitePrinter: suitePrinter,
+		}
+	case len(opts.AssertFuncs) > 0:
+		printer = runtime.NewCustomAssertPrinter(&suitePrinter, opts.AssertFuncs)
+	}
+	runTimeInfo := runtime.NewInfo(printer)
+	runTimeInfo.Logger = opts.Logger
 	return &TestCase{
 		FuncDecl:    f,
 		Pointer:     pointer,
 		PackageInfo: pkgInfo,
 		Opts:        opts,
 		Deco:        decorator,
-		RunTimeInfo: runtime.NewInfo(runtime.NewTestifySuitePrinter("s")),
+		RunTimeInfo: runTimeInfo,
 		Dynamic: Dynamic{
 			CanGenInterface: make(map[string]bool),
 		},
 	}
 }
 
+// NewCase creates a new test case for the same function with freshly generated values
+func (g *TestCase) NewCase() *TestCase {
+	res := New(g.FuncDecl, g.Pointer, g.PackageInfo, g.Opts, g.Deco)
+	res.ExpectError = g.ExpectError
+	res.TypeArgs = g.TypeArgs
+	res.Create()
+	return res
+}
+
+// logger retrieves the logger used for generation diagnostics
+func (g *TestCase) logger() log.FieldLogger {
+	if g.Opts.Logger != nil {
+		return g.Opts.Logger
+	}
+	return log.StandardLogger()
+}
+
 // Dynamic struct for performance improvements using dynamic programming
 type Dynamic struct {
 	CanGenInterface map[string]bool
@@ -96,7 +271,7 @@ func (g *TestCase) TestCasePrefix(funcDecl *ast.FuncDecl) string {
 	if len(funcDecl.Recv.List) == 1 {
 		return g.TypeToPrefix(funcDecl.Recv.List[0].Type)
 	}
-	log.Warningf("expected func receiver to have only one field")
+	g.logger().Warningf("expected func receiver to have only one field")
 	return g.Opts.IdentGen.Create(&ast.Ident{Name: "prefix"}).Name
 }
 
@@ -110,7 +285,7 @@ func (g *TestCase) TypeToPrefix(e ast.Expr) string {
 	case *ast.StarExpr:
 		return g.TypeToPrefix(t.X)
 	default:
-		log.Warningf("unexpected field receiver type found: %T", e)
+		g.logger().Warningf("unexpected field receiver type found: %T", e)
 		return g.Opts.IdentGen.Create(&ast.Ident{Name: "prefix"}).Name
 	}
 }
@@ -118,8 +293,30 @@ func (g *TestCase) TypeToPrefix(e ast.Expr) string {
 // Create converts a function declaration to a list of assignment statements
 // and declaration statements
 func (g *TestCase) Create() {
+	if g.Opts.SeedOffsets {
+		g.RegenerateCase(seed.NewOffset())
+		return
+	}
+	g.create()
+}
+
+// RegenerateCase creates the test case using value and variable generators seeded with given seed offset,
+// regenerating a case with the seed offset it was created with reproduces the same test case
+func (g *TestCase) RegenerateCase(seedOffset int64) {
+	g.logger().Debugf("creating test case for func %s with seed offset: %d", g.FuncDecl.Name.Name, seedOffset)
+	g.SeedOffset = seedOffset
+	g.Opts.ValTestCase = values.NewSeededGenerator(seedOffset)
+	g.Opts.VarTestCase = variables.NewSeededGenerator(seedOffset)
+	g.create()
+}
+
+func (g *TestCase) create() {
 	// Reset local scope counter whenever creating new testcase
 	g.Opts.IdentGen.ResetLocal()
+	g.Aliases = make(map[string]string)
+	g.TypedNils = []string{}
+	g.gomockCtrl = nil
+	g.Invalid = false
 	g.Opts.IdentGen.Create(&ast.Ident{Name: "s"})
 
 	// Get receiver statements and declarations
@@ -128,12 +325,32 @@ func (g *TestCase) Create() {
 	fieldToAssignResult := g.FieldToAssignStmts(g.FuncDecl.Type.Params, g.FuncDecl.Name.Name, g.Pointer)
 	// Create print statements for generating assert statements
 	identsPrint, results, resultUsages := g.ResultsToPrintStmts(g.FuncDecl.Type.Results, g.FuncDecl.Name.Name, g.Pointer)
+	// Create assert statements forced by directives
+	g.RunTimeInfo.Expectations = g.ExpectationStmts(g.FuncDecl.Type.Results, identsPrint)
+	g.RunTimeInfo.Expectations = append(g.RunTimeInfo.Expectations, g.InvariantStmts(receiverResult.Idents, fieldToAssignResult.Idents, identsPrint)...)
+	oracleStmts, oracleAsserts := g.OracleStmts(receiverResult.Idents, fieldToAssignResult.Idents, identsPrint)
+	g.RunTimeInfo.Expectations = append(g.RunTimeInfo.Expectations, oracleAsserts...)
+	g.RunTimeInfo.ExpectationsOnly = len(oracleAsserts) > 0
+	g.RunTimeInfo.Expectations = append(g.RunTimeInfo.Expectations, g.IdempotentStmts(receiverResult.Idents, identsPrint)...)
+
+	leakCheckIdent := ""
+	if g.Opts.GoroutineLeaks && g.SpawnsGoroutines() {
+		leakCheckIdent = g.Opts.IdentGen.Create(&ast.Ident{Name: "goroutines"}).Name
+	}
+	raceCheckIdent, raceIndexIdent := "", ""
+	if g.Opts.ConcurrentInvocations > 0 && g.IsConcurrencyRelevant() {
+		raceCheckIdent = g.Opts.IdentGen.Create(&ast.Ident{Name: "raceWg"}).Name
+		raceIndexIdent = g.Opts.IdentGen.Create(&ast.Ident{Name: "invocation"}).Name
+	}
 
 	// Create function statements for just calling(used for evolution execution)
 	// as well as assigning the return values(used for creating assert stmts)
 	funcStmt, funcPrintStmt := g.FuncDeclToExprStmt(g.FuncDecl, receiverResult.Idents, fieldToAssignResult.Idents, identsPrint)
-	tempStmts := receiverResult.Statements
+	// Package state is reset before creating the values, which may depend on it
+	tempStmts := g.ResetStmts()
+	tempStmts = append(tempStmts, receiverResult.Statements...)
 	tempStmts = append(tempStmts, fieldToAssignResult.Statements...)
+	tempStmts = append(tempStmts, results.ArgStmts...)
 	resStmts := []string{}
 	for _, tempStmt := range tempStmts {
 		resStmts = append(resStmts, MustPrettyPrintElement(tempStmt))
@@ -145,15 +362,31 @@ func (g *TestCase) Create() {
 		resDecls = append(resDecls, MustPrettyPrintElement(tempDecl))
 	}
 
+	// Only the runtime values of the results are printed, so skipping their print statements skips their assertions
+	if g.Opts.SkipResultAssertions {
+		results.Stmts = []ast.Stmt{}
+	}
+	results.Stmts = append(results.Stmts, g.SideEffectPrintStmts(receiverResult.Idents, fieldToAssignResult.Idents)...)
 	resultStmts := []string{}
 	for _, resultStmt := range results.Stmts {
 		resultStmts = append(resultStmts, MustPrettyPrintElement(resultStmt))
 	}
 
+	pureStmts := []string{}
+	for _, pureStmt := range g.PurePrintStmts(fieldToAssignResult.Idents) {
+		pureStmts = append(pureStmts, MustPrettyPrintElement(pureStmt))
+	}
+
 	resultUsageStmts := []string{}
 	for _, resultUsage := range resultUsages {
 		resultUsageStmts = append(resultUsageStmts, MustPrettyPrintElement(resultUsage))
 	}
+	resultIdents := []string{}
+	for _, identPrint := range identsPrint {
+		if ident, ok := identPrint.(*ast.Ident); ok && ident.Name != "_" {
+			resultIdents = append(resultIdents, ident.Name)
+		}
+	}
 
 	chanIdents := []string{}
 	for _, chanIdent := range receiverResult.ChanIdents {
@@ -169,25 +402,78 @@ func (g *TestCase) Create() {
 	g.Decls = resDecls
 	g.FuncStmt = MustPrettyPrintElement(funcStmt)
 	g.ResultStmts = resultStmts
+	g.PureStmts = pureStmts
+	g.OracleCallStmts = oracleStmts
 	g.ResultUsageStmts = resultUsageStmts
+	g.ResultIdents = resultIdents
 	g.ChanIdents = chanIdents
+	g.LeakCheckIdent = leakCheckIdent
+	// Channels are closed after the function is called, so concurrent calls could block or panic
+	if len(chanIdents) == 0 {
+		g.RaceCheckIdent = raceCheckIdent
+		g.RaceIndexIdent = raceIndexIdent
+	}
+	// Channels are closed after the function is called, so repeated calls could block or panic
+	g.AllocsIdent, g.AllocsStmt, g.AllocsPrintStmts = "", "", []string{}
+	if len(chanIdents) == 0 && g.IsAllocsRelevant() {
+		allocsIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: "allocs"})
+		g.AllocsIdent = allocsIdent.Name
+		g.AllocsStmt = MustPrettyPrintElement(g.AllocsMeasureStmt(allocsIdent, funcStmt))
+		for _, allocsPrintStmt := range g.AllocsToPrintStmts(allocsIdent) {
+			g.AllocsPrintStmts = append(g.AllocsPrintStmts, MustPrettyPrintElement(allocsPrintStmt))
+		}
+		g.RunTimeInfo.Expectations = append(g.RunTimeInfo.Expectations, g.AllocsExpectations(allocsIdent)...)
+	}
 	// In case all output values are not verifiable funcPrintStmt is nil
 	if funcPrintStmt != nil {
 		g.FuncPrintStmt = MustPrettyPrintElement(funcPrintStmt)
 	}
 }
 
+// ExpectationStmts creates the assert statements forced by an expect error directive
+func (g *TestCase) ExpectationStmts(results *ast.FieldList, identsPrint []ast.Expr) []runtime.Stmt {
+	if g.ExpectError == nil {
+		return []runtime.Stmt{}
+	}
+	if results != nil {
+		i := 0
+		for _, field := range results.List {
+			amount := len(field.Names)
+			if amount == 0 {
+				amount = 1
+			}
+			for j := 0; j < amount; j++ {
+				t, ok := field.Type.(*ast.Ident)
+				if ok && g.IsError(t.Name) && i < len(identsPrint) {
+					if ident, ok := identsPrint[i].(*ast.Ident); ok && ident.Name != "_" {
+						return []runtime.Stmt{&runtime.AssertStmt{
+							AssertStmtType: runtime.AssertStmtTypeError,
+							Expected:       ident.Name,
+						}}
+					}
+				}
+				i++
+			}
+		}
+	}
+	g.logger().Warningf("expect-error directive specified for func %s, which does not return an error", g.FuncDecl.Name.Name)
+	return []runtime.Stmt{}
+}
+
 // FuncDeclToExprStmt converts func declaration to expression statement
 func (g *TestCase) FuncDeclToExprStmt(f *ast.FuncDecl, recvIdent, paramIdent []*ast.Ident, printIdents []ast.Expr) (ast.Stmt, ast.Stmt) {
 	callExpr := &ast.CallExpr{
 		Fun: f.Name,
 	}
+	if g.UsesExportShim() {
+		callExpr.Fun = &ast.Ident{Name: ExportShimName(f.Name.Name)}
+	}
 	if f.Recv != nil &&
 		// sanity checks
 		len(f.Recv.List) == 1 &&
 		len(f.Recv.List[0].Names) == 1 {
 		if len(recvIdent) != 1 {
-			log.Warningf("receiver ident should always be 1, but is: %d", len(recvIdent))
+			g.logger().Warningf("receiver ident should always be 1, but is: %d", len(recvIdent))
 		}
 		callExpr = &ast.CallExpr{
 			Fun: &ast.SelectorExpr{
@@ -196,11 +482,13 @@ func (g *TestCase) FuncDeclToExprStmt(f *ast.FuncDecl, recvIdent, paramIdent []*
 			},
 		}
 	}
+	callExpr.Fun = g.instantiatedFunc(callExpr.Fun)
 
 	// Put all parameter identifiers into the call expression
 	for _, x := range paramIdent {
 		callExpr.Args = append(callExpr.Args, x)
 	}
+	SpreadVariadic(callExpr, f.Type)
 	assignToken := token.DEFINE
 	shouldUseAssign := true
 	// If only one param is present and is "_" use "=" for assign, as we can't assign _ := someVar
@@ -243,7 +531,7 @@ func (g *TestCase) GetFuncReceiverStmts(recv *ast.FieldList, funcName string, po
 		newIdent := g.Opts.IdentGen.Create(&ast.Ident{Name: funcName})
 		result.Idents = append(result.Idents, newIdent)
 		values := g.Deco.GetReceiverVal(fileName, funcName)
-		result.Statements = append(result.Statements, assignStmt(newIdent, values[g.Opts.ValTestCase.DecoratorIndex(len(values))].Call))
+		result.Statements = append(result.Statements, g.CustomValStmts(newIdent, values[g.Opts.ValTestCase.DecoratorIndex(len(values))])...)
 		return result
 	}
 
@@ -280,8 +568,9 @@ func (res *FieldToAssignRes) Append(idents, chanIdents []*ast.Ident, stmts []ast
 // FieldToAssignStmts converts a parameter to assignment statements
 func (g *TestCase) FieldToAssignStmts(params *ast.FieldList, funcName string, pointer *importer.PkgResolverPointer) *FieldToAssignRes {
 	result := &FieldToAssignRes{}
+	aliasable := make(map[string][]*ast.Ident)
 	for _, p := range params.List {
-		result.AppendRes(g.FieldToAssignStmt(p, funcName, pointer))
+		result.AppendRes(g.fieldToAssignStmt(p, funcName, pointer, aliasable))
 	}
 	return result
 }
@@ -293,6 +582,10 @@ type CycleInfo struct {
 	Interfaces     map[*ast.InterfaceType]int
 	StructMem      map[*ast.StructType]ast.Expr
 	InterfaceMem   map[*ast.InterfaceType]ast.Decl
+	// GenericInstances type definitions of instantiated generic types with their type parameters substituted
+	GenericInstances map[string]ast.Expr
+	// budget remaining amount of collection elements of the value, only set if a value budget is configured
+	budget valueBudget
 }
 
 // RecursionInput input object for traversing the AST
@@ -307,11 +600,12 @@ type RecursionInput struct {
 // FreshCycleInfo creates a fresh cycle info struct
 func FreshCycleInfo() CycleInfo {
 	return CycleInfo{
-		Structs:        make(map[string]int),
-		StructByStruct: make(map[*ast.StructType]int),
-		Interfaces:     make(map[*ast.InterfaceType]int),
-		InterfaceMem:   make(map[*ast.InterfaceType]ast.Decl),
-		StructMem:      make(map[*ast.StructType]ast.Expr),
+		Structs:          make(map[string]int),
+		StructByStruct:   make(map[*ast.StructType]int),
+		Interfaces:       make(map[*ast.InterfaceType]int),
+		InterfaceMem:     make(map[*ast.InterfaceType]ast.Decl),
+		StructMem:        make(map[*ast.StructType]ast.Expr),
+		GenericInstances: make(map[string]ast.Expr),
 	}
 }
 
@@ -328,25 +622,92 @@ func (r *RecursionInput) Copy() *RecursionInput {
 
 // FieldToAssignStmt converts a function parameter to an assignment field
 func (g *TestCase) FieldToAssignStmt(p *ast.Field, funcName string, pointer *importer.PkgResolverPointer) *FieldToAssignRes {
+	return g.fieldToAssignStmt(p, funcName, pointer, make(map[string][]*ast.Ident))
+}
+
+// fieldToAssignStmt converts a function parameter to an assignment field, parameters may be aliased
+// to the preceding parameters of the same type in aliasable
+func (g *TestCase) fieldToAssignStmt(p *ast.Field, funcName string, pointer *importer.PkgResolverPointer, aliasable map[string][]*ast.Ident) *FieldToAssignRes {
 	res := []ast.Stmt{}
 	decls := []ast.Decl{}
 	idents := []*ast.Ident{}
 	chanIdents := []*ast.Ident{}
+	hints := map[string][]ast.Expr{}
+	if g.Opts.BranchHintBias > 0 {
+		hints = g.BranchHints()
+	}
+	bounds := map[string][]ast.Expr{}
+	if g.Opts.OverflowBias > 0 {
+		bounds = g.OverflowBounds()
+	}
 	for _, param := range p.Names {
 		newIdent := g.Opts.IdentGen.Create(param)
 		_, fileName := filepath.Split(pointer.File)
 
+		// Expect error directives force the value of the given parameter
+		if g.ExpectError != nil && g.ExpectError.Param == param.Name {
+			idents = append(idents, newIdent)
+			elemIdent := g.variadicElemIdent(p.Type, newIdent)
+			res = append(res, assignStmt(elemIdent, g.ExpectError.Value))
+			res = append(res, g.variadicSliceStmts(p.Type, newIdent, elemIdent, pointer)...)
+			continue
+		}
+
 		// If decorators have been specified use to generate value statements
 		hasVal := g.Deco.HasVal(fileName, funcName, param.Name)
 		if hasVal && g.Opts.ValTestCase.DecoratorVal() {
 			idents = append(idents, newIdent)
 			values := g.Deco.GetVal(fileName, funcName, param.Name)
-			res = append(res, assignStmt(newIdent, values[g.Opts.ValTestCase.DecoratorIndex(len(values))].Call))
+			elemIdent := g.variadicElemIdent(p.Type, newIdent)
+			res = append(res, g.CustomValStmts(elemIdent, values[g.Opts.ValTestCase.DecoratorIndex(len(values))])...)
+			res = append(res, g.variadicSliceStmts(p.Type, newIdent, elemIdent, pointer)...)
+			continue
+		}
+
+		// Range directives bound the values of numeric parameters, so hints and aliases outside the range are avoided
+		if r, ok := g.Deco.GetRange(fileName, funcName, param.Name); ok {
+			if expr, ok := g.RangeValExpr(variadicElemType(p.Type), r); ok {
+				idents = append(idents, newIdent)
+				elemIdent := g.variadicElemIdent(p.Type, newIdent)
+				res = append(res, assignStmt(elemIdent, expr))
+				res = append(res, g.variadicSliceStmts(p.Type, newIdent, elemIdent, pointer)...)
+				continue
+			}
+		}
+
+		// Constants used in comparisons of the parameter are likely to trigger a specific branch
+		if hint, ok := g.BranchHint(hints, param.Name); ok {
+			idents = append(idents, newIdent)
+			elemIdent := g.variadicElemIdent(p.Type, newIdent)
+			res = append(res, assignStmt(elemIdent, hint))
+			res = append(res, g.variadicSliceStmts(p.Type, newIdent, elemIdent, pointer)...)
+			continue
+		}
+
+		// Bounds of integers used in arithmetic are likely to overflow
+		if bound, ok := g.OverflowBound(bounds, param.Name); ok {
+			idents = append(idents, newIdent)
+			elemIdent := g.variadicElemIdent(p.Type, newIdent)
+			res = append(res, assignStmt(elemIdent, bound))
+			res = append(res, g.variadicSliceStmts(p.Type, newIdent, elemIdent, pointer)...)
 			continue
 		}
+
+		// Passing the same object for multiple parameters exercises self-aliasing edge cases
+		if alias, ok := g.Alias(p.Type, param, aliasable); ok {
+			idents = append(idents, newIdent)
+			res = append(res, assignStmt(newIdent, alias))
+			continue
+		}
+		recordAliasable(p.Type, newIdent, aliasable)
 		i := NewRecursionInput(p.Type, newIdent.Name, pointer, newIdent)
 
-		recursionResult := g.TypeExprToValExpr(i)
+		var recursionResult *TypeExprToValExprRes
+		if implementers := g.InterfaceParamImplementers(p.Type, i); len(implementers) > 0 {
+			recursionResult = g.MixedInterfaceElementToValExpr(implementers, i)
+		} else {
+			recursionResult = g.TypeExprToValExpr(i)
+		}
 
 		res = append(res, recursionResult.Statements...)
 		decls = append(decls, recursionResult.Declarations...)
@@ -368,6 +729,9 @@ type TypeExprToValExprRes struct {
 	Expr         ast.Expr
 	Statements   []ast.Stmt
 	Declarations []ast.Decl
+	// CycleCut indicates the value is an empty struct returned by cycle detection,
+	// pointers to such values are generated as nil so recursive chains terminate
+	CycleCut bool
 	// FIXME:
 	ChanIdents []*ast.Ident
 	ChanStmts  []ast.Stmt
@@ -393,6 +757,12 @@ func EmptyResult() *TypeExprToValExprRes {
 	}
 }
 
+// IsEmptyExpr checks if an expression is the empty expression of an empty result, i.e. no value could be generated
+func IsEmptyExpr(e ast.Expr) bool {
+	basicLit, ok := e.(*ast.BasicLit)
+	return ok && basicLit.Kind == token.ILLEGAL && basicLit.Value == ""
+}
+
 // TypeExprToValExpr converts a type expression, the type definition in a function parameter, to an expression used in an assignment statement
 func (g *TestCase) TypeExprToValExpr(input *RecursionInput) *TypeExprToValExprRes {
 	switch t := input.e.(type) {
@@ -411,7 +781,7 @@ func (g *TestCase) TypeExprToValExpr(input *RecursionInput) *TypeExprToValExprRe
 		case *ast.TypeSpec:
 			return g.TypeSpecToValExpr(t, objectDeclType, input)
 		default:
-			log.Warningf("unimplemented object declaration type")
+			g.logger().Warningf("unimplemented object declaration type")
 			return EmptyResult()
 		}
 	// Handle pointer typess
@@ -435,21 +805,18 @@ func (g *TestCase) TypeExprToValExpr(input *RecursionInput) *TypeExprToValExprRe
 	// Handle selectors e.g. pkg.Something
 	case *ast.SelectorExpr:
 		return g.SelectorExprToValExpr(input)
+	// Handle instantiated generic types e.g. Stack[string]
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return g.GenericInstanceToValExpr(input)
 	// Handle ellipsis type e.g. ...X
 	case *ast.Ellipsis:
-		return g.TypeExprToValExpr(&RecursionInput{
-			e:          t.Elt,
-			counter:    input.counter,
-			pkgPointer: input.pkgPointer,
-			varName:    input.varName,
-			identList:  input.identList,
-		})
+		return g.VariadicToValExpr(t, input)
 	// Default should not be hit all types are handled accordingly
 	default:
 		if input.e == nil {
 			return g.InterfaceTypeToValExpr(input)
 		}
-		log.Warningf("typeExprToValExpr not implemented yet: %T", t)
+		g.logger().Warningf("typeExprToValExpr not implemented yet: %T", t)
 		return EmptyResult()
 	}
 }
@@ -457,6 +824,25 @@ func (g *TestCase) TypeExprToValExpr(input *RecursionInput) *TypeExprToValExprRe
 // TypeSpecToValExpr converts type spec to val expression
 // Start of type recursion
 func (g *TestCase) TypeSpecToValExpr(t *ast.Ident, objectDeclType *ast.TypeSpec, input *RecursionInput) *TypeExprToValExprRes {
+	// Prefer the validation of the type itself over filling its fields directly, which is the fallback if the text is rejected
+	if g.Opts.TextUnmarshaler && g.HasTextUnmarshaler(objectDeclType, input) {
+		return g.TextUnmarshalerToValExpr(objectDeclType, input, g.typeSpecDeclToValExpr(t, objectDeclType, input))
+	}
+	return g.typeSpecDeclToValExpr(t, objectDeclType, input)
+}
+
+// typeSpecDeclToValExpr converts type spec to val expression based on its declaration
+func (g *TestCase) typeSpecDeclToValExpr(t *ast.Ident, objectDeclType *ast.TypeSpec, input *RecursionInput) *TypeExprToValExprRes {
+	if constructor := g.FindFunctionalOptionsConstructor(objectDeclType, input); constructor != nil {
+		return g.FunctionalOptionsToValExpr(constructor, input)
+	}
+	if constructor := g.FindUnexportedTypeConstructor(objectDeclType, input.pkgPointer); constructor != nil && g.CanDereference(constructor, objectDeclType) {
+		return g.UnexportedTypeConstructorToValExpr(constructor, input)
+	}
+	if interfaceType, ok := objectDeclType.Type.(*ast.InterfaceType); ok && g.Opts.Gomock &&
+		g.PackageInfo.IsRoot(input.pkgPointer) && g.FindGomock(objectDeclType.Name.Name) {
+		return g.GomockToValExpr(objectDeclType.Name.Name, interfaceType, input)
+	}
 	switch oType := objectDeclType.Type.(type) {
 	case *ast.StructType:
 		return g.StructExprToValExpr(&RecursionInput{
@@ -466,53 +852,76 @@ func (g *TestCase) TypeSpecToValExpr(t *ast.Ident, objectDeclType *ast.TypeSpec,
 			counter:    input.counter,
 			identList:  input.identList,
 		})
-	default:
-		// Detect if we are dealing with ungeneratable interfaces
-		shouldReturn := g.ShouldReturnForInterface(objectDeclType.Type, &RecursionInput{
-			e:          objectDeclType.Type,
-			counter:    FreshCycleInfo(),
-			pkgPointer: input.pkgPointer,
-			varName:    input.varName,
-			identList:  input.identList,
-		})
-		if shouldReturn {
-			return g.InterfaceNilFunc(t, input)
+	case *ast.MapType:
+		return g.NamedMapToValExpr(objectDeclType, oType, input)
+	case *ast.ChanType:
+		if factory := g.FindNamedChanFactory(objectDeclType, input.pkgPointer); factory != nil {
+			return g.PointerConstructorToValExpr(factory, input)
+		}
+		if g.IsMakeableNamedChan(oType) {
+			return g.NamedChanToValExpr(objectDeclType, oType, input)
 		}
+		return g.ConvertedTypeSpecToValExpr(t, objectDeclType, input)
+	default:
+		return g.ConvertedTypeSpecToValExpr(t, objectDeclType, input)
+	}
+}
 
-		// Detect if we are dealing with ungeneratable functions
-		shouldReturn = g.ShouldReturnForFunc(objectDeclType.Type, &RecursionInput{
-			e:          objectDeclType.Type,
-			counter:    FreshCycleInfo(),
-			pkgPointer: input.pkgPointer,
-			varName:    input.varName,
-			identList:  input.identList,
-		})
-		if shouldReturn {
-			return g.FuncNilFunc(t, input)
+// ConvertedTypeSpecToValExpr converts a value of the underlying type of a type spec to the defined type,
+// e.g. Celsius(-12.5) or Handler(func(path string) int {...})
+func (g *TestCase) ConvertedTypeSpecToValExpr(t *ast.Ident, objectDeclType *ast.TypeSpec, input *RecursionInput) *TypeExprToValExprRes {
+	// Detect if we are dealing with ungeneratable interfaces
+	shouldReturn := g.ShouldReturnForInterface(objectDeclType.Type, &RecursionInput{
+		e:          objectDeclType.Type,
+		counter:    FreshCycleInfo(),
+		pkgPointer: input.pkgPointer,
+		varName:    input.varName,
+		identList:  input.identList,
+	})
+	if shouldReturn {
+		if result, ok := g.SealedInterfaceToValExpr(objectDeclType.Type, t.Name, input.pkgPointer, input); ok {
+			return result
 		}
+		return g.InterfaceNilFunc(t, input)
+	}
 
-		recursionResult := g.TypeExprToValExpr(&RecursionInput{
-			e:          objectDeclType.Type,
-			varName:    input.varName,
-			pkgPointer: input.pkgPointer,
-			counter:    input.counter,
-			identList:  input.identList,
-		})
+	// Detect if we are dealing with ungeneratable functions
+	shouldReturn = g.ShouldReturnForFunc(objectDeclType.Type, &RecursionInput{
+		e:          objectDeclType.Type,
+		counter:    FreshCycleInfo(),
+		pkgPointer: input.pkgPointer,
+		varName:    input.varName,
+		identList:  input.identList,
+	})
+	if shouldReturn {
+		return g.FuncNilFunc(t, input)
+	}
 
-		// we don't need to create call expression for interface type
-		if _, ok := objectDeclType.Type.(*ast.InterfaceType); ok {
-			return recursionResult
-		}
-		result := &TypeExprToValExprRes{}
-		result.Merge(recursionResult)
+	recursionResult := g.TypeExprToValExpr(&RecursionInput{
+		e:          objectDeclType.Type,
+		varName:    input.varName,
+		pkgPointer: input.pkgPointer,
+		counter:    input.counter,
+		identList:  input.identList,
+	})
 
-		res := &ast.CallExpr{
-			Fun:  g.CorrectTypeExpr(objectDeclType.Name, input),
-			Args: []ast.Expr{recursionResult.Expr},
-		}
-		result.Expr = res
-		return result
+	// we don't need to create call expression for interface type
+	if _, ok := objectDeclType.Type.(*ast.InterfaceType); ok {
+		return recursionResult
+	}
+	// An alias of an instantiated generic type e.g. type IntStack = Stack[int] is identical to the instantiation
+	if _, _, ok := genericInstance(objectDeclType.Type); ok && objectDeclType.Assign.IsValid() {
+		return recursionResult
+	}
+	result := &TypeExprToValExprRes{}
+	result.Merge(recursionResult)
+
+	res := &ast.CallExpr{
+		Fun:  g.CorrectTypeExpr(objectDeclType.Name, input),
+		Args: []ast.Expr{recursionResult.Expr},
 	}
+	result.Expr = res
+	return result
 }
 
 // IdentWithNilObjectToValExpr converts identifier with nil object to val expression
@@ -525,12 +934,23 @@ func (g *TestCase) IdentWithNilObjectToValExpr(t *ast.Ident, input *RecursionInp
 		}
 	}
 	if g.IsError(t.Name) {
+		if g.Opts.WrappedErrors {
+			return g.WrappedErrExprToValExpr(input)
+		}
 		return g.ErrExprToValExpr()
 	}
+	if g.IsAny(t.Name) {
+		return g.InterfaceTypeToValExpr(&RecursionInput{
+			varName:    input.varName,
+			pkgPointer: input.pkgPointer,
+			counter:    input.counter,
+			identList:  input.identList,
+		})
+	}
 	// t.Name != basic val this is from another file in the same package
 	found, expr, newPointer := g.PackageInfo.FindInCurrent(input.pkgPointer, t.Name)
 	if !found {
-		log.Warningf("identifier not present in this file not found in other file: %s", t.Name)
+		g.logger().Warningf("identifier not present in this file not found in other file: %s", t.Name)
 	}
 	return g.TypeExprToValExpr(&RecursionInput{
 		e:          expr,
@@ -556,21 +976,61 @@ func (g *TestCase) UnnamedStructToValExpr(t *ast.StructType, input *RecursionInp
 
 // SelectorExprToValExpr converts a selector expression to a value expression
 func (g *TestCase) SelectorExprToValExpr(input *RecursionInput) *TypeExprToValExprRes {
+	// Handle instantiated generic types of imported packages e.g. pkg.List[int]
+	if _, _, ok := genericInstance(input.e); ok {
+		return g.ImportedGenericInstanceToValExpr(input)
+	}
 	t, ok := input.e.(*ast.SelectorExpr)
 	// Sanity check
 	if !ok {
-		log.Warningf("SelectorExprToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("SelectorExprToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 
+	if g.IsReflectValue(t, input.pkgPointer) {
+		return g.ReflectValueToValExpr(t)
+	}
+
+	if g.IsJSONRawMessage(t, input.pkgPointer) {
+		return g.JSONRawMessageToValExpr(t, input)
+	}
+
+	if g.IsJSONNumber(t, input.pkgPointer) {
+		return g.JSONNumberToValExpr(t, input)
+	}
+
+	if g.IsBigNumber(t, input.pkgPointer) {
+		return g.BigNumberToValExpr(t)
+	}
+
+	if g.IsSyncType(t, input.pkgPointer) {
+		return g.SyncTypeToValExpr(t, input, false)
+	}
+
+	if g.IsTemplate(t, input.pkgPointer) {
+		return g.TemplateToValExpr(t, input, false)
+	}
+
+	if g.IsRegexp(t, input.pkgPointer) {
+		return g.RegexpToValExpr(t, input, false)
+	}
+
+	if g.IsContext(t, input.pkgPointer) {
+		return g.ContextToValExpr(t, input)
+	}
+
+	if g.Opts.TempFiles && g.IsOSFile(t, input.pkgPointer) {
+		return g.TempFileToValExpr(input, false)
+	}
+
 	if selectorIdent, ok := t.X.(*ast.Ident); ok {
 		// Resolve imports
 		found, expr, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
 		if newPointer == nil {
-			log.Warning("new pointer nil")
+			g.logger().Warning("new pointer nil")
 		}
 		if !found {
-			log.Warningf("identifier not found in imports: %s, expr: %v", selectorIdent.Name, t.X)
+			g.logger().Warningf("identifier not found in imports: %s, expr: %v", selectorIdent.Name, t.X)
 			return EmptyResult()
 		}
 		shouldReturn := g.ShouldReturnForInterface(expr, &RecursionInput{
@@ -581,6 +1041,9 @@ func (g *TestCase) SelectorExprToValExpr(input *RecursionInput) *TypeExprToValEx
 			identList:  input.identList,
 		})
 		if shouldReturn {
+			if result, ok := g.SealedInterfaceToValExpr(expr, t.Sel.Name, newPointer, input); ok {
+				return result
+			}
 			return g.InterfaceNilFunc(t, input)
 		}
 		recursionResult := g.TypeExprToValExpr(&RecursionInput{
@@ -590,15 +1053,15 @@ func (g *TestCase) SelectorExprToValExpr(input *RecursionInput) *TypeExprToValEx
 			counter:    input.counter,
 			identList:  input.identList,
 		})
-		result := &TypeExprToValExprRes{}
+		result := &TypeExprToValExprRes{CycleCut: recursionResult.CycleCut}
 		result.Merge(recursionResult)
 		switch recursionType := recursionResult.Expr.(type) {
 		case *ast.CompositeLit:
-			recursionType.Type = t
+			recursionType.Type = g.CorrectTypeExpr(t, input)
 			result.Expr = recursionType
 			return result
 		case *ast.CallExpr:
-			recursionType.Fun = t
+			recursionType.Fun = g.CorrectTypeExpr(t, input)
 			result.Expr = recursionType
 			return result
 		default:
@@ -606,7 +1069,7 @@ func (g *TestCase) SelectorExprToValExpr(input *RecursionInput) *TypeExprToValEx
 			return result
 		}
 	} else {
-		log.Warningf("unimplemented selector X: %T", t.Sel)
+		g.logger().Warningf("unimplemented selector X: %T", t.Sel)
 	}
 	return EmptyResult()
 }
@@ -656,14 +1119,15 @@ func (g *TestCase) InterfaceTypeToValExpr(input *RecursionInput) *TypeExprToValE
 	t, ok := input.e.(*ast.InterfaceType)
 	// Sanity check
 	if input.e != nil && !ok {
-		log.Warningf("InterfaceTypeToValExpr is not used correctly: %T", input.e)
+		g.logger().Warningf("InterfaceTypeToValExpr is not used correctly: %T", input.e)
 		return EmptyResult()
 	}
 
 	if ok && t.Incomplete {
-		log.Warningf("Incomplete interface detected")
+		g.logger().Warningf("Incomplete interface detected")
 	}
-	// Empty interface
+	// Empty interface, values are always of a basic type, which makes them
+	// comparable and therefore safe to use as keys of interface keyed maps
 	if input.e == nil || t.Methods.List == nil {
 		return &TypeExprToValExprRes{
 			Expr:         g.BasicExprToValExpr(g.Opts.ValTestCase.Type()),
@@ -712,6 +1176,11 @@ func (g *TestCase) InterfaceTypeToValExpr(input *RecursionInput) *TypeExprToValE
 	implementationResult := g.InterfaceTypeToFuncImpl(input, interfaceImplIdent)
 	result.Merge(implementationResult)
 
+	if typedNil, ok := g.TypedNil(interfaceImplIdent); ok {
+		result.Expr = typedNil
+		return result
+	}
+
 	elts := []ast.Expr{}
 	result.Expr = &ast.UnaryExpr{
 		Op: token.AND,
@@ -727,19 +1196,25 @@ func (g *TestCase) InterfaceTypeToValExpr(input *RecursionInput) *TypeExprToValE
 
 // MethodFuncTypeToFuncImpl converts func type to function implementation declarations
 func (g *TestCase) MethodFuncTypeToFuncImpl(funcType *ast.FuncType, method *ast.Field, input *RecursionInput, interfaceImplIdent *ast.Ident, result *TypeExprToValExprRes) *TypeExprToValExprRes {
-	recursionResult := g.FuncReturnListToBodyStatements(&RecursionInput{
+	bodyInput := &RecursionInput{
 		e:          funcType,
 		varName:    input.varName,
 		pkgPointer: input.pkgPointer,
 		counter:    input.counter,
 		identList:  input.identList,
-	})
+	}
+	var recursionResult *TypeExprToValExprRes
+	if g.ShouldUseZeroValueBody(funcType, method) {
+		recursionResult = g.FuncReturnListToZeroValueStatements(bodyInput)
+	} else {
+		recursionResult = g.FuncReturnListToBodyStatements(bodyInput)
+	}
 	result.Merge(recursionResult)
 	// Statements are used for body
 	result.Statements = []ast.Stmt{}
 
 	if len(method.Names) != 1 {
-		log.Warningf("expected 1 method name got: %d", len(method.Names))
+		g.logger().Warningf("expected 1 method name got: %d", len(method.Names))
 	}
 
 	funcExpr := g.CorrectTypeExpr(funcType, input)
@@ -770,10 +1245,17 @@ func (g *TestCase) MethodFuncTypeToFuncImpl(funcType *ast.FuncType, method *ast.
 
 // InterfaceTypeToFuncImpl converts interface type to function implementation declarations
 func (g *TestCase) InterfaceTypeToFuncImpl(input *RecursionInput, interfaceImplIdent *ast.Ident) *TypeExprToValExprRes {
+	return g.interfaceTypeToFuncImpl(input, interfaceImplIdent, make(map[string]bool))
+}
+
+// interfaceTypeToFuncImpl converts interface type to function implementation declarations,
+// methods which are already implemented are skipped, since an interface may be embedded
+// multiple times in the transitive embedding closure e.g. in case of diamond embedding
+func (g *TestCase) interfaceTypeToFuncImpl(input *RecursionInput, interfaceImplIdent *ast.Ident, implemented map[string]bool) *TypeExprToValExprRes {
 	t, ok := input.e.(*ast.InterfaceType)
 	// Sanity check
 	if !ok {
-		log.Warningf("InterfaceTypeToFuncImpl is not  used correctly: %T", input.e)
+		g.logger().Warningf("InterfaceTypeToFuncImpl is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 	result := &TypeExprToValExprRes{}
@@ -781,52 +1263,59 @@ func (g *TestCase) InterfaceTypeToFuncImpl(input *RecursionInput, interfaceImplI
 		// Normal method definitions
 		// nolint: nestif
 		if funcType, ok := method.Type.(*ast.FuncType); ok {
+			if len(method.Names) == 1 && implemented[method.Names[0].Name] {
+				continue
+			}
+			for _, name := range method.Names {
+				implemented[name.Name] = true
+			}
 			result = g.MethodFuncTypeToFuncImpl(funcType, method, input, interfaceImplIdent, result)
 			// Nested interface
 		} else if ident, ok := method.Type.(*ast.Ident); ok {
 			// Resolve directly nested interfaces
 			if typeSpec, ok := ident.Obj.Decl.(*ast.TypeSpec); ok {
 				if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
-					recursionResult := g.InterfaceTypeToFuncImpl(&RecursionInput{
+					recursionResult := g.interfaceTypeToFuncImpl(&RecursionInput{
 						e:          interfaceType,
 						counter:    input.counter,
 						pkgPointer: input.pkgPointer,
 						varName:    input.varName,
 						identList:  input.identList,
-					}, interfaceImplIdent)
+					}, interfaceImplIdent, implemented)
 					result.Merge(recursionResult)
 				} else {
-					log.Warningf("unexpected type spec type: %T", typeSpec.Type)
+					g.logger().Warningf("unexpected type spec type: %T", typeSpec.Type)
 				}
 			} else {
-				log.Warningf("Unexpected object type: %T", ident.Obj.Decl)
+				g.logger().Warningf("Unexpected object type: %T", ident.Obj.Decl)
 			}
 		} else if t, ok := method.Type.(*ast.SelectorExpr); ok {
 			// In case directly nested interface as selector
 			// Resolve import and recurse
 			selectorIdent, ok := t.X.(*ast.Ident)
 			if !ok {
-				log.Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, selectorIdent.Name)
+				g.logger().Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, selectorIdent.Name)
 				return EmptyResult()
 			}
 			found, expr, newPointer := g.PackageInfo.FindImport(input.pkgPointer, selectorIdent.Name, t.Sel.Name)
 			if newPointer == nil {
-				log.Warning("new pointer nil")
+				g.logger().Warning("new pointer nil")
 			}
 			if !found {
-				log.Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, selectorIdent.Name)
+				g.logger().Warningf("identifier not found in imports: %s, ident: %s", selectorIdent.Name, selectorIdent.Name)
 				return EmptyResult()
 			}
-			recursionResult := g.InterfaceTypeToFuncImpl(&RecursionInput{
+			// The methods are declared in the imported package, so their signatures are qualified relative to it
+			recursionResult := g.interfaceTypeToFuncImpl(&RecursionInput{
 				e:          expr,
 				counter:    input.counter,
-				pkgPointer: input.pkgPointer,
+				pkgPointer: newPointer,
 				varName:    input.varName,
 				identList:  input.identList,
-			}, interfaceImplIdent)
+			}, interfaceImplIdent, implemented)
 			result.Merge(recursionResult)
 		} else {
-			log.Warningf("interface specified non functype type: %T", method.Type)
+			g.logger().Warningf("interface specified non functype type: %T", method.Type)
 		}
 	}
 	return result
@@ -834,6 +1323,15 @@ func (g *TestCase) InterfaceTypeToFuncImpl(input *RecursionInput, interfaceImplI
 
 // ChanTypeToValExpr converts a chan type to a value expression
 func (g *TestCase) ChanTypeToValExpr(t *ast.ChanType, input *RecursionInput) *TypeExprToValExprRes {
+	if factory := g.FindChanFactory(t, input.pkgPointer); factory != nil {
+		return g.PointerConstructorToValExpr(factory, input)
+	}
+	if g.Opts.SignalChannels && IsSignalChan(t) {
+		return g.SignalChanToValExpr(input)
+	}
+	if t.Dir == ast.RECV {
+		return g.RecvChanToValExpr(t, input)
+	}
 	newIdent := g.Opts.IdentGen.Create(input.identList.Current())
 
 	res := &ast.CallExpr{
@@ -866,7 +1364,7 @@ func (g *TestCase) FuncTypeToValExpr(input *RecursionInput) *TypeExprToValExprRe
 	t, ok := resFunc.(*ast.FuncType)
 	// Sanity check
 	if !ok {
-		log.Warningf("FuncTypeToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("FuncTypeToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 	result := &TypeExprToValExprRes{}
@@ -890,7 +1388,7 @@ func (g *TestCase) FuncReturnListToBodyStatements(input *RecursionInput) *TypeEx
 	t, ok := input.e.(*ast.FuncType)
 	// Sanity check
 	if !ok {
-		log.Warningf("FuncReturnListToBodyStatements  is not  used correctly: %T", input.e)
+		g.logger().Warningf("FuncReturnListToBodyStatements  is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 	result := &TypeExprToValExprRes{}
@@ -944,24 +1442,46 @@ func (g *TestCase) ArrayExprToValExpr(input *RecursionInput) *TypeExprToValExprR
 	t, ok := input.e.(*ast.ArrayType)
 	// Sanity check
 	if !ok {
-		log.Warningf("ArrayExprToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("ArrayExprToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 
-	arrayLen := getArrayLen(t.Len)
+	arrayLen := getArrayLen(t.Len, g.logger())
 
 	result := &TypeExprToValExprRes{}
-	arrayLenToUse := g.Opts.ValTestCase.ArrayLen(arrayLen)
+	arrayLenToUse := 0
+	if t.Len == nil {
+		arrayLenToUse = g.Opts.ValTestCase.SliceLen(g.Opts.LenBoundaryBias)
+	} else {
+		arrayLenToUse = g.Opts.ValTestCase.ArrayLen(arrayLen)
+	}
+	arrayLenToUse, counter := g.spendBudget(input, arrayLenToUse, 1)
+	var implementers []*SealedImplementer
+	if g.Opts.MixedInterfaceSlices {
+		implementers = g.MixedInterfaceImplementers(t.Elt, input)
+	}
 	exprRes := []ast.Expr{}
 	for i := 0; i < arrayLenToUse; i++ {
 		// Create values for array type
-		recursionResult := g.TypeExprToValExpr(&RecursionInput{
+		elementInput := &RecursionInput{
 			e:          t.Elt,
 			varName:    input.varName,
 			pkgPointer: input.pkgPointer,
-			counter:    input.counter,
+			counter:    counter,
 			identList:  input.identList,
-		})
+		}
+		var recursionResult *TypeExprToValExprRes
+		if len(implementers) > 0 {
+			recursionResult = g.MixedInterfaceElementToValExpr(implementers, elementInput)
+		} else {
+			recursionResult = g.TypeExprToValExpr(elementInput)
+		}
+		// An element which can't be generated e.g. of an unresolved package would result in a malformed literal
+		if IsEmptyExpr(recursionResult.Expr) {
+			g.logger().Warningf("unable to generate element of array type: %s, skipping test case", types.ExprString(t))
+			g.Invalid = true
+			return EmptyResult()
+		}
 		result.Merge(recursionResult)
 		exprRes = append(exprRes, recursionResult.Expr)
 	}
@@ -982,11 +1502,11 @@ func (g *TestCase) MapExprToValExpr(input *RecursionInput) *TypeExprToValExprRes
 	t, ok := input.e.(*ast.MapType)
 	// Sanity check
 	if !ok {
-		log.Warningf("MapExprToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("MapExprToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 
-	mapLen := g.Opts.ValTestCase.MapLen()
+	mapLen, counter := g.spendBudget(input, g.Opts.ValTestCase.MapLen(), 2)
 
 	// Resulting map declaration
 	res := &ast.CompositeLit{
@@ -996,7 +1516,7 @@ func (g *TestCase) MapExprToValExpr(input *RecursionInput) *TypeExprToValExprRes
 		},
 		Elts: []ast.Expr{},
 	}
-	duplCheck := NewDuplMapChecker()
+	duplCheck := NewDuplMapChecker(g.logger())
 	result := &TypeExprToValExprRes{}
 	for i := 0; i < mapLen; i++ {
 		// Create expressions for key value
@@ -1004,7 +1524,7 @@ func (g *TestCase) MapExprToValExpr(input *RecursionInput) *TypeExprToValExprRes
 			e:          t.Key,
 			varName:    input.varName,
 			pkgPointer: input.pkgPointer,
-			counter:    input.counter,
+			counter:    counter,
 			identList:  input.identList,
 		})
 		isDupl := duplCheck.IsDuplExpr(keyRecursionResult.Expr)
@@ -1017,7 +1537,7 @@ func (g *TestCase) MapExprToValExpr(input *RecursionInput) *TypeExprToValExprRes
 			e:          t.Value,
 			varName:    input.varName,
 			pkgPointer: input.pkgPointer,
-			counter:    input.counter,
+			counter:    counter,
 			identList:  input.identList,
 		})
 		result.Merge(valRecursionInput)
@@ -1036,9 +1556,27 @@ func (g *TestCase) StarExprToValExpr(input *RecursionInput) *TypeExprToValExprRe
 	t, ok := input.e.(*ast.StarExpr)
 	// Sanity check
 	if !ok {
-		log.Warningf("StarExprToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("StarExprToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
+	if selectorExpr, ok := t.X.(*ast.SelectorExpr); ok && g.IsSyncType(selectorExpr, input.pkgPointer) {
+		return g.SyncTypeToValExpr(selectorExpr, input, true)
+	}
+	if selectorExpr, ok := t.X.(*ast.SelectorExpr); ok && g.IsTemplate(selectorExpr, input.pkgPointer) {
+		return g.TemplateToValExpr(selectorExpr, input, true)
+	}
+	if selectorExpr, ok := t.X.(*ast.SelectorExpr); ok && g.IsRegexp(selectorExpr, input.pkgPointer) {
+		return g.RegexpToValExpr(selectorExpr, input, true)
+	}
+	if selectorExpr, ok := t.X.(*ast.SelectorExpr); ok && g.Opts.TempFiles && g.IsOSFile(selectorExpr, input.pkgPointer) {
+		return g.TempFileToValExpr(input, true)
+	}
+	// Prefer constructing the pointer the way the package itself does
+	if typeName, pointer, ok := g.PointedTypeName(t.X, input); ok {
+		if constructor := g.FindPointerConstructor(typeName, pointer); constructor != nil {
+			return g.PointerConstructorToValExpr(constructor, input)
+		}
+	}
 	identTemp := g.Opts.IdentGen.Create(&ast.Ident{
 		Name: "pointer" + cases.Title(language.English).String(input.identList.Previous().Name),
 	})
@@ -1053,7 +1591,18 @@ func (g *TestCase) StarExprToValExpr(input *RecursionInput) *TypeExprToValExprRe
 		counter:    input.counter,
 		identList:  input.identList,
 	})
+	// Terminate recursive chains, e.g. of mutually referencing structs, with nil,
+	// unless pointers are required to be non nil, then the chain ends with a pointer to the empty struct
+	if recursionResult.CycleCut && !g.Opts.NonNilPointers {
+		result.Expr = &ast.Ident{Name: "nil"}
+		return result
+	}
 	result.Merge(recursionResult)
+	// Value is already a dereferenced pointer, use the pointer directly
+	if derefExpr, ok := recursionResult.Expr.(*ast.StarExpr); ok {
+		result.Expr = derefExpr.X
+		return result
+	}
 	// Create assignment of initial val
 	tempAssignStmt := assignStmt(identTemp, recursionResult.Expr)
 	// Create pointer from recursive expression
@@ -1071,7 +1620,7 @@ func (g *TestCase) StructExprToValExpr(input *RecursionInput) *TypeExprToValExpr
 	structExpr, ok := input.e.(*ast.StructType)
 	// Sanity check
 	if !ok {
-		log.Warningf("StructExprToValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("StructExprToValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 	// Create identifier for input variable name
@@ -1097,17 +1646,18 @@ func (g *TestCase) StructExprToValExpr(input *RecursionInput) *TypeExprToValExpr
 	input.counter.Structs[name]++
 
 	// If cycle exceeds max recursion val return, we get into an infinite loop otherwise
-	if input.counter.Structs[name] > g.Opts.MaxRecursion && ok {
+	if input.counter.Structs[name] > g.MaxRecursion(input) && ok {
 		// Return memory
 		return &TypeExprToValExprRes{
 			Expr:         mem,
 			Statements:   []ast.Stmt{},
 			Declarations: []ast.Decl{},
+			CycleCut:     true,
 		}
 	}
 
 	if structExpr.Incomplete {
-		log.Warningf("Incomplete struct detected")
+		g.logger().Warningf("Incomplete struct detected")
 	}
 
 	return g.StructFieldsToKeyValExpr(res, input)
@@ -1119,12 +1669,18 @@ func (g *TestCase) StructFieldsToKeyValExpr(res *ast.CompositeLit, input *Recurs
 	structExpr, ok := input.e.(*ast.StructType)
 	// Sanity check
 	if !ok {
-		log.Warningf("StructFieldsToKeyValExpr is not  used correctly: %T", input.e)
+		g.logger().Warningf("StructFieldsToKeyValExpr is not  used correctly: %T", input.e)
 		return EmptyResult()
 	}
 	result := &TypeExprToValExprRes{}
 	elts := []ast.Expr{}
+	useDefaults := g.UseDefaultTags(structExpr)
 	for _, field := range structExpr.Fields.List {
+		// Leave fields which are omitted from json when empty at their zero value
+		// so both the present and absent variant are exercised
+		if g.HasJSONOmitEmpty(field) && g.Opts.ValTestCase.OmitEmpty() {
+			continue
+		}
 		// Directly nested struct is indicated by field without names
 		if len(field.Names) == 0 {
 			n := g.GetUnnamedStructIdent(field.Type, input)
@@ -1165,6 +1721,15 @@ func (g *TestCase) StructFieldsToKeyValExpr(res *ast.CompositeLit, input *Recurs
 			if cantGen {
 				continue
 			}
+			if useDefaults {
+				if defaultVal, ok := g.DefaultTagToValExpr(field); ok {
+					elts = append(elts, &ast.KeyValueExpr{
+						Key:   &ast.Ident{Name: n.Name},
+						Value: defaultVal,
+					})
+					continue
+				}
+			}
 
 			recursionResult := g.TypeExprToValExpr(&RecursionInput{
 				e:          field.Type,
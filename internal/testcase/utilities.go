@@ -57,7 +57,7 @@ func MustPrettyPrintElement(e interface{}) string {
 	return writer.GetString()
 }
 
-func getArrayLen(expr ast.Expr) int {
+func getArrayLen(expr ast.Expr, logger log.FieldLogger) int {
 	if expr == nil {
 		return -1
 	}
@@ -65,22 +65,22 @@ func getArrayLen(expr ast.Expr) int {
 	case *ast.BasicLit:
 		len, err := strconv.Atoi(t.Value)
 		if err != nil {
-			log.WithError(err).Errorf("unable to convert basic lit val to integer")
+			logger.WithError(err).Errorf("unable to convert basic lit val to integer")
 		}
 		return len
 	case *ast.Ident:
 		if vSpec, ok := t.Obj.Decl.(*ast.ValueSpec); ok {
 			if len(vSpec.Values) != 1 {
-				log.Warningf("unexpected amount of vspec values")
+				logger.Warningf("unexpected amount of vspec values")
 			}
 			for _, e := range vSpec.Values {
-				return getArrayLen(e)
+				return getArrayLen(e, logger)
 			}
 		} else {
-			log.Warningf("WUT: %T", t)
+			logger.Warningf("WUT: %T", t)
 		}
 	default:
-		log.Warningf("unknown array len expression: %T", t)
+		logger.Warningf("unknown array len expression: %T", t)
 		return -1
 	}
 	return -1
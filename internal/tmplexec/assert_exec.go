@@ -1,6 +1,9 @@
 package tmplexec
 
 import (
+	"strings"
+
+	log "github.com/sirupsen/logrus"
 	"github.com/wimspaargaren/final-unit/internal/gen"
 	"gopkg.in/pipe.v2"
 )
@@ -18,8 +21,14 @@ type AssertExecutor struct {
 	Opts Opts
 }
 
-// Execute executes organism on assert template
+// goroutineLeakMessage message of the assertion of the generated tests detecting goroutine leaks
+const goroutineLeakMessage = "goroutine leak detected"
+
+// Execute executes organism on assert template. Generated tests failing only because a goroutine leak is detected
+// aren't treated as an error, leaks aren't checked while capturing values, so these failures are the expected outcome
+// for leaking functions. Any other failure of a generated test is an error
 func (v *AssertExecutor) Execute(organism *gen.Organism) (string, error) {
+	organism.HoistHelpers()
 	err := generateFileFromTemplate(organism, assertTemplate)
 	if err != nil {
 		return "", err
@@ -33,8 +42,67 @@ func (v *AssertExecutor) Execute(organism *gen.Organism) (string, error) {
 	)
 	out, err := pipe.Output(p)
 	if err != nil {
-		return "", err
+		failed := failedTests(string(out))
+		if isBuildFailure(string(out)) || len(failed) == 0 || !onlyGoroutineLeaks(string(out), failed) {
+			return "", err
+		}
+		log.Warningf("generated tests detect goroutine leaks: %s", strings.Join(failed, ", "))
 	}
 
 	return string(out), nil
 }
+
+// failedTests retrieves the names of the failed tests of verbose go test output,
+// the suites are omitted if the failed tests of the suite are reported
+func failedTests(out string) []string {
+	const failPrefix = "--- FAIL: "
+	res := []string{}
+	suites := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, failPrefix) {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, failPrefix))
+		if len(fields) == 0 {
+			continue
+		}
+		name := fields[0]
+		if strings.Contains(name, "/") {
+			res = append(res, name)
+		} else {
+			suites = append(suites, name)
+		}
+	}
+	if len(res) == 0 {
+		return suites
+	}
+	return res
+}
+
+// onlyGoroutineLeaks checks if the output of every failed test of verbose go test output reports a goroutine leak
+func onlyGoroutineLeaks(out string, failed []string) bool {
+	outputs := testOutputs(out)
+	for _, name := range failed {
+		if !strings.Contains(outputs[name], goroutineLeakMessage) {
+			return false
+		}
+	}
+	return true
+}
+
+// testOutputs retrieves the output per test of verbose go test output, output is attributed to the test
+// of the last preceding run, continue or name line e.g. === RUN   TestSuite/TestLeak0
+func testOutputs(out string) map[string]string {
+	res := make(map[string]string)
+	current := ""
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "===" && (fields[1] == "RUN" || fields[1] == "CONT" || fields[1] == "NAME") {
+			current = fields[2]
+			continue
+		}
+		res[current] += line + "\n"
+	}
+	return res
+}
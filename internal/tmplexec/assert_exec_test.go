@@ -0,0 +1,293 @@
+package tmplexec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+)
+
+func TestAssertTemplateHeader(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		HeaderTemplate:   "// Licensed under the MIT license\n{{ .Header }}",
+	}
+	generator, err := gen.New("../../test/data/inputs/example_global_names", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(assertTemplate)
+	require.NoError(t, err)
+	for _, f := range organisms[0].Files {
+		buf := &bytes.Buffer{}
+		require.NoError(t, tmpl.Execute(buf, f))
+		assert.True(t, strings.HasPrefix(buf.String(), "// Licensed under the MIT license\n"+gen.DefaultHeader+"\n"+generatedMarker+"\npackage globalnames\n"))
+	}
+}
+
+func TestAssertTemplateConcurrentInvocations(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:          3,
+		OrganismAmount:        1,
+		TestCasesPerFunc:      1,
+		ConcurrentInvocations: 8,
+	}
+	generator, err := gen.New("../../test/data/inputs/example_race", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(assertTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	f := organisms[0].Files[0]
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, f))
+	assert.Contains(t, buf.String(), `for invocation := 0; invocation < 8; invocation++ {
+	raceWg.Add(1)
+	go func() {
+		defer raceWg.Done()
+		Add(amount)
+	}()
+}
+raceWg.Wait()`)
+	assert.Equal(t, 2, strings.Count(buf.String(), "raceWg.Wait()"))
+}
+
+func TestValueTemplatePure(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+	}
+	generator, err := gen.New("../../test/data/inputs/example_pure", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(valueTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, organisms[0].Files[0]))
+	out := buf.String()
+
+	// The output of pure functions starts before the call, so their parameters are captured unchanged
+	median := testFunc(out, "TestMedian0")
+	assert.Equal(t, 2, strings.Count(median, `fmt.Println("<START;Median0>")`))
+	assert.Less(t, strings.LastIndex(median, `fmt.Println("<START;Median0>")`), strings.Index(median, ":= Median(values)"))
+	double := testFunc(out, "TestDouble0")
+	assert.Greater(t, strings.LastIndex(double, `fmt.Println("<START;Double0>")`), strings.Index(double, "Double(values)"))
+}
+
+// testFunc retrieves the source of the test func with given name from a rendered template
+func testFunc(out, name string) string {
+	start := strings.Index(out, name+"()")
+	end := strings.Index(out[start:], "\nfunc ")
+	return out[start : start+end]
+}
+
+func TestAssertTemplateScaffold(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Scaffold:         true,
+	}
+	generator, err := gen.New("../../test/data/inputs/example_scaffold", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(assertTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, organisms[0].Files[0]))
+	out := buf.String()
+
+	// Named results keep their name, results which can't be asserted automatically are captured as well
+	split := testFunc(out, "TestSplit0")
+	assert.Contains(t, split, "first, last, err := Split(name)")
+	assert.Contains(t, split, "// TODO: assert on first, last, err")
+	lookup := testFunc(out, "TestLookup0")
+	assert.Contains(t, lookup, "out, out2 := Lookup(id)")
+	assert.Contains(t, lookup, "// TODO: assert on out, out2")
+	assert.Contains(t, lookup, "_ = out2")
+	assert.Contains(t, testFunc(out, "TestLog0"), "// TODO: add assertions")
+	assert.NotContains(t, out, "s.")
+}
+
+func TestAssertTemplateAllocs(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Allocs:           true,
+	}
+	generator, err := gen.New("../../test/data/inputs/example_allocs", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(assertTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, organisms[0].Files[0]))
+	out := buf.String()
+
+	// The allocations are measured after the call and asserted against the maximum of the directive
+	count := testFunc(out, "TestCount0")
+	assert.Contains(t, count, "allocs := testing.AllocsPerRun(100, func() {\n\tCount(words)\n})")
+	assert.Less(t, strings.Index(count, ":= Count(words)"), strings.Index(count, "testing.AllocsPerRun"))
+	assert.Less(t, strings.Index(count, "testing.AllocsPerRun"), strings.Index(count, "s.LessOrEqual(allocs,float64(0))"))
+	// Without captured allocations the measurement is still used, as the value template measured it as well
+	join := testFunc(out, "TestJoin0")
+	assert.Contains(t, join, "testing.AllocsPerRun")
+	assert.Contains(t, join, "_ = allocs")
+}
+
+func TestValueTemplateAllocs(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 1,
+		Allocs:           true,
+	}
+	generator, err := gen.New("../../test/data/inputs/example_allocs", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(valueTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, organisms[0].Files[0]))
+	out := buf.String()
+
+	// The allocations are measured after the results are captured, also if only the directive's maximum is asserted
+	for _, name := range []string{"Join", "Count"} {
+		test := testFunc(out, "Test"+name+"0")
+		assert.Less(t, strings.Index(test, ":= "+name+"(words)"), strings.Index(test, "testing.AllocsPerRun"))
+		assert.Less(t, strings.Index(test, "testing.AllocsPerRun"), strings.LastIndex(test, `fmt.Println("<END;`+name+`0>")`))
+	}
+}
+
+func TestAssertTemplateTestNames(t *testing.T) {
+	opts := &gen.Options{
+		MaxRecursion:     3,
+		OrganismAmount:   1,
+		TestCasesPerFunc: 2,
+		TestNameTemplate: "TestUnit{{ .Func }}",
+	}
+	generator, err := gen.New("../../test/data/inputs/example_allocs", opts)
+	require.NoError(t, err)
+	organisms := generator.GetTestCases()
+	require.Equal(t, 1, len(organisms))
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"add": func(x int) int {
+			return x + 1
+		},
+	}).Parse(assertTemplate)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(organisms[0].Files))
+	buf := &bytes.Buffer{}
+	require.NoError(t, tmpl.Execute(buf, organisms[0].Files[0]))
+	out := buf.String()
+
+	// Test methods are named using the template, colliding names are made unique
+	assert.Contains(t, out, "Suite) TestUnitJoin(){")
+	assert.Contains(t, out, "Suite) TestUnitJoin_2(){")
+	assert.NotContains(t, out, "TestJoin0")
+}
+
+func TestOnlyGoroutineLeaks(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Out      string
+		Expected bool
+	}{
+		{
+			Name: "leak",
+			Out: "=== RUN   TestSuite\n=== RUN   TestSuite/TestLeak0\n    leak_test.go:12: \n        \tError:      \t\"3\" is not less than or equal to \"2\"\n" +
+				"        \tMessages:   \tgoroutine leak detected\n=== RUN   TestSuite/TestSum0\n--- FAIL: TestSuite (1.00s)\n" +
+				"    --- FAIL: TestSuite/TestLeak0 (1.00s)\n    --- PASS: TestSuite/TestSum0 (0.00s)\nFAIL\n",
+			Expected: true,
+		},
+		{
+			Name: "leak and failing assertion",
+			Out: "=== RUN   TestSuite\n=== RUN   TestSuite/TestLeak0\n        \tMessages:   \tgoroutine leak detected\n" +
+				"=== RUN   TestSuite/TestSum0\n    sum_test.go:20: \n        \tError:      \tNot equal: \n--- FAIL: TestSuite (1.00s)\n" +
+				"    --- FAIL: TestSuite/TestLeak0 (1.00s)\n    --- FAIL: TestSuite/TestSum0 (0.00s)\nFAIL\n",
+			Expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, onlyGoroutineLeaks(test.Out, failedTests(test.Out)))
+		})
+	}
+}
+
+func TestFailedTests(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Out      string
+		Expected []string
+	}{
+		{
+			Name:     "passes",
+			Out:      "=== RUN   TestSuite\n=== RUN   TestSuite/TestDouble0\n--- PASS: TestSuite (0.00s)\n    --- PASS: TestSuite/TestDouble0 (0.00s)\nPASS\n",
+			Expected: []string{},
+		},
+		{
+			Name:     "failed test case",
+			Out:      "=== RUN   TestSuite\n--- FAIL: TestSuite (1.00s)\n    --- FAIL: TestSuite/TestLeak0 (1.00s)\n    --- PASS: TestSuite/TestSum0 (0.00s)\nFAIL\n",
+			Expected: []string{"TestSuite/TestLeak0"},
+		},
+		{
+			Name:     "failed suite",
+			Out:      "=== RUN   TestSuite\n--- FAIL: TestSuite (0.00s)\nFAIL\n",
+			Expected: []string{"TestSuite"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, failedTests(test.Out))
+		})
+	}
+}
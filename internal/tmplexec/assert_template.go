@@ -1,64 +1,107 @@
 package tmplexec
 
-const assertTemplate = `// Code generated by finalunit, visit us at https://github.com/wimspaargaren/final-unit
+const assertTemplate = `{{ .Header }}
+` + generatedMarker + `
 package {{.PackageName}}
 
 import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+{{- if .UsesCmp }}
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+{{- end }}
+{{- if .AssertImport }}
+	{{ .AssertImport }}
+{{- end }}
 )
 
 type {{.SuiteName}}Suite struct {
 	suite.Suite
 }
 
+{{range .Helpers}}
+{{ . }}
+{{end}}
+
 {{/* assign file to var for usage inside loop  */}}
 {{ $test := .}}
 
 {{ range $funcName, $testCases := .TestCases }}
 {{/* range test cases */}}
 {{range $index, $testCase := $testCases}}
-{{/* Print declarations */}}
+{{/* Print declarations, unless declared in the shared declarations file */}}
+{{ if not $test.DeclsShared }}
 {{range  $testCase.Decls}}
 {{ . }}
 {{end}}
+{{ end }}
 {{/* Print functions */}}
+{{ if $testCase.SourcePosition }}
+// Source: {{ $testCase.SourcePosition }}
+{{- end }}
 
-func (s *{{$test.SuiteName}}Suite) Test{{ $funcName }}{{  $index }}(){
+func (s *{{$test.SuiteName}}Suite) {{ $test.TestName $funcName $index }}(){
+{{/* If enabled, panics observed at runtime are reported by a skipped failing test */}}
+{{ if $testCase.ReportsPanic }}
+{{range $testCase.PanicReport }}// {{ . }}
+{{end}}
+{{ $testCase.PanicSkipStmt }}
+{{ end }}
 {{ if $testCase.HasChan }}
 wg := sync.WaitGroup{}
 wg.Add(1)
 {{ end }}
+{{ if $testCase.HasLeakCheck }}
+{{ $testCase.LeakCheckIdent }} := runtime.NumGoroutine()
+{{ end }}
 {{range  $testCase.Stmts}}	{{ . }}
 {{end}}
 {{/* If run time info reported that a function may panic wrap it in a Panics func */}}
-{{ if $testCase.RunTimeInfo.Panics }}
+{{ if $testCase.ReportsPanic }}
+{{ $testCase.FuncStmt }}
+{{ else if and $testCase.RunTimeInfo.Panics (not $testCase.Opts.Scaffold) }}
 s.Panics(func(){
 	{{ $testCase.FuncStmt }}
 })
-{{/* If run time detected valid use normal assert */}}
-{{ else if $testCase.RunTimeInfo.IsValid }}
+{{/* If run time detected valid use normal assert, scaffolding is emitted regardless */}}
+{{ else if or $testCase.Opts.Scaffold $testCase.RunTimeInfo.IsValid }}
 {{ if $testCase.HasChan }}
 go func(){
 	defer func() {
 		if r := recover(); r != nil {
-		fmt.Println("Recovered in Test{{ $funcName }}{{  $index }}", r)
+		fmt.Println("Recovered in {{ $test.TestName $funcName $index }}", r)
 	}
 	defer wg.Done()
 	}()
 {{ end }}
+{{/* The oracle is called first, so it receives the arguments before the function may modify them */}}
+{{ if not $testCase.Opts.Scaffold }}
+{{range  $testCase.OracleCallStmts}}{{ . }}
+{{end}}
+{{ end }}
 {{ if $testCase.HasPrintStmts }}
 {{ $testCase.FuncPrintStmt }}
 {{ else }}
 {{ $testCase.FuncStmt }}
 {{ end }}
 
-{{range  $testCase.RunTimeInfo.GetAssertStmts }}{{ . }}
+{{ if $testCase.Opts.Scaffold }}
+// {{ $testCase.ScaffoldTODO }}
+{{ else }}
+{{range  $testCase.ResultAssertStmts }}{{ . }}
 {{end}}
+{{ end }}
 {{/* Ensure values are always used */}}
 {{range  $testCase.ResultUsageStmts}}{{ . }}
 {{end}}
+{{/* Allocations are measured after the asserted call, so the repeated calls don't affect the asserted values */}}
+{{ if $testCase.HasAllocsCheck }}
+{{ $testCase.AllocsStmt }}
+{{range  $testCase.AllocsAssertStmts }}{{ . }}
+{{end}}
+{{ end }}
 {{ if $testCase.HasChan }}
 }()
 {{range  $testCase.ChanIdents}}	close({{ . }})
@@ -66,6 +109,25 @@ go func(){
 // Wait until function is executed
 wg.Wait()
 {{ end }}
+{{ if $testCase.HasRaceCheck }}
+// Call the function concurrently, so data races are detected when running go test -race
+{{ $testCase.RaceCheckIdent }} := sync.WaitGroup{}
+for {{ $testCase.RaceIndexIdent }} := 0; {{ $testCase.RaceIndexIdent }} < {{ $testCase.Opts.ConcurrentInvocations }}; {{ $testCase.RaceIndexIdent }}++ {
+	{{ $testCase.RaceCheckIdent }}.Add(1)
+	go func() {
+		defer {{ $testCase.RaceCheckIdent }}.Done()
+		{{ $testCase.FuncStmt }}
+	}()
+}
+{{ $testCase.RaceCheckIdent }}.Wait()
+{{ end }}
+{{ if $testCase.HasLeakCheck }}
+// Give spawned goroutines time to settle before checking for leaks
+for i := 0; i < 100 && runtime.NumGoroutine() > {{ $testCase.LeakCheckIdent }}; i++ {
+	time.Sleep(10 * time.Millisecond)
+}
+s.LessOrEqual(runtime.NumGoroutine(), {{ $testCase.LeakCheckIdent }}, "goroutine leak detected")
+{{ end }}
 {{/* If not valid add FIXME comment */}}
 {{ else }}
 // FIXME: non deterministic results detected, please add assert statements manually
@@ -0,0 +1,78 @@
+package tmplexec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"gopkg.in/pipe.v2"
+)
+
+// NewCaseCoverageExecutor creates an executor measuring the statements covered by a single test case
+func NewCaseCoverageExecutor(opts Opts) *CaseCoverageExecutor {
+	return &CaseCoverageExecutor{
+		Opts: opts,
+	}
+}
+
+// CaseCoverageExecutor executor measuring the coverage of individual test cases
+type CaseCoverageExecutor struct {
+	Opts Opts
+}
+
+// CaseCoverage executes a single test case of the organism on the coverage template
+// and retrieves the blocks of statements it covers
+func (v *CaseCoverageExecutor) CaseCoverage(organism *gen.Organism, funcName string, index int) (map[string]bool, error) {
+	err := generateFileFromTemplate(organism, coverageTemplate)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := ioutil.TempFile("", "finalunit-*.out")
+	if err != nil {
+		return nil, err
+	}
+	err = profile.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err := os.Remove(profile.Name())
+		if err != nil {
+			log.WithError(err).Error("unable to remove cover profile")
+		}
+	}()
+	script := pipe.Script(
+		pipe.Exec("goimports", "-w", v.Opts.Dir),
+		pipe.Exec("go", "test", "./"+v.Opts.Dir, "-coverprofile="+profile.Name(), "-testify.m", fmt.Sprintf("^Test%s%d$", funcName, index)),
+	)
+	out, err := pipe.Output(pipe.Line(script))
+	if err != nil {
+		log.Debugf("unable to measure coverage of test case: %s", string(out))
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(profile.Name())
+	if err != nil {
+		return nil, err
+	}
+	return ParseCoverProfile(string(data)), nil
+}
+
+// ParseCoverProfile retrieves the covered blocks of a go cover profile,
+// blocks are identified by their position e.g. file.go:10.2,12.3
+func ParseCoverProfile(profile string) map[string]bool {
+	res := make(map[string]bool)
+	for _, line := range strings.Split(profile, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		if fields[2] == "0" {
+			continue
+		}
+		res[fields[0]] = true
+	}
+	return res
+}
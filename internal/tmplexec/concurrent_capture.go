@@ -0,0 +1,89 @@
+package tmplexec
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"gopkg.in/pipe.v2"
+)
+
+// captureFunc captures the output of the test cases of a single function
+type captureFunc func(funcName string) (string, error)
+
+// funcNames retrieves the sorted names of the functions of which the organism contains test cases
+func funcNames(organism *gen.Organism) []string {
+	seen := make(map[string]bool)
+	res := []string{}
+	for _, f := range organism.Files {
+		for funcName := range f.TestCases {
+			if seen[funcName] {
+				continue
+			}
+			seen[funcName] = true
+			res = append(res, funcName)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// captureConcurrently captures the output of every function using at most concurrency goroutines,
+// the outputs are joined in the order of the given functions regardless of the order they finish in
+func captureConcurrently(names []string, concurrency int, capture captureFunc) (string, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	outputs := make([]string, len(names))
+	errs := make([]error, len(names))
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, name := range names {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			outputs[i], errs[i] = capture(name)
+		}(i, name)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return "", fmt.Errorf("unable to capture values of %s: %w", names[i], err)
+		}
+	}
+	return strings.Join(outputs, ""), nil
+}
+
+// testNamePattern creates the pattern matching exactly the test methods of the value template of given function,
+// e.g. ^(TestFoo0|TestFoo1)$, so the test cases of a function with the same prefix e.g. Foo1 aren't matched
+func testNamePattern(organism *gen.Organism, funcName string) string {
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, f := range organism.Files {
+		for index := range f.TestCases[funcName] {
+			name := regexp.QuoteMeta(fmt.Sprintf("Test%s%d", funcName, index))
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return "^(" + strings.Join(names, "|") + ")$"
+}
+
+// captureFuncValues runs only the test cases of given function on the value template
+func (v *ValueExecutor) captureFuncValues(organism *gen.Organism, funcName string) (string, error) {
+	out, err := pipe.Output(pipe.Exec("go", "test", v.Opts.Dir, "-v", "-testify.m", testNamePattern(organism, funcName)))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,80 @@
+package tmplexec
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+	"github.com/wimspaargaren/final-unit/internal/testcase"
+)
+
+func TestCaptureConcurrently(t *testing.T) {
+	names := []string{"A", "B", "C", "D", "E"}
+	delays := map[string]time.Duration{"A": 40, "B": 10, "C": 30, "D": 0, "E": 20}
+	capture := func(funcName string) (string, error) {
+		// Later functions finish first, the result is still ordered
+		time.Sleep(delays[funcName] * time.Millisecond)
+		return fmt.Sprintf("<START;%s0>\n<END;%s0>\n", funcName, funcName), nil
+	}
+	for _, concurrency := range []int{0, 1, 2, 5} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			res, err := captureConcurrently(names, concurrency, capture)
+			assert.NoError(t, err)
+			assert.Equal(t, "<START;A0>\n<END;A0>\n<START;B0>\n<END;B0>\n<START;C0>\n<END;C0>\n<START;D0>\n<END;D0>\n<START;E0>\n<END;E0>\n", res)
+		})
+	}
+}
+
+func TestCaptureConcurrentlyError(t *testing.T) {
+	errCapture := errors.New("exit status 2")
+	_, err := captureConcurrently([]string{"A", "B"}, 2, func(funcName string) (string, error) {
+		if funcName == "B" {
+			return "", errCapture
+		}
+		return "", nil
+	})
+	assert.True(t, errors.Is(err, errCapture))
+}
+
+func TestTestNamePattern(t *testing.T) {
+	organism := &gen.Organism{
+		Files: []*gen.File{
+			{TestCases: map[string][]*testcase.TestCase{"Foo1": {{}, {}}, "Foo10": {{}}}},
+			{TestCases: map[string][]*testcase.TestCase{"Foo1": {{}}}},
+		},
+	}
+	pattern := testNamePattern(organism, "Foo1")
+	assert.Equal(t, "^(TestFoo10|TestFoo11)$", pattern)
+	// TestFoo100 is the test case of Foo10, which a prefix pattern like ^TestFoo1[0-9]+$ would match as well
+	re := regexp.MustCompile(pattern)
+	assert.True(t, re.MatchString("TestFoo11"))
+	assert.False(t, re.MatchString("TestFoo100"))
+	assert.False(t, re.MatchString("TestFoo12"))
+}
+
+// BenchmarkCaptureConcurrently captures a package with many functions, of which every
+// go test process takes roughly the same time
+func BenchmarkCaptureConcurrently(b *testing.B) {
+	names := []string{}
+	for i := 0; i < 64; i++ {
+		names = append(names, fmt.Sprintf("Func%d", i))
+	}
+	capture := func(funcName string) (string, error) {
+		time.Sleep(time.Millisecond)
+		return funcName, nil
+	}
+	for _, concurrency := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("concurrency %d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := captureConcurrently(names, concurrency, capture)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -3,13 +3,9 @@ package tmplexec
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
-	"text/template"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/wimspaargaren/final-unit/internal/gen"
 	"gopkg.in/pipe.v2"
 )
@@ -29,64 +25,97 @@ type CoverageExecutor struct {
 
 // Execute executes organism on assert template
 func (v *CoverageExecutor) Execute(organism *gen.Organism) (string, error) {
-	for _, f := range organism.Files {
-		ext := filepath.Ext(f.FileName)
-		filePath := f.FileName
-		executionPath := strings.TrimSuffix(filePath, ext) + "_test.go"
-		tmpl, err := template.New("").Funcs(template.FuncMap{
-			"add": func(x int) int {
-				return x + 1
-			},
-		}).Parse(coverageTemplate)
-		if err != nil {
-			return "", err
-		}
-		file, err := os.Create(filepath.Clean(executionPath))
-		if err != nil {
-			return "", err
-		}
-		defer func() {
-			err := file.Close()
-			if err != nil {
-				log.WithError(err).Error("unable to close file")
-			}
-		}()
-
-		err = tmpl.Execute(file, f)
-		if err != nil {
-			return "", err
-		}
+	err := generateFileFromTemplate(organism, coverageTemplate)
+	if err != nil {
+		return "", err
 	}
 	script := pipe.Script(
 		pipe.Exec("goimports", "-w", v.Opts.Dir),
-		pipe.Exec("go", "test", "./"+v.Opts.Dir, "-cover"),
+		// Verbose output includes the panics recovered by the test cases
+		pipe.Exec("go", "test", "./"+v.Opts.Dir, "-cover", "-v"),
 	)
 	p := pipe.Line(
 		script,
 	)
 	out, err := pipe.Output(p)
+	err = applyStagedFitness(organism, string(out), err)
 	if err != nil {
 		return string(out), err
 	}
-	if strings.Contains(string(out), "no statements") {
-		organism.Fitness = maxTestScore
-		return fmt.Sprintf("%f", maxTestScore), err
+	return fmt.Sprintf("%f", organism.Fitness), nil
+}
+
+// applyStagedFitness sets the fitness of an organism from the output of measuring its coverage.
+// Organisms which don't compile get no fitness instead of failing the evolution, so they're bred out,
+// organisms of which test cases panic are marked, as running without panics outranks coverage
+func applyStagedFitness(organism *gen.Organism, out string, testErr error) error {
+	organism.BuildFailed = false
+	organism.Panics = false
+	if testErr != nil {
+		if !isBuildFailure(out) {
+			return testErr
+		}
+		organism.BuildFailed = true
+		organism.Fitness = 0
+		return nil
+	}
+	f, err := parseCoverage(out)
+	if err != nil {
+		return err
 	}
-	splitted := strings.Split(string(out), "coverage: ")
+	organism.Panics = strings.Contains(out, recoveredPrefix)
+	organism.Fitness = f
+	return nil
+}
+
+// isBuildFailure checks if go test output reports the tests couldn't be built
+func isBuildFailure(out string) bool {
+	return strings.Contains(out, "[build failed]") || strings.Contains(out, "[setup failed]")
+}
+
+// recoveredPrefix prefix printed by the coverage template when a test case recovered from a panic
+const recoveredPrefix = "Recovered in Test"
+
+// ErrTestsFailed tests of the package failed, the coverage is measured using the passing tests
+var ErrTestsFailed = fmt.Errorf("tests failed")
+
+// MeasureCoverage measures the coverage of the tests currently present in given directory. If tests fail
+// the coverage of the passing tests is returned together with an ErrTestsFailed error
+func MeasureCoverage(dir string) (float64, error) {
+	out, err := pipe.Output(pipe.Exec("go", "test", "./"+dir, "-cover"))
+	return coverageResult(string(out), err)
+}
+
+// coverageResult retrieves the coverage from the output of measuring the coverage of the tests of a package,
+// failing tests are reported by an ErrTestsFailed error, tests which can't be built by any other error
+func coverageResult(out string, testErr error) (float64, error) {
+	if testErr != nil && (isBuildFailure(out) || !strings.Contains(out, "coverage: ")) {
+		return 0, fmt.Errorf("unable to measure coverage: %w, output: %s", testErr, out)
+	}
+	coverage, err := parseCoverage(out)
+	if err != nil {
+		return 0, err
+	}
+	if testErr != nil {
+		return coverage, fmt.Errorf("%w: %s", ErrTestsFailed, strings.Join(failedTests(out), ", "))
+	}
+	return coverage, nil
+}
+
+// parseCoverage parses the coverage percentage of go test output
+func parseCoverage(out string) (float64, error) {
+	if strings.Contains(out, "no statements") {
+		return maxTestScore, nil
+	}
+	splitted := strings.Split(out, "coverage: ")
 
 	// We don't have coverage just return
 	if len(splitted) == 1 {
-		organism.Fitness = 0
-		return "0", nil
+		return 0, nil
 	}
 	result := strings.Split(splitted[1], "%")[0]
 	const bitSize = 64
-	f, err := strconv.ParseFloat(result, bitSize)
-	if err != nil {
-		return "", err
-	}
-	organism.Fitness = f
-	return fmt.Sprintf("%f", f), nil
+	return strconv.ParseFloat(result, bitSize)
 }
 
 const maxTestScore float64 = 100
@@ -1,6 +1,7 @@
 package tmplexec
 
 const coverageTemplate = `// Coverage template
+` + generatedMarker + `
 package {{.PackageName}}
 
 import (
@@ -19,10 +20,12 @@ type {{.SuiteName}}Suite struct {
 {{ range $funcName, $testCases := .TestCases }}
 {{/* range test cases */}}
 {{range $index, $testCase := $testCases}}
-{{/* Print declarations */}}
+{{/* Print declarations, unless declared in the shared declarations file */}}
+{{ if not $test.DeclsShared }}
 {{range  $testCase.Decls}}
 {{ . }}
 {{end}}
+{{ end }}
 {{/* Print functions */}}
 
 func (s *{{$test.SuiteName}}Suite) Test{{ $funcName }}{{  $index }}(){
@@ -0,0 +1,138 @@
+package tmplexec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+)
+
+func TestParseCoverage(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Out      string
+		Expected float64
+	}{
+		{
+			Name:     "coverage",
+			Out:      "ok  \tgithub.com/x/y\t0.005s\tcoverage: 41.2% of statements\n",
+			Expected: 41.2,
+		},
+		{
+			Name:     "no test files",
+			Out:      "?   \tgithub.com/x/y\t[no test files]\n",
+			Expected: 0,
+		},
+		{
+			Name:     "no statements",
+			Out:      "ok  \tgithub.com/x/y\t0.005s\tcoverage: [no statements]\n",
+			Expected: maxTestScore,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res, err := parseCoverage(test.Out)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Expected, res)
+		})
+	}
+}
+
+func TestCoverageResult(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Out       string
+		Err       error
+		Expected  float64
+		ErrIs     error
+		ExpectErr bool
+	}{
+		{
+			Name:     "passes",
+			Out:      "ok  \tgithub.com/x/y\t0.005s\tcoverage: 41.2% of statements\n",
+			Expected: 41.2,
+		},
+		{
+			Name:     "failing tests",
+			Out:      "--- FAIL: TestSuite (0.00s)\n    --- FAIL: TestSuite/TestDivide (0.00s)\nFAIL\ncoverage: 30.0% of statements\nFAIL\tgithub.com/x/y\t0.005s\n",
+			Err:      errors.New("exit status 1"),
+			Expected: 30,
+			ErrIs:    ErrTestsFailed,
+		},
+		{
+			Name:      "build failed",
+			Out:       "# github.com/x/y [github.com/x/y.test]\n./y_test.go:12:2: undefined: x\nFAIL\tgithub.com/x/y [build failed]\n",
+			Err:       errors.New("exit status 2"),
+			ExpectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res, err := coverageResult(test.Out, test.Err)
+			assert.Equal(t, test.Expected, res)
+			switch {
+			case test.ErrIs != nil:
+				assert.ErrorIs(t, err, test.ErrIs)
+			case test.ExpectErr:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, ErrTestsFailed)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+	_, err := coverageResult("--- FAIL: TestSuite (0.00s)\n    --- FAIL: TestSuite/TestDivide (0.00s)\nFAIL\ncoverage: 30.0% of statements\n", errors.New("exit status 1"))
+	assert.EqualError(t, err, "tests failed: TestSuite/TestDivide")
+}
+
+func TestApplyStagedFitness(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Out         string
+		Err         error
+		Fitness     float64
+		BuildFailed bool
+		Panics      bool
+		ExpectErr   bool
+	}{
+		{
+			Name:    "passes",
+			Out:     "=== RUN   TestSuite\n--- PASS: TestSuite (0.00s)\nPASS\ncoverage: 41.2% of statements\nok  \tgithub.com/x/y\t0.005s\tcoverage: 41.2% of statements\n",
+			Fitness: 41.2,
+		},
+		{
+			Name:    "panics",
+			Out:     "=== RUN   TestSuite\nRecovered in TestDivide0 runtime error: integer divide by zero\n--- PASS: TestSuite (0.00s)\nPASS\ncoverage: 60.0% of statements\nok  \tgithub.com/x/y\t0.005s\tcoverage: 60.0% of statements\n",
+			Fitness: 60,
+			Panics:  true,
+		},
+		{
+			Name:        "build failed",
+			Out:         "# github.com/x/y [github.com/x/y.test]\n./y_test.go:12:2: undefined: x\nFAIL\tgithub.com/x/y [build failed]\n",
+			Err:         errors.New("exit status 2"),
+			BuildFailed: true,
+		},
+		{
+			Name:      "other failure",
+			Out:       "--- FAIL: TestSuite (0.00s)\nFAIL\tgithub.com/x/y\t0.005s\n",
+			Err:       errors.New("exit status 1"),
+			ExpectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			// Fitness of a previous generation is reset
+			organism := &gen.Organism{Fitness: 80, Panics: true}
+			err := applyStagedFitness(organism, test.Out, test.Err)
+			if test.ExpectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Fitness, organism.Fitness)
+			assert.Equal(t, test.BuildFailed, organism.BuildFailed)
+			assert.Equal(t, test.Panics, organism.Panics)
+		})
+	}
+}
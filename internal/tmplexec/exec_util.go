@@ -1,6 +1,11 @@
 package tmplexec
 
 import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,15 +19,28 @@ import (
 type Opts struct {
 	Dir      string
 	Override bool
+	// Concurrency amount of functions of which the values are captured at the same time,
+	// if at most one all functions are captured in a single run
+	Concurrency int
 }
 
+// ErrNotGenerated a file which would be overwritten wasn't generated by final-unit
+var ErrNotGenerated = fmt.Errorf("refusing to overwrite file not generated by final-unit")
+
+// generatedMarker marks the package level files generated by final-unit, which are recreated on every run
+const generatedMarker = "// final-unit:generated"
+
 // IExecutor interface for executor of template
 type IExecutor interface {
 	Execute(organism *gen.Organism) (string, error)
 }
 
+// generateFileFromTemplate creates the test files of the organism, marked as generated by final-unit. Test files
+// generated per function don't correspond to a source file, so hand-written files of the same name aren't overwritten.
+// Generated files which aren't created any more, e.g. of removed functions, are deleted
 func generateFileFromTemplate(organism *gen.Organism, templateString string) error {
-	for _, f := range organism.Files {
+	written := make(map[string]bool)
+	for _, f := range organism.OutputFiles() {
 		ext := filepath.Ext(f.FileName)
 		filePath := f.FileName
 		executionPath := strings.TrimSuffix(filePath, ext) + "_test.go"
@@ -34,10 +52,11 @@ func generateFileFromTemplate(organism *gen.Organism, templateString string) err
 		if err != nil {
 			return err
 		}
-		file, err := os.Create(filepath.Clean(executionPath))
+		file, err := createTestFile(executionPath, f.DeclsShared)
 		if err != nil {
 			return err
 		}
+		written[filepath.Clean(executionPath)] = true
 		defer func() {
 			err := file.Close()
 			if err != nil {
@@ -49,5 +68,202 @@ func generateFileFromTemplate(organism *gen.Organism, templateString string) err
 			return err
 		}
 	}
+	sharedDecls, err := generateSharedDecls(organism)
+	if err != nil {
+		return err
+	}
+	testMain, err := generateTestMain(organism)
+	if err != nil {
+		return err
+	}
+	exportShims, err := generateExportShims(organism)
+	if err != nil {
+		return err
+	}
+	written[sharedDecls] = true
+	written[testMain] = true
+	written[exportShims] = true
+	return removeStaleGeneratedFiles(organism, written)
+}
+
+// createTestFile creates a test file, only test files generated per function are checked to be generated by final-unit
+func createTestFile(path string, perFunc bool) (*os.File, error) {
+	if perFunc {
+		return createGeneratedFile(path)
+	}
+	return os.Create(filepath.Clean(path))
+}
+
+// removeStaleGeneratedFiles deletes the test files in the directory of the organism marked as generated by final-unit,
+// which weren't written for the organism
+func removeStaleGeneratedFiles(organism *gen.Organism, written map[string]bool) error {
+	if len(organism.Files) == 0 {
+		return nil
+	}
+	dir, _ := filepath.Split(organism.Files[0].FileName)
+	fileNames, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return err
+	}
+	for _, fileName := range fileNames {
+		if written[filepath.Clean(fileName)] {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Clean(fileName))
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(content), generatedMarker) {
+			continue
+		}
+		log.Debugf("removing stale generated file: %s", fileName)
+		err = os.Remove(fileName)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// generateSharedDecls creates the file declaring the declarations shared by the test files generated per function,
+// the file is always created in that case, so declarations of previous generations don't linger.
+// The path of the created file is returned
+func generateSharedDecls(organism *gen.Organism) (string, error) {
+	if len(organism.Files) == 0 || !organism.Files[0].Opts.FilePerFunc {
+		return "", nil
+	}
+	tmpl, err := template.New("").Parse(sharedDeclsTemplate)
+	if err != nil {
+		return "", err
+	}
+	dir, _ := filepath.Split(organism.Files[0].FileName)
+	path := filepath.Clean(filepath.Join(dir, gen.SharedDeclsFileName))
+	file, err := createGeneratedFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			log.WithError(err).Error("unable to close file")
+		}
+	}()
+	return path, tmpl.Execute(file, struct {
+		Header      string
+		PackageName string
+		Decls       []string
+	}{
+		Header:      organism.Files[0].Header(),
+		PackageName: organism.Files[0].PackageName,
+		Decls:       organism.SharedDecls(),
+	})
+}
+
+// generateExportShims creates the file declaring the exported wrappers of the unexported functions
+// called by the test cases, no file is created if no wrappers are needed. The path of the created file is returned
+func generateExportShims(organism *gen.Organism) (string, error) {
+	shims := organism.ExportShims()
+	if len(shims) == 0 || len(organism.Files) == 0 {
+		return "", nil
+	}
+	tmpl, err := template.New("").Parse(exportShimTemplate)
+	if err != nil {
+		return "", err
+	}
+	dir, _ := filepath.Split(organism.Files[0].FileName)
+	path := filepath.Clean(filepath.Join(dir, gen.ExportShimFileName))
+	file, err := createGeneratedFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			log.WithError(err).Error("unable to close file")
+		}
+	}()
+	return path, tmpl.Execute(file, struct {
+		Header      string
+		PackageName string
+		Shims       []string
+	}{
+		Header:      organism.Files[0].Header(),
+		PackageName: organism.Files[0].PackageName,
+		Shims:       shims,
+	})
+}
+
+// generateTestMain creates the file declaring the TestMain setting up and tearing down the fixture of the package,
+// a single file is created for all test files of the package. No file is created if no TestMain is needed,
+// or if the package already declares a TestMain in a test file which isn't generated by final-unit.
+// The path of the created file is returned
+func generateTestMain(organism *gen.Organism) (string, error) {
+	testMain := organism.TestMain()
+	if testMain == "" {
+		return "", nil
+	}
+	tmpl, err := template.New("").Parse(testMainTemplate)
+	if err != nil {
+		return "", err
+	}
+	dir, _ := filepath.Split(organism.Files[0].FileName)
+	if fileName, ok := findTestMain(dir); ok {
+		log.Warningf("TestMain already declared in %s, setup and teardown directives aren't applied", fileName)
+		return "", nil
+	}
+	path := filepath.Clean(filepath.Join(dir, gen.TestMainFileName))
+	file, err := createGeneratedFile(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		err := file.Close()
+		if err != nil {
+			log.WithError(err).Error("unable to close file")
+		}
+	}()
+	return path, tmpl.Execute(file, struct {
+		Header      string
+		PackageName string
+		TestMain    string
+	}{
+		Header:      organism.Files[0].Header(),
+		PackageName: organism.Files[0].PackageName,
+		TestMain:    testMain,
+	})
+}
+
+// createGeneratedFile creates a package level file generated by final-unit, an existing file is only overwritten
+// if it's marked as generated by final-unit
+func createGeneratedFile(path string) (*os.File, error) {
+	content, err := ioutil.ReadFile(filepath.Clean(path))
+	if err == nil && !strings.Contains(string(content), generatedMarker) {
+		return nil, fmt.Errorf("%w: %s", ErrNotGenerated, path)
+	}
+	return os.Create(filepath.Clean(path))
+}
+
+// findTestMain finds the test file in the directory declaring a TestMain, test files generated by final-unit are ignored
+func findTestMain(dir string) (string, bool) {
+	fileNames, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return "", false
+	}
+	for _, fileName := range fileNames {
+		content, err := ioutil.ReadFile(filepath.Clean(fileName))
+		if err != nil || strings.Contains(string(content), generatedMarker) {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), fileName, content, 0)
+		if err != nil {
+			continue
+		}
+		for _, decl := range f.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if ok && funcDecl.Recv == nil && funcDecl.Name.Name == "TestMain" {
+				return fileName, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,102 @@
+package tmplexec
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wimspaargaren/final-unit/internal/gen"
+)
+
+func TestCreateGeneratedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "final-unit")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	handWritten := filepath.Join(dir, "export_test.go")
+	assert.NoError(t, ioutil.WriteFile(handWritten, []byte("package x\n\nvar Exported = unexported\n"), 0o600))
+	generated := filepath.Join(dir, "finalunit_export_test.go")
+	assert.NoError(t, ioutil.WriteFile(generated, []byte(generatedMarker+"\npackage x\n"), 0o600))
+
+	_, err = createGeneratedFile(handWritten)
+	assert.True(t, errors.Is(err, ErrNotGenerated))
+	content, err := ioutil.ReadFile(handWritten)
+	assert.NoError(t, err)
+	assert.Equal(t, "package x\n\nvar Exported = unexported\n", string(content))
+
+	for _, path := range []string{generated, filepath.Join(dir, "new_test.go")} {
+		file, err := createGeneratedFile(path)
+		assert.NoError(t, err)
+		assert.NoError(t, file.Close())
+		content, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Empty(t, content)
+	}
+}
+
+func TestFindTestMain(t *testing.T) {
+	dir, err := ioutil.TempDir("", "final-unit")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	generated := filepath.Join(dir, "finalunit_main_test.go")
+	assert.NoError(t, ioutil.WriteFile(generated, []byte(generatedMarker+"\npackage x\n\nfunc TestMain(m *testing.M) {}\n"), 0o600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "db.go"), []byte("package x\n\nfunc TestMain() {}\n"), 0o600))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "db_test.go"), []byte("package x\n\ntype s struct{}\n\nfunc (s) TestMain() {}\n"), 0o600))
+
+	_, ok := findTestMain(dir)
+	assert.False(t, ok)
+
+	handWritten := filepath.Join(dir, "setup_test.go")
+	assert.NoError(t, ioutil.WriteFile(handWritten, []byte("package x_test\n\nfunc TestMain(m *testing.M) {}\n"), 0o600))
+	fileName, ok := findTestMain(dir)
+	assert.True(t, ok)
+	assert.Equal(t, handWritten, fileName)
+}
+
+func TestRemoveStaleGeneratedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "final-unit")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	written := filepath.Join(dir, "sum_test.go")
+	stale := filepath.Join(dir, "finalunit_export_test.go")
+	handWritten := filepath.Join(dir, "export_test.go")
+	for _, path := range []string{written, stale} {
+		assert.NoError(t, ioutil.WriteFile(path, []byte(generatedMarker+"\npackage x\n"), 0o600))
+	}
+	assert.NoError(t, ioutil.WriteFile(handWritten, []byte("package x\n\nvar Exported = unexported\n"), 0o600))
+
+	organism := &gen.Organism{Files: []*gen.File{{FileName: filepath.Join(dir, "sum.go")}}}
+	assert.NoError(t, removeStaleGeneratedFiles(organism, map[string]bool{written: true}))
+
+	for path, exists := range map[string]bool{written: true, stale: false, handWritten: true} {
+		_, err := os.Stat(path)
+		assert.Equal(t, exists, err == nil, path)
+	}
+}
+
+func TestGenerateExportShimsWithoutShims(t *testing.T) {
+	dir, err := ioutil.TempDir("", "final-unit")
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, os.RemoveAll(dir))
+	}()
+	shimFile := filepath.Join(dir, gen.ExportShimFileName)
+	assert.NoError(t, ioutil.WriteFile(shimFile, []byte(generatedMarker+"\npackage x\n\nvar ExportSum = sum\n"), 0o600))
+	organism := &gen.Organism{Files: []*gen.File{{FileName: filepath.Join(dir, "sum.go")}}}
+
+	// No wrappers are needed any more, so the file isn't written and is removed as stale
+	fileName, err := generateExportShims(organism)
+	assert.NoError(t, err)
+	assert.Equal(t, "", fileName)
+	assert.NoError(t, removeStaleGeneratedFiles(organism, map[string]bool{fileName: true}))
+	_, err = os.Stat(shimFile)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,10 @@
+package tmplexec
+
+const exportShimTemplate = `{{ .Header }}
+` + generatedMarker + `
+package {{.PackageName}}
+
+{{range .Shims}}
+{{ . }}
+{{end}}
+`
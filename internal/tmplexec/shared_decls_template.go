@@ -0,0 +1,10 @@
+package tmplexec
+
+const sharedDeclsTemplate = `{{ .Header }}
+` + generatedMarker + `
+package {{.PackageName}}
+
+{{range .Decls}}
+{{ . }}
+{{end}}
+`
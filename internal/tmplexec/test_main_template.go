@@ -0,0 +1,8 @@
+package tmplexec
+
+const testMainTemplate = `{{ .Header }}
+` + generatedMarker + `
+package {{.PackageName}}
+
+{{ .TestMain }}
+`
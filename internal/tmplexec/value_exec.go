@@ -24,6 +24,9 @@ func (v *ValueExecutor) Execute(organism *gen.Organism) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if v.Opts.Concurrency > 1 {
+		return v.executeConcurrently(organism)
+	}
 	script := pipe.Script(
 		pipe.Exec("goimports", "-w", v.Opts.Dir),
 		pipe.Exec("go", "clean", "-testcache"),
@@ -39,3 +42,19 @@ func (v *ValueExecutor) Execute(organism *gen.Organism) (string, error) {
 
 	return string(out), nil
 }
+
+// executeConcurrently executes the test cases of every function in a separate go test process,
+// running at most Concurrency processes at the same time
+func (v *ValueExecutor) executeConcurrently(organism *gen.Organism) (string, error) {
+	script := pipe.Script(
+		pipe.Exec("goimports", "-w", v.Opts.Dir),
+		pipe.Exec("go", "clean", "-testcache"),
+	)
+	_, err := pipe.Output(pipe.Line(script))
+	if err != nil {
+		return "", err
+	}
+	return captureConcurrently(funcNames(organism), v.Opts.Concurrency, func(funcName string) (string, error) {
+		return v.captureFuncValues(organism, funcName)
+	})
+}
@@ -1,6 +1,7 @@
 package tmplexec
 
 const valueTemplate = `// Value template
+` + generatedMarker + `
 package {{.PackageName}}
 
 import (
@@ -19,10 +20,12 @@ type {{.SuiteName}}Suite struct {
 {{ range $funcName, $testCases := .TestCases }}
 {{/* range test cases */}}
 {{range $index, $testCase := $testCases}}
-{{/* Print declarations */}}
+{{/* Print declarations, unless declared in the shared declarations file */}}
+{{ if not $test.DeclsShared }}
 {{range  $testCase.Decls}}
 {{ . }}
 {{end}}
+{{ end }}
 {{/* Print functions */}}
 
 func (s *{{$test.SuiteName}}Suite) Test{{ $funcName }}{{  $index }}(){
@@ -30,6 +33,7 @@ defer func() {
 		if r := recover(); r != nil {
 		fmt.Println("<START;{{ $funcName }}{{ $index }}>")
 		fmt.Println("Recovered in Test{{ $funcName }}{{  $index }}", r)
+		fmt.Printf("Panic stack of Test{{ $funcName }}{{  $index }} %q\n", debug.Stack())
 		fmt.Println("<END;{{ $funcName }}{{ $index }}>")
 	}
 }()
@@ -39,11 +43,18 @@ wg.Add(1)
 {{ end }}
 {{range  $testCase.Stmts}}	{{ . }}
 {{end}}
+{{/* Parameters of pure functions are captured before the call */}}
+{{ if $testCase.PureStmts }}
+fmt.Println("<START;{{ $funcName }}{{  $index }}>")
+{{range  $testCase.PureStmts}}	 {{ . }}
+{{end}}
+{{ end }}
 {{ if $testCase.HasChan }}
 go func(){
 	defer func() {
 		if r := recover(); r != nil {
 		fmt.Println("Recovered in Test{{ $funcName }}{{  $index }}", r)
+		fmt.Printf("Panic stack of Test{{ $funcName }}{{  $index }} %q\n", debug.Stack())
 	}
 	defer wg.Done()
 	}()
@@ -53,9 +64,20 @@ go func(){
 {{ else }}
 {{ $testCase.FuncStmt }}
 {{ end }}
+{{ if not $testCase.PureStmts }}
 fmt.Println("<START;{{ $funcName }}{{  $index }}>")
+{{ end }}
 {{range  $testCase.ResultStmts}}	 {{ . }}
 {{end}}
+{{/* Ensure values are always used, also if they aren't printed */}}
+{{range  $testCase.ResultUsageStmts}}{{ . }}
+{{end}}
+{{/* Allocations are measured after the captured call, so the repeated calls don't affect the captured values */}}
+{{ if $testCase.HasAllocsCheck }}
+{{ $testCase.AllocsStmt }}
+{{range  $testCase.AllocsPrintStmts}}	 {{ . }}
+{{end}}
+{{ end }}
 fmt.Println("<END;{{ $funcName }}{{  $index }}>")
 {{ if $testCase.HasChan }}
 }()
@@ -10,3 +10,11 @@ func LowerCaseFirstLetter(str string) string {
 	}
 	return ""
 }
+
+// UpperCaseFirstLetter converts first character of a string to upper case
+func UpperCaseFirstLetter(str string) string {
+	for i, v := range str {
+		return string(unicode.ToUpper(v)) + str[i+1:]
+	}
+	return ""
+}
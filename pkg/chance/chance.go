@@ -10,17 +10,35 @@ const fullChance = 100
 
 // IsChance returns booleans for given chance
 func IsChance(chance float64) bool {
+	return isChance(rand.Float64, chance)
+}
+
+// IsChanceWithRand returns booleans for given chance using given source
+func IsChanceWithRand(r *rand.Rand, chance float64) bool {
+	return isChance(r.Float64, chance)
+}
+
+func isChance(float64Func func() float64, chance float64) bool {
 	if chance >= 0 && chance <= fullChance {
 		temp := chance / fullChance
-		return rand.Float64() < temp
+		return float64Func() < temp
 	}
 	return false
 }
 
 // GetIndex get index based on given length
 func GetIndex(length int) int {
+	return getIndex(rand.Intn, length)
+}
+
+// GetIndexWithRand get index based on given length using given source
+func GetIndexWithRand(r *rand.Rand, length int) int {
+	return getIndex(r.Intn, length)
+}
+
+func getIndex(intnFunc func(int) int, length int) int {
 	if length == 0 {
 		return 0
 	}
-	return rand.Intn(length)
+	return intnFunc(length)
 }
@@ -1,6 +1,7 @@
 package chance
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -24,6 +25,17 @@ func (s *ChanceTestSuite) TestLengthZero() {
 	}
 }
 
+func (s *ChanceTestSuite) TestWithRand() {
+	r1 := rand.New(rand.NewSource(1))
+	r2 := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s.Equal(GetIndexWithRand(r1, 4), GetIndexWithRand(r2, 4))
+		s.Equal(IsChanceWithRand(r1, 50), IsChanceWithRand(r2, 50))
+	}
+	s.Equal(0, GetIndexWithRand(r1, 0))
+	s.False(IsChanceWithRand(r1, 0))
+}
+
 func TestChanceTestSuite(t *testing.T) {
 	suite.Run(t, new(ChanceTestSuite))
 }
@@ -12,3 +12,9 @@ func SetRandomSeed(seed int64) {
 	rand.Seed(seed)
 	gofakeit.SetGlobalFaker(gofakeit.New(seed))
 }
+
+// NewOffset creates a new seed offset from the global source, which can be used
+// to seed generators independently of the global source
+func NewOffset() int64 {
+	return rand.Int63()
+}
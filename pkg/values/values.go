@@ -3,8 +3,10 @@
 package values
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"regexp"
 	"strconv"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -36,28 +38,60 @@ type IGen interface {
 	Float32() string
 	Float64() string
 
+	IntRange(lower, upper int) string
+	FloatRange(lower, upper float64) string
+
 	Complex64() string
 	Complex128() string
 
+	JSON() string
+	JSONNumber() string
+	TemplateText() string
+	RegexpPattern() string
+
 	Error() bool
 	DecoratorVal() bool
 	DecoratorIndex(length int) int
+	OptionFunc() bool
+	OmitEmpty() bool
+	DefaultTag() bool
+	CustomErrorIndex(amount int) int
+	ImplementerIndex(amount int) int
+	BranchHintIndex(bias float64, amount int) int
+	AliasIndex(bias float64, amount int) int
+	OverflowIndex(bias float64, amount int) int
+	CorpusIndex(amount int) int
+	TypedNil(bias float64) bool
+	ClosedSignalChan() bool
 
 	ArrayLen(maxLen int) int
+	SliceLen(boundaryBias float64) int
 	MapLen() int
 }
 
 // Gen IGen implementation
-type Gen struct{}
+type Gen struct {
+	// faker is only set for seeded generators, otherwise the global sources are used
+	faker *gofakeit.Faker
+}
 
 // NewGenerator creates a new generator
 func NewGenerator() IGen {
 	return &Gen{}
 }
 
+// NewSeededGenerator creates a new generator using its own source seeded with given seed,
+// the generated values are therefore independent of other generators
+func NewSeededGenerator(seed int64) IGen {
+	return &Gen{
+		// gofakeit.New would use a random seed for 0
+		faker: gofakeit.NewCustom(rand.NewSource(seed).(rand.Source64)),
+	}
+}
+
 // Int Generates an int value
 func (g *Gen) Int() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Type retrieves random basic lit type
@@ -84,79 +118,89 @@ func (g *Gen) Type() string {
 		"complex128",
 	}
 
-	return types[gofakeit.Number(0, len(types)-1)]
+	return types[g.number(0, len(types)-1)]
 }
 
 // Int8 Generates an int8 value
 func (g *Gen) Int8() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Int16 Generates an int16 value
 func (g *Gen) Int16() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Int32 Generates an int32 value
 func (g *Gen) Int32() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Int64 Generates an int64 value
 func (g *Gen) Int64() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // UInt Generates an uint value
 func (g *Gen) UInt() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // UInt8 Generates an uint8 value
 func (g *Gen) UInt8() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // UInt16 Generates an uint16 value
 func (g *Gen) UInt16() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // UInt32 Generates an uint32 value
 func (g *Gen) UInt32() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // UInt64 Generates an uint64 value
 func (g *Gen) UInt64() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // UIntPtr Generates an uintptr value
 func (g *Gen) UIntPtr() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Bool Generates an bool value
 func (g *Gen) Bool() string {
-	val := gofakeit.Bool()
+	val := g.bool()
 	return strconv.FormatBool(val)
 }
 
 // String Generates an bool value
 func (g *Gen) String() string {
-	val := gofakeit.Name()
+	val := g.name()
 	return fmt.Sprintf(`"%s"`, val)
 }
 
 // Float64 Generates an float64 value
 func (g *Gen) Float64() string {
-	return FloatVal()
+	return g.floatVal()
+}
+
+// IntRange Generates an integer value between lower and upper, both inclusive
+func (g *Gen) IntRange(lower, upper int) string {
+	return fmt.Sprintf("%d", g.number(lower, upper))
+}
+
+// FloatRange Generates a float value between lower and upper
+func (g *Gen) FloatRange(lower, upper float64) string {
+	return strconv.FormatFloat(g.float64Range(lower, upper), 'g', -1, 64)
 }
 
 // Float32 Generates an float32 value
 func (g *Gen) Float32() string {
-	return FloatVal()
+	return g.floatVal()
 }
 
 // Complex64 Generates an complex64 value
@@ -166,36 +210,180 @@ func (g *Gen) Complex64() string {
 
 // Complex128 Generates an complex128 value
 func (g *Gen) Complex128() string {
-	return IntVal()
+	return g.intVal()
 }
 
 // Byte Generates an byte value
 func (g *Gen) Byte() string {
-	return UIntVal()
+	return g.uintVal()
 }
 
 // Rune Generates an rune value
 func (g *Gen) Rune() string {
-	return IntVal()
+	return g.intVal()
+}
+
+const (
+	maxJSONDepth = 2
+	maxJSONLen   = 4
+)
+
+// JSON Generates a valid JSON document, being an object, array or scalar
+func (g *Gen) JSON() string {
+	data, err := json.Marshal(g.jsonVal(maxJSONDepth))
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
+
+// JSONNumber Generates a numeric string valid as json.Number, either in integer or in float form
+func (g *Gen) JSONNumber() string {
+	if g.bool() {
+		return g.intVal()
+	}
+	return g.floatVal()
+}
+
+// templateActions actions valid for any data passed to a template
+var templateActions = []string{
+	"{{.}}",
+	`{{printf "%v" .}}`,
+	"{{if .}}{{.}}{{else}}empty{{end}}",
+	"{{with .}}{{.}}{{end}}",
+	"{{/* comment */}}",
+}
+
+// TemplateText Generates the text of a valid template, mixing literal text with actions
+func (g *Gen) TemplateText() string {
+	parts := g.intn(3) + 1
+	res := ""
+	for i := 0; i < parts; i++ {
+		if g.bool() {
+			res += g.name() + " "
+		}
+		res += templateActions[g.intn(len(templateActions))]
+	}
+	return res
+}
+
+// regexpAtoms pattern fragments which are valid on their own and in any concatenation
+var regexpAtoms = []string{
+	"[a-z]+",
+	"[A-Z0-9]*",
+	`\d{1,3}`,
+	`\w+`,
+	`\s?`,
+	".*",
+	"(foo|bar)",
+	"x?",
+}
+
+// RegexpPattern Generates a valid regular expression, mixing quoted literal text with safe atoms
+func (g *Gen) RegexpPattern() string {
+	parts := g.intn(3) + 1
+	res := ""
+	if g.bool() {
+		res += "^"
+	}
+	for i := 0; i < parts; i++ {
+		if g.bool() {
+			res += regexp.QuoteMeta(g.name())
+		}
+		res += regexpAtoms[g.intn(len(regexpAtoms))]
+	}
+	if g.bool() {
+		res += "$"
+	}
+	return res
+}
+
+func (g *Gen) jsonVal(depth int) interface{} {
+	kinds := 4
+	if depth > 0 {
+		kinds += 2
+	}
+	switch g.intn(kinds) {
+	case 0:
+		return g.name()
+	case 1:
+		return g.number(-100, 100)
+	case 2:
+		return g.bool()
+	case 3:
+		return nil
+	case 4:
+		res := []interface{}{}
+		for i := g.intn(maxJSONLen); i > 0; i-- {
+			res = append(res, g.jsonVal(depth-1))
+		}
+		return res
+	default:
+		res := make(map[string]interface{})
+		for i := g.intn(maxJSONLen); i > 0; i-- {
+			res[g.name()] = g.jsonVal(depth - 1)
+		}
+		return res
+	}
 }
 
 // Error Indicates if an error should be returned or nil
 func (g *Gen) Error() bool {
-	val := gofakeit.Bool()
+	val := g.bool()
 	return val
 }
 
 // DecoratorVal Indicates if a value should be used in the decorator spec
 func (g *Gen) DecoratorVal() bool {
 	const decoratorChance = 80
+	if g.faker != nil {
+		return chance.IsChanceWithRand(g.faker.Rand, decoratorChance)
+	}
 	return chance.IsChance(decoratorChance)
 }
 
 // DecoratorIndex returns random index for array length of decorators
 func (g *Gen) DecoratorIndex(length int) int {
+	if g.faker != nil {
+		return chance.GetIndexWithRand(g.faker.Rand, length)
+	}
 	return chance.GetIndex(length)
 }
 
+// OptionFunc Indicates if an option function should be passed to a functional options constructor
+func (g *Gen) OptionFunc() bool {
+	return g.bool()
+}
+
+// OmitEmpty Indicates if a field tagged with omitempty should be left at its zero value
+func (g *Gen) OmitEmpty() bool {
+	return g.bool()
+}
+
+// DefaultTag Indicates if a field tagged with default should be initialized to its default value
+func (g *Gen) DefaultTag() bool {
+	return g.bool()
+}
+
+// CustomErrorIndex Picks the error type of the package under test wrapped by an error,
+// -1 indicates a generic error is wrapped instead
+func (g *Gen) CustomErrorIndex(amount int) int {
+	if amount == 0 || !g.bool() {
+		return -1
+	}
+	return g.intn(amount)
+}
+
+// ImplementerIndex picks one of the given amount of implementers of an interface as element of a slice,
+// -1 indicates the generated implementation is used instead
+func (g *Gen) ImplementerIndex(amount int) int {
+	index := g.intn(amount + 1)
+	if index == amount {
+		return -1
+	}
+	return index
+}
+
 const (
 	maxArrayLen     = 10
 	changeVal       = 100
@@ -207,7 +395,7 @@ func (g *Gen) ArrayLen(maxLen int) int {
 	if maxLen == 0 {
 		return 0
 	}
-	change := rand.Intn(changeVal)
+	change := g.intn(changeVal)
 	if change < 1 {
 		return 0
 	}
@@ -215,35 +403,139 @@ func (g *Gen) ArrayLen(maxLen int) int {
 		if change > notMaxLenChance {
 			return maxLen
 		}
-		return rand.Intn(maxLen)
+		return g.intn(maxLen)
 	}
-	return rand.Intn(maxArrayLen)
+	return g.intn(maxArrayLen)
+}
+
+// SliceLen creates the length of a slice, with a probability of boundaryBias
+// one of the boundary lengths 0, 1 or the max length is used
+func (g *Gen) SliceLen(boundaryBias float64) int {
+	if boundaryBias > 0 && g.float64Range(0, 1) < boundaryBias {
+		boundaries := []int{0, 1, maxArrayLen}
+		return boundaries[g.intn(len(boundaries))]
+	}
+	return g.ArrayLen(-1)
+}
+
+// AliasIndex returns the index of one of the given amount of parameters to alias with a probability of bias,
+// -1 is returned if no parameter should be aliased
+func (g *Gen) AliasIndex(bias float64, amount int) int {
+	if amount == 0 || bias <= 0 || g.float64Range(0, 1) >= bias {
+		return -1
+	}
+	return g.intn(amount)
+}
+
+// CorpusIndex returns the index of one of the given amount of corpus values to use,
+// -1 is returned if a random value should be generated instead
+func (g *Gen) CorpusIndex(amount int) int {
+	const corpusChance = 50
+	if amount == 0 || g.intn(100) >= corpusChance {
+		return -1
+	}
+	return g.intn(amount)
+}
+
+// ClosedSignalChan Indicates if a signal channel should be closed instead of receiving a buffered signal
+func (g *Gen) ClosedSignalChan() bool {
+	return g.bool()
+}
+
+// TypedNil indicates with a probability of bias if an interface should be a typed nil pointer
+func (g *Gen) TypedNil(bias float64) bool {
+	return bias > 0 && g.float64Range(0, 1) < bias
+}
+
+// OverflowIndex returns the index of one of the given amount of integer bounds with a probability of bias,
+// -1 is returned if no bound should be used
+func (g *Gen) OverflowIndex(bias float64, amount int) int {
+	if amount == 0 || bias <= 0 || g.float64Range(0, 1) >= bias {
+		return -1
+	}
+	return g.intn(amount)
+}
+
+// BranchHintIndex returns the index of one of the given amount of branch hints with a probability of bias,
+// -1 is returned if no hint should be used
+func (g *Gen) BranchHintIndex(bias float64, amount int) int {
+	if amount == 0 || bias <= 0 || g.float64Range(0, 1) >= bias {
+		return -1
+	}
+	return g.intn(amount)
 }
 
 // MapLen creates the length of a map
 func (g *Gen) MapLen() int {
-	return rand.Intn(maxArrayLen)
+	return g.intn(maxArrayLen)
 }
 
 // Helpers
 
 // IntVal create random int value and converts it to string
 func IntVal() string {
-	const lower, upper int = -100, 100
-	val := gofakeit.Number(lower, upper)
-	return strconv.Itoa(val)
+	return (&Gen{}).intVal()
 }
 
 // UIntVal create random uint value and converts it to string
 func UIntVal() string {
-	const lower, upper int = 0, 100
-	val := gofakeit.Number(lower, upper)
-	return strconv.Itoa(val)
+	return (&Gen{}).uintVal()
 }
 
 // FloatVal create random float value and converts it to string
 func FloatVal() string {
+	return (&Gen{}).floatVal()
+}
+
+func (g *Gen) intVal() string {
+	const lower, upper int = -100, 100
+	val := g.number(lower, upper)
+	return strconv.Itoa(val)
+}
+
+func (g *Gen) uintVal() string {
+	const lower, upper int = 0, 100
+	val := g.number(lower, upper)
+	return strconv.Itoa(val)
+}
+
+func (g *Gen) floatVal() string {
 	const lower, upper float64 = -100, 100
-	val := gofakeit.Float64Range(lower, upper)
+	val := g.float64Range(lower, upper)
 	return fmt.Sprintf("%f", val)
 }
+
+func (g *Gen) number(lower, upper int) int {
+	if g.faker != nil {
+		return g.faker.Number(lower, upper)
+	}
+	return gofakeit.Number(lower, upper)
+}
+
+func (g *Gen) float64Range(lower, upper float64) float64 {
+	if g.faker != nil {
+		return g.faker.Float64Range(lower, upper)
+	}
+	return gofakeit.Float64Range(lower, upper)
+}
+
+func (g *Gen) bool() bool {
+	if g.faker != nil {
+		return g.faker.Bool()
+	}
+	return gofakeit.Bool()
+}
+
+func (g *Gen) name() string {
+	if g.faker != nil {
+		return g.faker.Name()
+	}
+	return gofakeit.Name()
+}
+
+func (g *Gen) intn(n int) int {
+	if g.faker != nil {
+		return g.faker.Rand.Intn(n)
+	}
+	return rand.Intn(n)
+}
@@ -0,0 +1,179 @@
+package values
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValuesTestSuite struct {
+	suite.Suite
+}
+
+func (s *ValuesTestSuite) TestSliceLenBoundaryBias() {
+	const draws = 10000
+	tests := []struct {
+		Name         string
+		BoundaryBias float64
+		// Expected rate of each length, random lengths are uniformly distributed over [0, maxArrayLen)
+		Rates map[int]float64
+	}{
+		{
+			Name:         "no bias",
+			BoundaryBias: 0,
+			Rates:        map[int]float64{0: 0.1, 1: 0.1, maxArrayLen: 0},
+		},
+		{
+			Name:         "bias",
+			BoundaryBias: 0.3,
+			Rates:        map[int]float64{0: 0.1 + 0.7*0.1, 1: 0.1 + 0.7*0.1, maxArrayLen: 0.1},
+		},
+	}
+	for _, test := range tests {
+		s.Run(test.Name, func() {
+			gen := NewSeededGenerator(1)
+			counts := make(map[int]int)
+			for i := 0; i < draws; i++ {
+				counts[gen.SliceLen(test.BoundaryBias)]++
+			}
+			for length, rate := range test.Rates {
+				s.InDelta(rate, float64(counts[length])/draws, 0.02, "length %d", length)
+			}
+		})
+	}
+}
+
+func (s *ValuesTestSuite) TestBranchHintIndex() {
+	gen := NewSeededGenerator(1)
+	s.Equal(-1, gen.BranchHintIndex(1, 0))
+	s.Equal(-1, gen.BranchHintIndex(0, 3))
+	for i := 0; i < 100; i++ {
+		index := gen.BranchHintIndex(1, 3)
+		s.True(index >= 0 && index < 3)
+	}
+}
+
+func (s *ValuesTestSuite) TestOverflowIndex() {
+	gen := NewSeededGenerator(1)
+	s.Equal(-1, gen.OverflowIndex(1, 0))
+	s.Equal(-1, gen.OverflowIndex(0, 2))
+	for i := 0; i < 100; i++ {
+		index := gen.OverflowIndex(1, 2)
+		s.True(index >= 0 && index < 2)
+	}
+}
+
+func (s *ValuesTestSuite) TestAliasIndex() {
+	gen := NewSeededGenerator(1)
+	s.Equal(-1, gen.AliasIndex(1, 0))
+	s.Equal(-1, gen.AliasIndex(0, 2))
+	for i := 0; i < 100; i++ {
+		index := gen.AliasIndex(1, 2)
+		s.True(index >= 0 && index < 2)
+	}
+}
+
+func (s *ValuesTestSuite) TestCorpusIndex() {
+	gen := NewSeededGenerator(1)
+	s.Equal(-1, gen.CorpusIndex(0))
+	used := 0
+	for i := 0; i < 100; i++ {
+		index := gen.CorpusIndex(3)
+		s.True(index >= -1 && index < 3)
+		if index != -1 {
+			used++
+		}
+	}
+	s.Greater(used, 0)
+	s.Less(used, 100)
+}
+
+func (s *ValuesTestSuite) TestImplementerIndex() {
+	gen := NewSeededGenerator(1)
+	s.Equal(-1, gen.ImplementerIndex(0))
+	seen := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		index := gen.ImplementerIndex(2)
+		s.True(index >= -1 && index < 2)
+		seen[index] = true
+	}
+	s.Equal(map[int]bool{-1: true, 0: true, 1: true}, seen)
+}
+
+func (s *ValuesTestSuite) TestTypedNil() {
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 100; i++ {
+		s.False(gen.TypedNil(0))
+		s.True(gen.TypedNil(1))
+	}
+}
+
+func (s *ValuesTestSuite) TestRange() {
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 100; i++ {
+		intVal, err := strconv.Atoi(gen.IntRange(0, 100))
+		s.Require().NoError(err)
+		s.True(intVal >= 0 && intVal <= 100)
+		floatVal, err := strconv.ParseFloat(gen.FloatRange(-1.5, 1.5), 64)
+		s.Require().NoError(err)
+		s.True(floatVal >= -1.5 && floatVal <= 1.5)
+	}
+	s.Equal("7", gen.IntRange(7, 7))
+}
+
+func (s *ValuesTestSuite) TestJSON() {
+	gen := NewSeededGenerator(1)
+	kinds := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		doc := gen.JSON()
+		s.True(json.Valid([]byte(doc)), doc)
+		kinds[doc[:1]] = true
+	}
+	// Objects, arrays and scalars are generated
+	s.True(kinds["{"])
+	s.True(kinds["["])
+	s.True(kinds["\""])
+}
+
+func (s *ValuesTestSuite) TestJSONNumber() {
+	gen := NewSeededGenerator(1)
+	forms := make(map[bool]bool)
+	for i := 0; i < 100; i++ {
+		number := json.Number(gen.JSONNumber())
+		_, err := number.Float64()
+		s.NoError(err, number)
+		_, err = number.Int64()
+		forms[err == nil] = true
+	}
+	// Both integer and float forms are generated
+	s.True(forms[true])
+	s.True(forms[false])
+}
+
+func (s *ValuesTestSuite) TestTemplateText() {
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 100; i++ {
+		text := gen.TemplateText()
+		tmpl, err := template.New("test").Parse(text)
+		s.Require().NoError(err, text)
+		s.NoError(tmpl.Execute(ioutil.Discard, 42), text)
+	}
+}
+
+func (s *ValuesTestSuite) TestRegexpPattern() {
+	gen := NewSeededGenerator(1)
+	for i := 0; i < 100; i++ {
+		pattern := gen.RegexpPattern()
+		_, err := regexp.Compile(pattern)
+		s.NoError(err, pattern)
+	}
+}
+
+func TestValuesTestSuite(t *testing.T) {
+	suite.Run(t, new(ValuesTestSuite))
+}
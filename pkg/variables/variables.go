@@ -20,22 +20,36 @@ const (
 )
 
 // Generator default generator implementation
-type Generator struct{}
+type Generator struct {
+	// rand is only set for seeded generators, otherwise the global source is used
+	rand *rand.Rand
+}
 
 // NewGenerator creates a new generator
 func NewGenerator() IGen {
 	return &Generator{}
 }
 
+// NewSeededGenerator creates a new generator using its own source seeded with given seed
+func NewSeededGenerator(seed int64) IGen {
+	return &Generator{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+}
+
 // Generate generates a random variable name of length 10
 func (g *Generator) Generate() string {
-	return randSeq(varNameLength)
+	intn := rand.Intn
+	if g.rand != nil {
+		intn = g.rand.Intn
+	}
+	return randSeq(intn, varNameLength)
 }
 
-func randSeq(n int) string {
+func randSeq(intn func(int) int, n int) string {
 	b := make([]rune, n)
 	for i := range b {
-		b[i] = letters[rand.Intn(len(letters))]
+		b[i] = letters[intn(len(letters))]
 	}
 	return string(b)
 }
@@ -0,0 +1,15 @@
+package alias
+
+// Buf buffer of bytes
+type Buf struct {
+	Data []byte
+}
+
+// Merge appends the data of src to dst
+func Merge(dst, src *Buf) int {
+	if dst == src {
+		return -1
+	}
+	dst.Data = append(dst.Data, src.Data...)
+	return len(dst.Data)
+}
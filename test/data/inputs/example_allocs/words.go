@@ -0,0 +1,19 @@
+package allocs
+
+import "strings"
+
+// Join joins the words, allocating the joined string
+func Join(words []string) string {
+	return strings.Join(words, " ")
+}
+
+// Count counts the words without allocating
+// final-unit:allocs 0
+func Count(words []string) int {
+	return len(words)
+}
+
+// Notify sends the word on the channel
+func Notify(word string, c chan string) {
+	c <- word
+}
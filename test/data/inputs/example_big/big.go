@@ -0,0 +1,11 @@
+package big
+
+import "math/big"
+
+func Double(x *big.Int) *big.Int {
+	return new(big.Int).Mul(x, big.NewInt(2))
+}
+
+func Half(x big.Float) *big.Float {
+	return new(big.Float).Quo(&x, big.NewFloat(2))
+}
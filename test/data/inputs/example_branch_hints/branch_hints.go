@@ -0,0 +1,23 @@
+package branchhints
+
+// Access grants access to admins only
+func Access(role string) bool {
+	if role == "admin" {
+		return true
+	}
+	return false
+}
+
+// Bucket categorises a size
+func Bucket(size int8, ratio float64) string {
+	if size > 126 {
+		return "max"
+	}
+	if -2 >= size {
+		return "negative"
+	}
+	if ratio < 0.5 {
+		return "low"
+	}
+	return "high"
+}
@@ -0,0 +1,5 @@
+package buildtags
+
+func Configure(p Poller) int {
+	return p.Events
+}
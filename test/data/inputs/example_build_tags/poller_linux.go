@@ -0,0 +1,6 @@
+package buildtags
+
+type Poller struct {
+	Events int
+	Epoll  bool
+}
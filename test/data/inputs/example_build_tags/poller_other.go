@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package buildtags
+
+type Poller struct {
+	Events int
+	Kqueue string
+}
@@ -0,0 +1,54 @@
+package chanfactory
+
+// Event an event of a stream
+type Event struct {
+	Name string
+}
+
+// Ticks a stream of ticks
+type Ticks chan int
+
+// NewEvents creates a closed stream containing up to 10 events
+func NewEvents(amount int) <-chan Event {
+	if amount < 0 {
+		amount = -amount
+	}
+	amount %= 10
+	res := make(chan Event, amount)
+	for i := 0; i < amount; i++ {
+		res <- Event{Name: "event"}
+	}
+	close(res)
+	return res
+}
+
+// NewTicks creates a stream containing a single tick
+func NewTicks() Ticks {
+	res := make(Ticks, 1)
+	res <- 1
+	return res
+}
+
+// Drain counts the events of a stream
+func Drain(events <-chan Event) int {
+	res := 0
+	for range events {
+		res++
+	}
+	return res
+}
+
+// Next receives the next tick, if any
+func Next(ticks Ticks) int {
+	select {
+	case t := <-ticks:
+		return t
+	default:
+		return -1
+	}
+}
+
+// Forward forwards a name
+func Forward(names chan string, name string) {
+	names <- name
+}
@@ -0,0 +1,38 @@
+package example
+
+import "sort"
+
+// Item item of an order
+type Item struct {
+	Name     string
+	Quantity int
+}
+
+// Order order of items
+type Order struct {
+	ID    int
+	Items []Item
+	Tags  []string
+}
+
+// Node node of a linked list, its pointers would be printed as addresses
+type Node struct {
+	Value int
+	Next  *Node
+}
+
+// Merge merges the items of given orders into a single order
+func Merge(a, b Order) Order {
+	res := Order{ID: a.ID}
+	res.Items = append(append(res.Items, a.Items...), b.Items...)
+	for _, tag := range append(a.Tags, b.Tags...) {
+		res.Tags = append(res.Tags, tag)
+	}
+	sort.Strings(res.Tags)
+	return res
+}
+
+// Push pushes a value on a list
+func Push(n *Node, value int) *Node {
+	return &Node{Value: value, Next: n}
+}
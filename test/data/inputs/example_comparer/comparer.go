@@ -0,0 +1,52 @@
+package comparer
+
+import "github.com/wimspaargaren/final-unit/test/data/inputs/example_comparer/geo"
+
+// Point point which counts how often it is moved
+type Point struct {
+	X     int
+	Y     int
+	moves int
+}
+
+// Equal compares the coordinates of two points
+func (p Point) Equal(other Point) bool {
+	return p.X == other.X && p.Y == other.Y
+}
+
+// Move moves a point horizontally
+func Move(p Point, dx int) Point {
+	p.X += dx
+	p.moves++
+	return p
+}
+
+// Celsius temperature in degrees celsius
+type Celsius float64
+
+// ToCelsius converts fahrenheit to celsius
+func ToCelsius(f float64) Celsius {
+	return Celsius((f - 32) * 5 / 9)
+}
+
+// Route route of which the destination is optional
+type Route struct {
+	Stops int
+	Dest  *string
+}
+
+// Equal compares the stops of two routes
+func (r Route) Equal(other Route) bool {
+	return r.Stops == other.Stops
+}
+
+// Extend adds a stop to a route
+func Extend(r Route) Route {
+	r.Stops++
+	return r
+}
+
+// Scale scales a vector of another package
+func Scale(v geo.Vec, factor int) geo.Vec {
+	return geo.NewVec(v.X*factor, factor)
+}
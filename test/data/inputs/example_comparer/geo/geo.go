@@ -0,0 +1,17 @@
+package geo
+
+// Vec vector which remembers its scale
+type Vec struct {
+	X     int
+	scale int
+}
+
+// NewVec creates a vector with given scale
+func NewVec(x, scale int) Vec {
+	return Vec{X: x, scale: scale}
+}
+
+// Equal compares the components of two vectors
+func (v Vec) Equal(other Vec) bool {
+	return v.X == other.X
+}
@@ -0,0 +1,8 @@
+package corpus
+
+import "fmt"
+
+// Register creates a description of a new user
+func Register(name, email string, age int) string {
+	return fmt.Sprintf("%s <%s> (%d)", name, email, age)
+}
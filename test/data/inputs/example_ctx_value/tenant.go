@@ -0,0 +1,29 @@
+package ctxvalue
+
+import "context"
+
+type ctxKey string
+
+// TenantKey context key of the tenant
+const TenantKey ctxKey = "tenant"
+
+// Tenant retrieves the tenant from the context
+// final-unit:ctx-value TenantKey "acme"
+// final-unit:ctx-value ctxKey("region") "eu"
+func Tenant(ctx context.Context) string {
+	tenant, ok := ctx.Value(TenantKey).(string)
+	if !ok {
+		return "unknown"
+	}
+	region, ok := ctx.Value(ctxKey("region")).(string)
+	if !ok {
+		return tenant
+	}
+	return tenant + "@" + region
+}
+
+// Deadline reports if the context has a deadline
+func Deadline(ctx context.Context) bool {
+	_, ok := ctx.Deadline()
+	return ok
+}
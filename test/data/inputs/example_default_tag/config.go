@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+// Config server configuration
+type Config struct {
+	Host    string        `default:"localhost"`
+	Port    int           `default:"8080"`
+	Debug   bool          `default:"true"`
+	Ratio   float32       `default:"0.5"`
+	Timeout time.Duration `default:"5s"`
+	Name    string
+}
+
+// Address creates the address of the server
+func Address(c Config) string {
+	if c.Host == "localhost" && c.Port == 8080 {
+		return "default"
+	}
+	return c.Host
+}
@@ -0,0 +1,26 @@
+package erroras
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError error indicating an invalid field
+type ValidationError struct {
+	Field string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + " is invalid"
+}
+
+// Validate validates a name
+func Validate(name string) error {
+	if name == "" {
+		return fmt.Errorf("validate: %w", &ValidationError{Field: "name"})
+	}
+	if len(name) > 10 {
+		return errors.New("name too long")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package expecterror
+
+import "fmt"
+
+// Divide divides a by b
+// final-unit:expect-error b 0
+func Divide(a, b int) (int, error) {
+	if b == 0 {
+		return 0, fmt.Errorf("division by zero")
+	}
+	return a / b, nil
+}
@@ -0,0 +1,18 @@
+package exportshim
+
+// Double doubles the sum of a and b
+func Double(a, b int) int {
+	return 2 * sum(a, b)
+}
+
+func sum(a, b int) int {
+	return a + b
+}
+
+type counter struct {
+	count int
+}
+
+func (c *counter) inc() {
+	c.count++
+}
@@ -0,0 +1,12 @@
+//go:build go1.18
+// +build go1.18
+
+package exportshim
+
+func first[T any](values []T) T {
+	var res T
+	if len(values) > 0 {
+		res = values[0]
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+package fat
+
+// Report aggregated data
+type Report struct {
+	Title  string
+	Rows   []Row
+	Totals map[string]float64
+}
+
+// Row single report row
+type Row struct {
+	Key    string
+	Values []int
+}
+
+// Store interface with many methods returning expensive values
+type Store interface {
+	Name() string
+	Count() int
+	Report(id int) Report
+	Reports() []Report
+	Rows(key string) ([]Row, error)
+	Index() map[string]Row
+}
+
+// Describe only calls a single method of the store
+func Describe(s Store) string {
+	report := s.Report(1)
+	if len(report.Rows) > 10 {
+		return "large " + report.Title
+	}
+	return "small " + report.Title
+}
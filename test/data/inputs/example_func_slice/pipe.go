@@ -0,0 +1,9 @@
+package function
+
+func Pipe(fns []func(int) int) int {
+	x := 0
+	for _, fn := range fns {
+		x = fn(x)
+	}
+	return x
+}
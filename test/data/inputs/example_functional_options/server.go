@@ -0,0 +1,56 @@
+package functionaloptions
+
+import "fmt"
+
+// Server server which is configured using functional options
+type Server struct {
+	host string
+	port int
+	tls  bool
+}
+
+// Option configures a server
+type Option func(*Server)
+
+// WithHost sets the host of the server
+func WithHost(host string) Option {
+	return func(s *Server) {
+		s.host = host
+	}
+}
+
+// WithPort sets the port of the server
+func WithPort(port int) Option {
+	return func(s *Server) {
+		s.port = port
+	}
+}
+
+// WithTLS enables tls
+func WithTLS() Option {
+	return func(s *Server) {
+		s.tls = true
+	}
+}
+
+// New creates a new server
+func New(opts ...Option) *Server {
+	s := &Server{
+		host: "localhost",
+		port: 80,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Address returns the address of the server
+func Address(s *Server) string {
+	return fmt.Sprintf("%s:%d", s.host, s.port)
+}
+
+// IsSecure reports if the server uses tls
+func IsSecure(s Server) bool {
+	return s.tls
+}
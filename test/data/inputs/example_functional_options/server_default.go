@@ -0,0 +1,7 @@
+package functionaloptions
+
+// NewDefault creates a new server listening on the default https port, it's declared after New in file order
+// so New is used to create servers
+func NewDefault(opts ...Option) *Server {
+	return New(append([]Option{WithPort(443), WithTLS()}, opts...)...)
+}
@@ -0,0 +1,31 @@
+//go:build go1.18
+// +build go1.18
+
+package genericalias
+
+// Stack stack of items
+type Stack[T any] struct {
+	Items []T
+}
+
+// Pair pair of a key and value
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// IntStack stack of integers
+type IntStack = Stack[int]
+
+// Entry entry of a string keyed map
+type Entry = Pair[string, bool]
+
+// Size returns the size of the stack
+func Size(s IntStack) int {
+	return len(s.Items)
+}
+
+// Enabled checks if the entry is enabled
+func Enabled(e *Entry) bool {
+	return e != nil && e.Value
+}
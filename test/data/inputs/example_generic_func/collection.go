@@ -0,0 +1,43 @@
+//go:build go1.18
+// +build go1.18
+
+package genericfunc
+
+import "fmt"
+
+// Number numeric types which can be summed
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Sum sums the values
+func Sum[T Number](values []T) T {
+	var res T
+	for _, v := range values {
+		res += v
+	}
+	return res
+}
+
+// Lookup retrieves the value of a key, or the fallback if the key isn't present
+func Lookup[K comparable, V any](m map[K]V, key K, fallback V) V {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return fallback
+}
+
+// Describe describes a value
+func Describe[T fmt.Stringer](v T) string {
+	return v.String()
+}
+
+// Clone copies the elements of a slice
+func Clone[S ~[]E, E any](s S) S {
+	return append(S(nil), s...)
+}
+
+// Size retrieves the amount of entries of a map
+func Size[M ~map[K]V, K comparable, V any](m M) int {
+	return len(m)
+}
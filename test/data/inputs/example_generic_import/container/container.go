@@ -0,0 +1,19 @@
+//go:build go1.18
+// +build go1.18
+
+package container
+
+// Stack stack of items
+type Stack[T any] struct {
+	Items []T
+	Next  *Stack[T]
+}
+
+// Pair pair of a key and value
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Set set of items
+type Set[T comparable] map[T]struct{}
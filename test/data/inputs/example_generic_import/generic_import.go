@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package genericimport
+
+import (
+	"github.com/wimspaargaren/final-unit/test/data/inputs/example_generic_import/container"
+)
+
+// Depth returns the amount of stacked stacks
+func Depth(s container.Stack[string]) int {
+	depth := 0
+	for next := s.Next; next != nil; next = next.Next {
+		depth++
+	}
+	return depth
+}
+
+// Key returns the key of a pair
+func Key(p container.Pair[string, int]) string {
+	return p.Key
+}
+
+// Size returns the size of a set
+func Size(s container.Set[int]) int {
+	return len(s)
+}
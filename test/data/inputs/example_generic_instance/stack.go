@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package genericinstance
+
+// Stack stack of items
+type Stack[T any] struct {
+	Items []T
+	Top   *T
+	Next  *Stack[T]
+}
+
+// Pair pair of a key and value
+type Pair[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Peek returns the top of the stack
+func Peek(s Stack[string]) string {
+	if s.Top == nil {
+		return ""
+	}
+	return *s.Top
+}
+
+// Swap swaps the key and value of a pair
+func Swap(p Pair[string, int]) Pair[int, string] {
+	return Pair[int, string]{Key: p.Value, Value: p.Key}
+}
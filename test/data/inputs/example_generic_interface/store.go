@@ -0,0 +1,33 @@
+//go:build go1.18
+// +build go1.18
+
+package genericinterface
+
+// Store generic key value store
+type Store[K comparable, V any] interface {
+	Get(key K) (V, bool)
+	Keys() []K
+}
+
+// Source produces values of a type
+type Source[T any] interface {
+	Next() T
+}
+
+// Lookup retrieves the value of given key or a fallback
+func Lookup(s Store[string, int], key string) int {
+	v, ok := s.Get(key)
+	if !ok {
+		return -1
+	}
+	return v
+}
+
+// Drain takes given amount of values from a source
+func Drain(s Source[float64], n int) []float64 {
+	res := []float64{}
+	for i := 0; i < n; i++ {
+		res = append(res, s.Next())
+	}
+	return res
+}
@@ -0,0 +1,6 @@
+package globalnames
+
+// Triple triples the read value
+func Triple(r Reader) int {
+	return r.Read() * 3
+}
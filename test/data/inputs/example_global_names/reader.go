@@ -0,0 +1,11 @@
+package globalnames
+
+// Reader reads a value
+type Reader interface {
+	Read() int
+}
+
+// Double doubles the read value
+func Double(r Reader) int {
+	return r.Read() * 2
+}
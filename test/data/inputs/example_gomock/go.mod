@@ -0,0 +1,5 @@
+// The mock requires gomock, which isn't a dependency of the module, so the example is excluded from the module
+
+module github.com/wimspaargaren/final-unit/test/data/inputs/example_gomock
+
+go 1.15
@@ -0,0 +1,35 @@
+package store
+
+import "io"
+
+// Store key value store
+type Store interface {
+	Get(key string) (string, error)
+	Delete(key string)
+}
+
+// Lookup retrieves the value of key from the store, falling back to a default
+func Lookup(st Store, key string) string {
+	val, err := st.Get(key)
+	if err != nil {
+		st.Delete(key)
+		return "default"
+	}
+	return val
+}
+
+// Cache store which is flushed and closed
+type Cache interface {
+	Store
+	io.Closer
+	Flush() error
+}
+
+// Evict removes key from the cache and flushes it
+func Evict(c Cache, key string) error {
+	c.Delete(key)
+	if err := c.Flush(); err != nil {
+		return err
+	}
+	return c.Close()
+}
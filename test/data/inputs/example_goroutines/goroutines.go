@@ -0,0 +1,34 @@
+package goroutines
+
+import "sync"
+
+// Leak spawns a goroutine which never returns
+func Leak(x int) {
+	block := make(chan int)
+	go func() {
+		block <- x
+	}()
+}
+
+// Sum spawns goroutines and waits for them to finish
+func Sum(x, y int) int {
+	res := 0
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, v := range []int{x, y} {
+		wg.Add(1)
+		go func(v int) {
+			defer wg.Done()
+			mu.Lock()
+			res += v
+			mu.Unlock()
+		}(v)
+	}
+	wg.Wait()
+	return res
+}
+
+// Double does not spawn any goroutines
+func Double(x int) int {
+	return x * 2
+}
@@ -0,0 +1,23 @@
+package helpers
+
+// Config configuration of a run
+type Config struct {
+	Verbose bool
+	Strict  bool
+}
+
+// Mode describes the mode of a configuration
+func Mode(c *Config) string {
+	if c.Strict {
+		return "strict"
+	}
+	return "lenient"
+}
+
+// Describe describes the configuration for a run
+func Describe(c Config, run int) string {
+	if c.Verbose {
+		return "verbose"
+	}
+	return "quiet"
+}
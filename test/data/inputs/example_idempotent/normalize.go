@@ -0,0 +1,37 @@
+package idempotent
+
+import "strings"
+
+// Normalize lower cases and trims a string
+// final-unit:idempotent
+func Normalize(s string) string {
+	return strings.ToLower(trim(s))
+}
+
+// Clamp clamps the bounds of a range to be positive
+// final-unit:idempotent
+func Clamp(low, high int) (int, int) {
+	if low < 0 {
+		low = 0
+	}
+	if high < low {
+		high = low
+	}
+	return low, high
+}
+
+func trim(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// Counter counts normalized values
+type Counter struct {
+	Seen int
+}
+
+// Normalize normalizes a value and counts it
+// final-unit:idempotent
+func (c *Counter) Normalize(s string) string {
+	c.Seen++
+	return Normalize(s)
+}
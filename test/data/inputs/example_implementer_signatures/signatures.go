@@ -0,0 +1,100 @@
+package signatures
+
+import (
+	"github.com/wimspaargaren/final-unit/test/data/inputs/example_implementer_signatures/store"
+)
+
+// Record record of the package itself, which differs from store.Record
+type Record struct {
+	Key string
+}
+
+// Node attachable node
+type Node struct {
+	Name string
+}
+
+// Other node of another kind
+type Other struct {
+	Name string
+}
+
+// Writer writes bytes and attaches nodes
+type Writer interface {
+	Write(p []byte) (int, error)
+	Attach(n *Node)
+}
+
+// Buffer implements Writer
+type Buffer struct {
+	Size int
+}
+
+// Write writes p
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.Size += len(p)
+	return len(p), nil
+}
+
+// Attach attaches n
+func (b *Buffer) Attach(n *Node) {}
+
+// Lines writes strings instead of bytes, so it doesn't implement Writer
+type Lines struct {
+	Count int
+}
+
+// Write writes p
+func (l *Lines) Write(p []string) (int, error) {
+	l.Count += len(p)
+	return len(p), nil
+}
+
+// Attach attaches n
+func (l *Lines) Attach(n *Node) {}
+
+// Detached attaches other nodes, so it doesn't implement Writer
+type Detached struct {
+	Count int
+}
+
+// Write writes p
+func (d *Detached) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Attach attaches n
+func (d *Detached) Attach(n *Other) {}
+
+// Memory implements store.Sink
+type Memory struct {
+	Size int
+}
+
+// Put stores r
+func (m *Memory) Put(r *store.Record) error {
+	m.Size++
+	return nil
+}
+
+// Local stores records of the package itself, so it doesn't implement store.Sink
+type Local struct {
+	Size int
+}
+
+// Put stores r
+func (l *Local) Put(r *Record) error {
+	l.Size++
+	return nil
+}
+
+// Flush writes the data to w
+func Flush(w Writer, data []byte) int {
+	n, _ := w.Write(data)
+	return n
+}
+
+// Save stores a record in s
+func Save(s store.Sink, key string) error {
+	return s.Put(&store.Record{Key: key})
+}
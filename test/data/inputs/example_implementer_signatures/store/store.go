@@ -0,0 +1,11 @@
+package store
+
+// Record stored record
+type Record struct {
+	Key string
+}
+
+// Sink stores records
+type Sink interface {
+	Put(r *Record) error
+}
@@ -0,0 +1,33 @@
+package diamond
+
+// Closer closes a resource
+type Closer interface {
+	Close() error
+}
+
+// Reader reads from a resource
+type Reader interface {
+	Closer
+	Read() string
+}
+
+// Writer writes to a resource
+type Writer interface {
+	Closer
+	Write(msg string) error
+}
+
+// ReadWriter embeds Closer twice through Reader and Writer
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+// Echo writes what it reads and closes the resource
+func Echo(rw ReadWriter) error {
+	err := rw.Write(rw.Read())
+	if err != nil {
+		return err
+	}
+	return rw.Close()
+}
@@ -0,0 +1,34 @@
+package embedimport
+
+import (
+	"fmt"
+	"io"
+)
+
+// Source reader with a name
+type Source interface {
+	io.Reader
+	Name() string
+}
+
+// Describe reads the first bytes of the source and describes it
+func Describe(src Source) string {
+	buf := make([]byte, 4)
+	n, err := src.Read(buf)
+	if err != nil {
+		return src.Name()
+	}
+	return src.Name() + ": " + string(buf[:n])
+}
+
+// Snapshot value which can be written and formatted
+type Snapshot interface {
+	io.WriterTo
+	fmt.Formatter
+	Version() int
+}
+
+// Latest retrieves the version of the snapshot
+func Latest(snapshot Snapshot) int {
+	return snapshot.Version()
+}
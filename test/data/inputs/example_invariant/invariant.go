@@ -0,0 +1,20 @@
+package invariant
+
+// Reverse reverses the order of the input
+// final-unit:invariant len(result) == len(input)
+func Reverse(input []int) []int {
+	res := make([]int, len(input))
+	for i, x := range input {
+		res[len(input)-1-i] = x
+	}
+	return res
+}
+
+// Split splits a slice at given index
+// final-unit:invariant len(head)+len(tail) == len(s)
+func Split(s []string, at int) (head, tail []string) {
+	if at < 0 || at > len(s) {
+		return s, nil
+	}
+	return s[:at], s[at:]
+}
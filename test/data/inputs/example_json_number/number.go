@@ -0,0 +1,12 @@
+package jsonnumber
+
+import "encoding/json"
+
+// Cents converts an amount to cents
+func Cents(amount json.Number) (int64, error) {
+	f, err := amount.Float64()
+	if err != nil {
+		return 0, err
+	}
+	return int64(f * 100), nil
+}
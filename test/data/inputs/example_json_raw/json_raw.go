@@ -0,0 +1,13 @@
+package jsonraw
+
+import "encoding/json"
+
+// Decode decodes a raw JSON message
+func Decode(msg json.RawMessage) (interface{}, error) {
+	var res interface{}
+	err := json.Unmarshal(msg, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,26 @@
+package jsonrawfield
+
+import "encoding/json"
+
+// Event event with a payload which is decoded depending on its kind
+type Event struct {
+	Kind    string
+	Payload json.RawMessage
+	Meta    *json.RawMessage
+}
+
+// Envelope wraps multiple events
+type Envelope struct {
+	Events []Event
+	Extra  map[string]json.RawMessage
+}
+
+// Encode encodes an event
+func Encode(e Event) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// Count counts the events of an envelope
+func Count(e Envelope) int {
+	return len(e.Events) + len(e.Extra)
+}
@@ -0,0 +1,16 @@
+//go:build go1.18
+// +build go1.18
+
+package mapany
+
+import "fmt"
+
+// Count counts the keys of a map keyed by any
+func Count(m map[any]int) int {
+	return len(m)
+}
+
+// Describe describes the keys of a map keyed by an empty interface
+func Describe(m map[interface{}]fmt.Stringer) string {
+	return fmt.Sprint(len(m))
+}
@@ -0,0 +1,12 @@
+package mapfield
+
+// Index index of values per key
+type Index struct {
+	Data map[string][]int
+}
+
+// Add adds a value to the index, which panics if the map of the index is nil
+func Add(index Index, key string, value int) int {
+	index.Data[key] = append(index.Data[key], value)
+	return len(index.Data[key])
+}
@@ -0,0 +1,34 @@
+package example
+
+// Chain linked list of which long chains are of interest
+// final-unit:max-depth Chain 6
+type Chain struct {
+	Next  *Chain
+	Value int
+}
+
+// List linked list generated with the global max recursion
+type List struct {
+	Next  *List
+	Value int
+}
+
+// ChainLength counts the links of a chain
+func ChainLength(c *Chain) int {
+	length := 0
+	for c != nil {
+		length++
+		c = c.Next
+	}
+	return length
+}
+
+// ListLength counts the elements of a list
+func ListLength(l *List) int {
+	length := 0
+	for l != nil {
+		length++
+		l = l.Next
+	}
+	return length
+}
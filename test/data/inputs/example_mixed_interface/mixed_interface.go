@@ -0,0 +1,48 @@
+package example
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Celsius temperature in degrees Celsius
+type Celsius float64
+
+// String prints the temperature
+func (c Celsius) String() string {
+	return fmt.Sprintf("%.1f°C", float64(c))
+}
+
+// Point point on a plane
+type Point struct {
+	X, Y int
+}
+
+// String prints the point
+func (p *Point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// Code numeric code, which doesn't implement fmt.Stringer
+type Code int
+
+// String prints the code in a given base
+func (c Code) String(base int) string {
+	return fmt.Sprintf("%d", int(c))
+}
+
+// Describe describes every item depending on its type
+func Describe(items []fmt.Stringer) string {
+	parts := []string{}
+	for _, item := range items {
+		switch v := item.(type) {
+		case Celsius:
+			parts = append(parts, "temperature "+v.String())
+		case *Point:
+			parts = append(parts, "point "+v.String())
+		default:
+			parts = append(parts, v.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
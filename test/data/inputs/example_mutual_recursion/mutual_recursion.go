@@ -0,0 +1,22 @@
+package mutualrecursion
+
+// Employee employee reporting to a manager
+type Employee struct {
+	Name    string
+	Manager *Manager
+}
+
+// Manager manager of a team of employees
+type Manager struct {
+	Self    *Employee
+	Reports []*Employee
+}
+
+// ChainLength counts the managers above the employee
+func ChainLength(e Employee) int {
+	res := 0
+	for m := e.Manager; m != nil && m.Self != nil; m = m.Self.Manager {
+		res++
+	}
+	return res
+}
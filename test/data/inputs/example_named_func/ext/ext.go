@@ -0,0 +1,4 @@
+package ext
+
+// Middleware transforms a value
+type Middleware func(x int) int
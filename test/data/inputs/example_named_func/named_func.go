@@ -0,0 +1,19 @@
+package namedfunc
+
+import "github.com/wimspaargaren/final-unit/test/data/inputs/example_named_func/ext"
+
+// Handler handles a value
+type Handler func(int) int
+
+var handlers []Handler
+
+// Register registers a handler
+func Register(h Handler) int {
+	handlers = append(handlers, h)
+	return h(len(handlers))
+}
+
+// Use applies a middleware of another package
+func Use(m ext.Middleware) int {
+	return m(1)
+}
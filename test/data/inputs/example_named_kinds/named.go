@@ -0,0 +1,42 @@
+package example
+
+// Signal signals completion
+type Signal chan struct{}
+
+// Results stream of results
+type Results <-chan int
+
+// Handler handles a request
+type Handler func(path string) int
+
+// Router routes paths to handlers
+type Router map[string]Handler
+
+// Wait waits until the signal is closed or sent on
+func Wait(s Signal) bool {
+	_, ok := <-s
+	return ok
+}
+
+// Sum sums the results until the channel is closed
+func Sum(r Results) int {
+	sum := 0
+	for x := range r {
+		sum += x
+	}
+	return sum
+}
+
+// Handle calls the handler of a path
+func Handle(r Router, path string) int {
+	h, ok := r[path]
+	if !ok {
+		return 404
+	}
+	return h(path)
+}
+
+// Call calls the handler
+func Call(h Handler) int {
+	return h("/")
+}
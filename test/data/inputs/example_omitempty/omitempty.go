@@ -0,0 +1,18 @@
+package omitempty
+
+import "encoding/json"
+
+// User user which is serialised to json
+type User struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname,omitempty"`
+}
+
+// Marshal marshals a user to json
+func Marshal(u User) (string, error) {
+	b, err := json.Marshal(u)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
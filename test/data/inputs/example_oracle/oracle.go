@@ -0,0 +1,53 @@
+package oracle
+
+// Sum sums the input using loop unrolling
+// final-unit:oracle naiveSum
+func Sum(input []int) int {
+	res := 0
+	i := 0
+	for ; i+1 < len(input); i += 2 {
+		res += input[i] + input[i+1]
+	}
+	if i < len(input) {
+		res += input[i]
+	}
+	return res
+}
+
+func naiveSum(input []int) int {
+	res := 0
+	for _, x := range input {
+		res += x
+	}
+	return res
+}
+
+// MinMax retrieves the minimum and maximum of the input in a single pass
+// final-unit:oracle naiveMinMax
+func MinMax(input []int) (int, int) {
+	if len(input) == 0 {
+		return 0, 0
+	}
+	min, max := input[0], input[0]
+	for _, x := range input[1:] {
+		if x < min {
+			min = x
+		} else if x > max {
+			max = x
+		}
+	}
+	return min, max
+}
+
+func naiveMinMax(input []int) (int, int) {
+	min, max := 0, 0
+	for i, x := range input {
+		if i == 0 || x < min {
+			min = x
+		}
+		if i == 0 || x > max {
+			max = x
+		}
+	}
+	return min, max
+}
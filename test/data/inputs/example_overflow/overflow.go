@@ -0,0 +1,17 @@
+package overflow
+
+// Add adds two integers
+func Add(a, b int) int {
+	return a + b
+}
+
+// Scale scales a small integer
+func Scale(x int8, factor uint16) int {
+	x *= 2
+	return int(x) * int(factor)
+}
+
+// Sub subtracts two integers
+func Sub(a, b int) int {
+	return a - b
+}
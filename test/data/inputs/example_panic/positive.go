@@ -0,0 +1,11 @@
+package panics
+
+import "fmt"
+
+// MustPositive returns x, panicking if it's negative
+func MustPositive(x int) int {
+	if x < 0 {
+		panic(fmt.Sprintf("negative value: %d", x))
+	}
+	return x
+}
@@ -0,0 +1,29 @@
+package pointerconstructor
+
+import "strings"
+
+// Service a service which must be created using its constructor
+type Service struct {
+	name  string
+	calls map[string]int
+}
+
+// NewService creates a new service
+func NewService(name string) *Service {
+	return &Service{
+		name:  name,
+		calls: make(map[string]int),
+	}
+}
+
+// Call calls the service
+func Call(svc *Service, method string) int {
+	svc.calls[method]++
+	return svc.calls[method]
+}
+
+// Join joins the strings of a builder
+func Join(b *strings.Builder, s string) string {
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package example
+
+// Shape shape which can be scaled
+type Shape interface {
+	Area() int
+	Scale(factor int)
+}
+
+// Square implements Shape through pointer receivers only, so only *Square is a Shape
+type Square struct {
+	Side int
+}
+
+// Area calculates the area of the square
+func (s *Square) Area() int {
+	return s.Side * s.Side
+}
+
+// Scale scales the square
+func (s *Square) Scale(factor int) {
+	s.Side *= factor
+}
+
+// Grow scales the shape and retrieves the new area
+func Grow(s Shape, factor int) int {
+	if _, ok := s.(*Square); ok {
+		factor++
+	}
+	s.Scale(factor)
+	return s.Area()
+}
@@ -0,0 +1,26 @@
+package ext
+
+// Counter counter which is embedded by other packages
+type Counter struct {
+	N int
+}
+
+// Add adds x to the counter
+func (c *Counter) Add(x int) int {
+	c.N += x
+	return c.N
+}
+
+// Value returns the value of the counter
+func (c Counter) Value() int {
+	return c.N
+}
+
+// Merge merges other into the counter
+func (c *Counter) Merge(other Counter) {
+	c.N += other.N
+}
+
+func (c *Counter) reset() {
+	c.N = 0
+}
@@ -0,0 +1,17 @@
+package ext
+
+// Gauge gauge which is embedded together with the counter by other packages
+type Gauge struct {
+	Level float64
+}
+
+// Add adds x to the gauge, conflicting with the method of the counter if both are embedded
+func (g *Gauge) Add(x int) int {
+	g.Level += float64(x)
+	return int(g.Level)
+}
+
+// Ratio returns the level of the gauge relative to max
+func (g Gauge) Ratio(max float64) float64 {
+	return g.Level / max
+}
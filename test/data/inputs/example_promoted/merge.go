@@ -0,0 +1,8 @@
+package promoted
+
+import "github.com/wimspaargaren/final-unit/test/data/inputs/example_promoted/ext"
+
+// Merge shadows the promoted method of the counter in another file than the one declaring the type
+func (w *Wrapper) Merge(other ext.Counter) {
+	w.N += other.N * 2
+}
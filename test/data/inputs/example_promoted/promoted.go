@@ -0,0 +1,14 @@
+package promoted
+
+import "github.com/wimspaargaren/final-unit/test/data/inputs/example_promoted/ext"
+
+// Wrapper embeds a counter of another package
+type Wrapper struct {
+	ext.Counter
+	Name string
+}
+
+// Value shadows the promoted method of the counter
+func (w Wrapper) Value() int {
+	return w.N * 2
+}
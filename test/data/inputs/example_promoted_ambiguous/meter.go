@@ -0,0 +1,21 @@
+package ambiguous
+
+import "github.com/wimspaargaren/final-unit/test/data/inputs/example_promoted/ext"
+
+// Label label of a meter
+type Label struct {
+	Text string
+}
+
+// Value returns the text of the label
+func (l Label) Value() string {
+	return l.Text
+}
+
+// Meter embeds a counter, a gauge and a label. Add is provided by the counter and the gauge,
+// Value by the counter and the label, so the selectors meter.Add and meter.Value are ambiguous
+type Meter struct {
+	ext.Counter
+	ext.Gauge
+	Label
+}
@@ -0,0 +1,30 @@
+package pure
+
+import "sort"
+
+// Sum sums the values
+// final-unit:pure
+func Sum(values []int) int {
+	res := 0
+	for _, v := range values {
+		res += v
+	}
+	return res
+}
+
+// Median retrieves the median of the values, but sorts the values in place
+// final-unit:pure
+func Median(values []int) int {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Ints(values)
+	return values[len(values)/2]
+}
+
+// Double doubles the values in place
+func Double(values []int) {
+	for i := range values {
+		values[i] *= 2
+	}
+}
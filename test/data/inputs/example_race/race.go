@@ -0,0 +1,24 @@
+package race
+
+var total int
+
+// Add adds given amount to the running total without synchronization
+func Add(amount int) {
+	total += amount
+}
+
+// Total retrieves the running total
+func Total() int {
+	return total
+}
+
+// Counter counts events
+type Counter struct {
+	total int
+}
+
+// Add adds given amount to the counter, its total field shadows no package state
+func (c *Counter) Add(total int) int {
+	c.total += total
+	return c.total
+}
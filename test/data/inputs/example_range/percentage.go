@@ -0,0 +1,24 @@
+package ranges
+
+import "fmt"
+
+// Percentage calculates the given percentage of total
+// final-unit:range percent 0 100
+func Percentage(total int, percent int) int {
+	if percent < 0 || percent > 100 {
+		panic(fmt.Sprintf("invalid percentage: %d", percent))
+	}
+	return total * percent / 100
+}
+
+// Scale scales value by a factor between zero and one
+// final-unit:range factor 0 1
+func Scale(value float64, factor float64) float64 {
+	return value * factor
+}
+
+// Level clamps the level to the maximum of an int8
+// final-unit:range level 0 1000
+func Level(level int8) int8 {
+	return level
+}
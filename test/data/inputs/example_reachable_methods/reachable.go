@@ -0,0 +1,24 @@
+package reachable
+
+// Server generated server interface with many methods
+type Server interface {
+	Name() string
+	Version() int
+	Ping(msg string) (string, error)
+	Get(id int) []byte
+	List(offset, limit int) ([]string, error)
+	Delete(id int) error
+	Stats() map[string]int
+}
+
+// Describe describes the server, calling its version in a helper
+func Describe(s Server) string {
+	if version(s) > 1 {
+		return "v2 " + s.Name()
+	}
+	return "v1 " + s.Name()
+}
+
+func version(s Server) int {
+	return s.Version()
+}
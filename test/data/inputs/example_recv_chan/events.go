@@ -0,0 +1,25 @@
+package events
+
+// Kind kind of an event
+type Kind struct {
+	Name     string
+	Priority int
+}
+
+// Event event emitted by a source
+type Event struct {
+	ID   int
+	Kind Kind
+	Tags []string
+}
+
+// CountHighPriority counts the received events with a priority above given threshold
+func CountHighPriority(events <-chan Event, threshold int) int {
+	count := 0
+	for event := range events {
+		if event.Kind.Priority > threshold {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,11 @@
+package reflection
+
+import "reflect"
+
+// Kind retrieves the kind name of a reflected value
+func Kind(v reflect.Value) string {
+	if !v.IsValid() {
+		return "invalid"
+	}
+	return v.Kind().String()
+}
@@ -0,0 +1,21 @@
+package example
+
+import (
+	"regexp"
+)
+
+// Rule validation rule matching values
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Match reports whether value matches given expression
+func Match(re *regexp.Regexp, value string) bool {
+	return re.MatchString(value)
+}
+
+// Validate validates a value using a rule
+func Validate(r Rule, value string) bool {
+	return r.Pattern.MatchString(value)
+}
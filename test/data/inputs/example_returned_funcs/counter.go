@@ -0,0 +1,17 @@
+package returnedfuncs
+
+// Counter returns a closure counting its calls, starting after start
+func Counter(start int) func() int {
+	count := start
+	return func() int {
+		count++
+		return count
+	}
+}
+
+// Scale returns a closure multiplying its input by factor
+func Scale(factor int) func(int) int {
+	return func(x int) int {
+		return x * factor
+	}
+}
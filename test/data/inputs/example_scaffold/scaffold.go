@@ -0,0 +1,41 @@
+package scaffold
+
+import (
+	"errors"
+	"strings"
+)
+
+// Greeter greets
+type Greeter interface {
+	Greet() string
+}
+
+type greeter struct {
+	name string
+}
+
+func (g greeter) Greet() string {
+	return "hello " + g.name
+}
+
+// Split splits a full name in a first and last name
+func Split(name string) (first, last string, err error) {
+	parts := strings.Fields(name)
+	if len(parts) != 2 {
+		return "", "", errors.New("expected first and last name")
+	}
+	return parts[0], parts[1], nil
+}
+
+// Lookup looks up the greeter of a user
+func Lookup(id int) (Greeter, bool) {
+	if id < 0 {
+		return nil, false
+	}
+	return greeter{name: "user"}, true
+}
+
+// Log logs a message
+func Log(msg string) {
+	_ = msg
+}
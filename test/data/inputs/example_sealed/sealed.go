@@ -0,0 +1,16 @@
+package sealed
+
+import (
+	"github.com/wimspaargaren/final-unit/test/data/inputs/example_sealed/shape"
+)
+
+func Area(s shape.Shape) float64 {
+	return s.Area()
+}
+
+func Value(t shape.Token) string {
+	if t == nil {
+		return ""
+	}
+	return t.Value()
+}
@@ -0,0 +1,31 @@
+package shape
+
+// Shape sealed interface, which can only be implemented in this package
+type Shape interface {
+	Area() float64
+	sealed()
+}
+
+// Token sealed interface without exported implementers
+type Token interface {
+	Value() string
+	sealed()
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s *Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+func (s *Square) sealed() {}
+
+type word struct{}
+
+func (w word) Value() string {
+	return "word"
+}
+
+func (w word) sealed() {}
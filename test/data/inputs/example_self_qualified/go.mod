@@ -0,0 +1,5 @@
+// The example refers to its own package by name, which doesn't compile, so it's excluded from the module
+
+module github.com/wimspaargaren/final-unit/test/data/inputs/example_self_qualified
+
+go 1.15
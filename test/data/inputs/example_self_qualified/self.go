@@ -0,0 +1,15 @@
+package self
+
+// Bar type referenced using the package its own name
+type Bar struct {
+	X int
+}
+
+// Level named basic type
+type Level int
+
+// Use uses self qualified types
+func Use(b self.Bar, p *self.Bar, l self.Level) self.Bar {
+	b.X += p.X + int(l)
+	return b
+}
@@ -0,0 +1,16 @@
+package sideeffects
+
+// Counter counts hits
+type Counter struct {
+	Hits int
+}
+
+// Increment increments the hits of the counter by given amount
+func Increment(c *Counter, amount int) {
+	c.Hits += amount
+}
+
+// Add adds a to b
+func Add(a, b int) int {
+	return a + b
+}
@@ -0,0 +1,14 @@
+package signal
+
+// Wait waits until done is signalled and returns the amount of received values
+func Wait(done <-chan struct{}, values <-chan int) int {
+	count := 0
+	for {
+		select {
+		case <-done:
+			return count
+		case <-values:
+			count++
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package orders
+
+import "time"
+
+// Customer customer placing orders
+type Customer struct {
+	ID   string
+	Name string
+}
+
+// Order order placed by a customer
+type Order struct {
+	ID        int
+	Amount    int
+	CreatedAt time.Time
+	Customer  Customer
+}
+
+// NewOrder creates an order of given amount for a customer
+func NewOrder(amount int, customer string) Order {
+	return Order{
+		ID:        amount * 7,
+		Amount:    amount,
+		CreatedAt: time.Now(),
+		Customer:  Customer{ID: customer, Name: customer},
+	}
+}
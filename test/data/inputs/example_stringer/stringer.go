@@ -0,0 +1,53 @@
+package stringer
+
+import "fmt"
+
+// Color a display color
+type Color int
+
+// String returns the name of the color
+func (c Color) String() string {
+	switch c {
+	case 0:
+		return "red"
+	case 1:
+		return "green"
+	default:
+		return fmt.Sprintf("Color(%d)", int(c))
+	}
+}
+
+// Point a point on a grid
+type Point struct {
+	X, Y int
+}
+
+// String formats the point
+func (p *Point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// Size a size which isn't a stringer
+type Size struct {
+	Width, Height int
+}
+
+// Mix mixes two colors
+func Mix(a, b Color) Color {
+	return (a + b) % 3
+}
+
+// Move moves a point
+func Move(p Point, dx, dy int) *Point {
+	return &Point{X: p.X + dx, Y: p.Y + dy}
+}
+
+// Palette returns a palette of colors
+func Palette(base Color) map[string]Color {
+	return map[string]Color{"base": base, "next": base + 1}
+}
+
+// Grow grows a size
+func Grow(s Size, n int) Size {
+	return Size{Width: s.Width + n, Height: s.Height + n}
+}
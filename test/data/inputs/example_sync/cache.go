@@ -0,0 +1,35 @@
+package synctypes
+
+import "sync"
+
+// Count counts the entries of the cache
+func Count(cache *sync.Map) int {
+	count := 0
+	cache.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// Run runs f once while holding the lock and waits for the group
+func Run(once *sync.Once, wg *sync.WaitGroup, mu *sync.Mutex, f func()) {
+	mu.Lock()
+	once.Do(f)
+	mu.Unlock()
+	wg.Wait()
+}
+
+// Guarded counter guarded by a mutex, which is held by value
+type Guarded struct {
+	mu sync.Mutex
+	N  int
+}
+
+// Inc increments the counter
+func Inc(g *Guarded) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.N++
+	return g.N
+}
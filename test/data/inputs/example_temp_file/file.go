@@ -0,0 +1,16 @@
+package example
+
+import (
+	"bufio"
+	"os"
+)
+
+// CountLines counts the lines of a file
+func CountLines(f *os.File) (int, error) {
+	scanner := bufio.NewScanner(f)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, scanner.Err()
+}
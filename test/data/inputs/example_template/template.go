@@ -0,0 +1,38 @@
+package example
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+// Page page rendered using a layout
+type Page struct {
+	Title  string
+	Layout *htmltemplate.Template
+}
+
+// Render renders given template with data
+func Render(t *template.Template, data string) (string, error) {
+	b := &strings.Builder{}
+	err := t.Execute(b, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// RenderPage renders the title of a page using its layout
+func RenderPage(p Page) (string, error) {
+	b := &strings.Builder{}
+	err := p.Layout.Execute(b, p.Title)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Name retrieves the name of a template
+func Name(t template.Template) string {
+	return t.Name()
+}
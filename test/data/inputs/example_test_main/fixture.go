@@ -0,0 +1,21 @@
+package example
+
+import "errors"
+
+var store map[string]int
+
+// openStore sets up the store shared by the tests
+// final-unit:setup
+func openStore() error {
+	if store != nil {
+		return errors.New("store already open")
+	}
+	store = map[string]int{}
+	return nil
+}
+
+// closeStore tears down the store shared by the tests
+// final-unit:teardown
+func closeStore() {
+	store = nil
+}
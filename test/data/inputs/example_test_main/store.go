@@ -0,0 +1,12 @@
+package example
+
+// Put stores the value of given key
+func Put(key string, value int) {
+	store[key] = value
+}
+
+// Get retrieves the value of given key
+func Get(key string) (int, bool) {
+	value, ok := store[key]
+	return value, ok
+}
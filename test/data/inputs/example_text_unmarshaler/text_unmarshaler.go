@@ -0,0 +1,68 @@
+package textunmarshaler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Email email address which is validated on construction
+type Email struct {
+	local  string
+	domain string
+}
+
+// UnmarshalText parses and validates an email address
+func (e *Email) UnmarshalText(text []byte) error {
+	parts := strings.Split(string(text), "@")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid email address: %s", text)
+	}
+	e.local = parts[0]
+	e.domain = parts[1]
+	return nil
+}
+
+// Level level which can't be unmarshalled as the receiver is not a pointer
+type Level struct {
+	Value int
+}
+
+// UnmarshalText has a value receiver and therefore can't be used for generation
+func (l Level) UnmarshalText(text []byte) error {
+	return nil
+}
+
+// Domain returns the domain of an email address
+func Domain(e Email) string {
+	return e.domain
+}
+
+// LevelValue returns the value of a level
+func LevelValue(l Level) int {
+	return l.Value
+}
+
+// Color color of which only the declared colors are valid
+type Color string
+
+// Declared colors
+const (
+	ColorRed  Color = "red"
+	ColorBlue Color = "blue"
+)
+
+// UnmarshalText parses and validates a color
+func (c *Color) UnmarshalText(text []byte) error {
+	switch Color(text) {
+	case ColorRed, ColorBlue:
+		*c = Color(text)
+		return nil
+	default:
+		return fmt.Errorf("unknown color: %s", text)
+	}
+}
+
+// IsRed checks if a color is red
+func IsRed(c Color) bool {
+	return c == ColorRed
+}
@@ -0,0 +1,25 @@
+package timefield
+
+import "time"
+
+// Record a stored record
+type Record struct {
+	ID        int
+	CreatedAt time.Time
+}
+
+// NewRecord creates a record created now
+func NewRecord(id int) Record {
+	return Record{
+		ID:        id,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Epoch creates a record created at the start of given year
+func Epoch(id int, year int) Record {
+	return Record{
+		ID:        id,
+		CreatedAt: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
@@ -0,0 +1,14 @@
+package typednil
+
+// Logger logs messages
+type Logger interface {
+	Log(msg string) string
+}
+
+// Describe describes whether a logger is present
+func Describe(l Logger) string {
+	if l == nil {
+		return "no logger"
+	}
+	return l.Log("present")
+}
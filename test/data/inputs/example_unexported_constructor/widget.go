@@ -0,0 +1,66 @@
+package widget
+
+import (
+	"strings"
+	"sync"
+)
+
+type widget struct {
+	name string
+	size int
+}
+
+// NewWidget creates a widget with given name and size
+func NewWidget(name string, size int) *widget {
+	if size < 1 {
+		size = 1
+	}
+	return &widget{name: name, size: size}
+}
+
+// Render renders a widget
+func Render(w widget) string {
+	return strings.Repeat(w.name, w.size)
+}
+
+type gauge struct {
+	level int
+}
+
+// NewGauge creates a gauge with given level
+func NewGauge(level int) gauge {
+	return gauge{level: level}
+}
+
+// Read reads the level of a gauge
+func Read(g gauge) int {
+	return g.level
+}
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+// NewCounter creates a counter starting at n
+func NewCounter(n int) *counter {
+	return &counter{n: n}
+}
+
+// Incr increments a counter
+func Incr(c *counter) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+	return c.n
+}
+
+type panel struct {
+	title string
+	c     counter
+}
+
+// Title retrieves the title of a panel
+func Title(p *panel) string {
+	return p.title
+}
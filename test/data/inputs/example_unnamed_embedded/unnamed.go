@@ -0,0 +1,35 @@
+package unnamedembedded
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// Count reads all input of an anonymous struct embedding a reader
+func Count(x struct {
+	io.Reader
+	N int
+}) int {
+	b, err := ioutil.ReadAll(x)
+	if err != nil {
+		return -1
+	}
+	return len(b) + x.N
+}
+
+// Shout shouts the name of an anonymous struct embedding a locally declared interface
+func Shout(x struct {
+	Namer
+	Times int
+}) string {
+	res := ""
+	for i := 0; i < x.Times; i++ {
+		res += x.Name()
+	}
+	return res
+}
+
+// Namer names things
+type Namer interface {
+	Name() string
+}
@@ -0,0 +1,20 @@
+package unordered
+
+// Keys retrieves the keys of a map, in map iteration order
+// final-unit:unordered
+func Keys(m map[string]int) []string {
+	res := []string{}
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
+
+// Values retrieves the values of a map, in map iteration order
+func Values(m map[string]int) []int {
+	res := []int{}
+	for _, v := range m {
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,5 @@
+// The example imports an unresolvable package, so it's excluded from the module
+
+module github.com/wimspaargaren/final-unit/test/data/inputs/example_unresolved_array
+
+go 1.15
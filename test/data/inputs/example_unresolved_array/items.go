@@ -0,0 +1,13 @@
+package items
+
+import "github.com/unknown/ext"
+
+// Count counts the items
+func Count(items []ext.Item) int {
+	return len(items)
+}
+
+// Double doubles the value
+func Double(x int) int {
+	return x * 2
+}
@@ -0,0 +1,5 @@
+package budget
+
+func Nested(x [][]map[string][]int) int {
+	return len(x)
+}
@@ -0,0 +1,47 @@
+package variadic
+
+import "time"
+
+// Item item identified by its name
+type Item string
+
+// Priority priority of a task
+type Priority int
+
+// Task task with a priority
+type Task struct {
+	Name     string
+	Priority Priority
+}
+
+// Join joins the items
+func Join(sep string, items ...Item) string {
+	res := ""
+	for i, item := range items {
+		if i > 0 {
+			res += sep
+		}
+		res += string(item)
+	}
+	return res
+}
+
+// Highest retrieves the highest priority of the tasks
+func Highest(tasks ...*Task) Priority {
+	var res Priority
+	for _, t := range tasks {
+		if t != nil && t.Priority > res {
+			res = t.Priority
+		}
+	}
+	return res
+}
+
+// Total sums the durations
+func Total(durations ...time.Duration) time.Duration {
+	var res time.Duration
+	for _, d := range durations {
+		res += d
+	}
+	return res
+}
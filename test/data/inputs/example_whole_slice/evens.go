@@ -0,0 +1,25 @@
+package wholeslice
+
+// Point point on a grid
+type Point struct {
+	X int
+	y int
+}
+
+// Evens retrieves the even numbers below n
+func Evens(n int) []int {
+	res := []int{}
+	for i := 0; i < n && i < 10; i += 2 {
+		res = append(res, i)
+	}
+	return res
+}
+
+// Diagonal retrieves points on the diagonal
+func Diagonal(n int) []Point {
+	res := []Point{}
+	for i := 0; i < n && i < 3; i++ {
+		res = append(res, Point{X: i, y: i})
+	}
+	return res
+}
@@ -0,0 +1,24 @@
+package wrappederror
+
+import "errors"
+
+// NotFoundError error indicating a missing resource
+type NotFoundError struct {
+	Resource string
+}
+
+func (e *NotFoundError) Error() string {
+	return e.Resource + " not found"
+}
+
+// StatusCode maps an error to a status code
+func StatusCode(err error) int {
+	if err == nil {
+		return 200
+	}
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		return 404
+	}
+	return 500
+}